@@ -19,14 +19,14 @@ func (c *AIClient) GenerateChatResponse(sessionID string, conversationHistory []
 
 // GenerateChatResponseWithLanguage generates AI response with language support
 func (c *AIClient) GenerateChatResponseWithLanguage(sessionID string, conversationHistory []map[string]string, userMessage string, language string) (string, error) {
+	return c.GenerateChatResponseWithOptions(sessionID, conversationHistory, userMessage, ChatOptions{Language: language})
+}
+
+// GenerateChatResponseWithOptions generates AI response using per-interview settings
+func (c *AIClient) GenerateChatResponseWithOptions(sessionID string, conversationHistory []map[string]string, userMessage string, opts ChatOptions) (string, error) {
 	// Build context for the AI including conversation history and language
-	contextMap := map[string]interface{}{
-		"interview_type":       "general",
-		"job_title":            "Software Engineer",
-		"context":              "Interview in progress",
-		"conversation_history": conversationHistory,
-		"language":             language,
-	}
+	contextMap := buildChatContext(opts, conversationHistory)
+	contextMap["context"] = "Interview in progress"
 
 	return c.enhancedClient.GenerateInterviewResponse(sessionID, userMessage, contextMap)
 }
@@ -38,17 +38,51 @@ func (c *AIClient) GenerateClosingMessage(sessionID string, conversationHistory
 
 // GenerateClosingMessageWithLanguage generates a closing AI response with language support
 func (c *AIClient) GenerateClosingMessageWithLanguage(sessionID string, conversationHistory []map[string]string, userMessage string, language string) (string, error) {
+	return c.GenerateClosingMessageWithOptions(sessionID, conversationHistory, userMessage, ChatOptions{Language: language})
+}
+
+// GenerateClosingMessageWithOptions generates a closing AI response using per-interview settings
+func (c *AIClient) GenerateClosingMessageWithOptions(sessionID string, conversationHistory []map[string]string, userMessage string, opts ChatOptions) (string, error) {
 	// Build context for the AI to indicate this is the final message
-	contextMap := map[string]interface{}{
-		"interview_type":       "general",
+	contextMap := buildChatContext(opts, conversationHistory)
+	contextMap["context"] = "This is the final message - wrap up the interview professionally and thank the candidate"
+	contextMap["closing_interview"] = true
+
+	return c.enhancedClient.GenerateInterviewResponse(sessionID, userMessage, contextMap)
+}
+
+// GenerateWelcomeBackMessageWithOptions generates a short message for a candidate resuming a paused
+// interview, picking up from the given stage (see the InterviewStage constants)
+func (c *AIClient) GenerateWelcomeBackMessageWithOptions(sessionID string, conversationHistory []map[string]string, stage string, opts ChatOptions) (string, error) {
+	contextMap := buildChatContext(opts, conversationHistory)
+	contextMap["context"] = "The candidate is resuming the interview after a pause"
+	contextMap["interview_stage"] = stage
+	contextMap["resuming_interview"] = true
+
+	return c.enhancedClient.GenerateInterviewResponse(sessionID, "", contextMap)
+}
+
+// buildChatContext converts chat options into the context map consumed by the enhanced client
+func buildChatContext(opts ChatOptions, conversationHistory []map[string]string) map[string]interface{} {
+	interviewType := opts.InterviewType
+	if interviewType == "" {
+		interviewType = "general"
+	}
+	language := opts.Language
+	if language == "" {
+		language = "en"
+	}
+
+	return map[string]interface{}{
+		"interview_type":       interviewType,
 		"job_title":            "Software Engineer",
-		"context":              "This is the final message - wrap up the interview professionally and thank the candidate",
+		"job_description":      opts.JobDescription,
+		"persona":              opts.Persona,
+		"resume_text":          opts.ResumeText,
+		"candidate_profile":    opts.Profile,
 		"conversation_history": conversationHistory,
-		"closing_interview":    true,
 		"language":             language,
 	}
-
-	return c.enhancedClient.GenerateInterviewResponse(sessionID, userMessage, contextMap)
 }
 
 // ShouldEndInterview determines if the interview should end
@@ -62,46 +96,96 @@ func (c *AIClient) EvaluateAnswers(questions []string, answers []string, languag
 	return c.EvaluateAnswersWithContext(questions, answers, "General interview evaluation", language)
 }
 
+// DefaultEvaluationCriteria are used when an interview does not specify its own criteria
+var DefaultEvaluationCriteria = []string{"communication", "technical_knowledge", "problem_solving", "clarity", "cultural_fit"}
+
 // EvaluateAnswersWithContext evaluates chat conversation with interview context
 func (c *AIClient) EvaluateAnswersWithContext(questions []string, answers []string, jobDesc, language string) (float64, string, error) {
-	if len(answers) == 0 {
-		return 0.0, "No answers provided.", nil
+	return c.EvaluateAnswersWithCriteria(questions, answers, jobDesc, language, nil)
+}
+
+// EvaluateAnswersWithCriteria evaluates answers against the given criteria, falling back to the defaults when empty
+func (c *AIClient) EvaluateAnswersWithCriteria(questions []string, answers []string, jobDesc, language string, criteria []string) (float64, string, error) {
+	resp, err := c.Evaluate(questions, answers, EvaluationOptions{
+		JobDescription: jobDesc,
+		Language:       language,
+		Criteria:       criteria,
+	})
+	if err != nil {
+		return 0.0, "Evaluation failed", err
 	}
+	return resp.OverallScore, resp.Feedback, nil
+}
 
-	// Use the enhanced AI client for real evaluation with context
-	ctx := context.Background()
+// Evaluate evaluates answers and returns the full result including per-category scores.
+// When a rubric is given the overall score is the weighted mean of the category scores;
+// when an ensemble is given the per-category scores are combined across its models, and
+// when sampling is given the evaluation is repeated and reported with its spread.
+func (c *AIClient) Evaluate(questions []string, answers []string, opts EvaluationOptions) (*EvaluationResponse, error) {
+	return c.EvaluateContext(context.Background(), questions, answers, opts)
+}
+
+// EvaluateContext is Evaluate with a context bounding the provider calls, e.g. a background job's timeout
+func (c *AIClient) EvaluateContext(ctx context.Context, questions []string, answers []string, opts EvaluationOptions) (*EvaluationResponse, error) {
+	criteria := opts.Criteria
+	if len(criteria) == 0 {
+		criteria = DefaultEvaluationCriteria
+	}
+	if len(answers) == 0 {
+		return &EvaluationResponse{
+			OverallScore:   0.0,
+			CategoryScores: map[string]float64{},
+			Feedback:       "No answers provided.",
+			ScoringMethod:  ScoringMethodModel,
+		}, nil
+	}
 
 	// Create evaluation request with proper context including language
 	req := &EvaluationRequest{
 		Questions:   questions,
 		Answers:     answers,
-		JobDesc:     jobDesc,
-		Criteria:    []string{"communication", "technical_knowledge", "problem_solving", "clarity", "cultural_fit"},
+		JobDesc:     opts.JobDescription,
+		Position:    opts.Position,
+		Criteria:    criteria,
+		Rubric:      opts.Rubric,
 		DetailLevel: "detailed",
-		Language:    language, // Pass language for evaluation
+		Language:    opts.Language, // Pass language for evaluation
 		Context: map[string]interface{}{
 			"interview_type":  "conversational",
 			"evaluation_type": "chat_based",
-			"language":        language, // Also include in context map
+			"language":        opts.Language, // Also include in context map
 		},
 	}
 
-	// Call enhanced client for evaluation
-	resp, err := c.enhancedClient.EvaluateAnswers(ctx, req)
-	if err != nil {
-		return 0.0, "Evaluation failed", err
+	// Run on several models when an ensemble is configured, otherwise on the default provider
+	evaluate := c.enhancedClient.EvaluateAnswers
+	if opts.Ensemble != nil && len(opts.Ensemble.Models) > 0 {
+		ensemble := *opts.Ensemble
+		evaluate = func(ctx context.Context, req *EvaluationRequest) (*EvaluationResponse, error) {
+			return c.enhancedClient.EvaluateEnsemble(ctx, req, ensemble)
+		}
 	}
 
-	return resp.OverallScore, resp.Feedback, nil
+	// Repeat the evaluation to measure score consistency when sampling is configured
+	if opts.Sampling != nil && opts.Sampling.Samples > 1 {
+		return evaluateSampled(ctx, req, *opts.Sampling, evaluate)
+	}
+	return evaluate(ctx, req)
 }
 
 // GenerateQuestionsFromResume generates interview questions based on resume and job description
 func (c *AIClient) GenerateQuestionsFromResume(resumeText, jobDescription string) ([]InterviewQuestion, error) {
+	return c.GenerateQuestionsForCandidate(resumeText, nil, jobDescription)
+}
+
+// GenerateQuestionsForCandidate generates interview questions from the resume and, when parsed,
+// the candidate profile, so questions can name specific past projects
+func (c *AIClient) GenerateQuestionsForCandidate(resumeText string, profile *CandidateProfile, jobDescription string) ([]InterviewQuestion, error) {
 	ctx := context.Background()
 
 	req := &QuestionGenerationRequest{
 		JobDescription:  jobDescription,
-		ResumeContent:   resumeText,
+		ResumeContent:   candidateResumeContent(resumeText, profile),
 		InterviewType:   "mixed",
 		NumQuestions:    8,
 		ExperienceLevel: "mid",
@@ -274,7 +274,18 @@ func (c *EnhancedAIClient) EvaluateAnswers(ctx context.Context, req *EvaluationR
 		return nil, fmt.Errorf("no available AI provider for evaluation: %w", err)
 	}
 
-	return provider.EvaluateAnswers(ctx, req)
+	resp, err := provider.EvaluateAnswers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyRubricScoring(resp, req.Rubric); err != nil {
+		return nil, err
+	}
+	if len(resp.UnscoredCriteria) > 0 {
+		utils.Warningf("Evaluation left rubric criteria unscored: %s", strings.Join(resp.UnscoredCriteria, ", "))
+	}
+	return resp, nil
 }
 
 // buildInterviewSystemPrompt creates a system prompt for interview context
@@ -306,6 +317,15 @@ func (c *EnhancedAIClient) buildInterviewSystemPrompt(context map[string]interfa
 	} else {
 		jobContext = "This is a general interview assessment"
 	}
+	if persona := getStringFromContext(context, "persona", ""); persona != "" {
+		jobContext += fmt.Sprintf("\n\nInterviewer persona: %s. Stay in this persona throughout the interview.", persona)
+	}
+	// The parsed profile is compact and names past projects, so it stands in for the raw resume
+	if profile, _ := context["candidate_profile"].(*CandidateProfile); !profile.IsEmpty() {
+		jobContext += fmt.Sprintf("\n\nCandidate profile:\n%s\n\nAsk about specific past projects and roles by name, for example \"Tell me about the payment migration at Acme\", and probe claims that matter for the job.", formatCandidateProfile(profile))
+	} else if resume := getStringFromContext(context, "resume_text", ""); resume != "" {
+		jobContext += fmt.Sprintf("\n\nCandidate resume:\n%s\n\nAsk about specific projects, roles and skills from the resume, and probe claims that matter for the job.", truncateResumeText(resume))
+	}
 
 	basePrompt := fmt.Sprintf(`%sYou are an experienced interviewer conducting a %s interview.
 
@@ -329,9 +349,43 @@ Remember: You are evaluating the candidate's technical skills, problem-solving a
 
 %s`, languageInstructions, interviewType, jobContext, languageInstructions)
 
+	if resuming, _ := context["resuming_interview"].(bool); resuming {
+		basePrompt += resumeInstructions(getStringFromContext(context, "interview_stage", InterviewStageQuestions))
+	}
+
 	return basePrompt
 }
 
+// maxPromptResumeLength caps how much resume text goes into a prompt, in characters
+const maxPromptResumeLength = 6000
+
+// truncateResumeText shortens resume text to fit the prompt budget, cutting at a line break when possible
+func truncateResumeText(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxPromptResumeLength {
+		return text
+	}
+	truncated := string(runes[:maxPromptResumeLength])
+	if i := strings.LastIndex(truncated, "\n"); i > len(truncated)/2 {
+		truncated = truncated[:i]
+	}
+	return truncated + "\n[resume truncated]"
+}
+
+// resumeInstructions tells the interviewer how to welcome back a candidate at the given stage
+func resumeInstructions(stage string) string {
+	var next string
+	switch stage {
+	case InterviewStageIntroduction:
+		next = "then ask your opening question again"
+	case InterviewStageConclusion:
+		next = "then invite any final remarks before closing"
+	default:
+		next = "then briefly restate your last question so they can answer it"
+	}
+	return fmt.Sprintf("\n\nThe candidate has just returned after pausing the interview. Welcome them back in one or two sentences, %s. Do not start a new topic.", next)
+}
+
 // Helper function to get string from context map
 func getStringFromContext(context map[string]interface{}, key, defaultValue string) string {
 	if val, exists := context[key]; exists {
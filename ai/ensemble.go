@@ -0,0 +1,206 @@
+// Multi-model ensemble evaluation and disagreement detection
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Ensemble aggregation methods
+const (
+	AggregationMean   = "mean"
+	AggregationMedian = "median"
+)
+
+// DefaultDisagreementThreshold is the score spread above which an ensemble evaluation needs human review
+const DefaultDisagreementThreshold = 0.2
+
+// EnsembleOptions configures evaluation across several providers/models
+type EnsembleOptions struct {
+	Models                []string `json:"models"`                 // "provider/model" specs, e.g. "openai/gpt-4"
+	Aggregation           string   `json:"aggregation"`            // "mean" (default) or "median"
+	DisagreementThreshold float64  `json:"disagreement_threshold"` // Max allowed score spread; DefaultDisagreementThreshold when 0
+}
+
+// ModelResult is a single model's contribution to an ensemble evaluation
+type ModelResult struct {
+	Provider       string             `json:"provider"`
+	Model          string             `json:"model"`
+	OverallScore   float64            `json:"overall_score"`
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Feedback       string             `json:"feedback,omitempty"`
+	Error          string             `json:"error,omitempty"` // Set when this model failed; excluded from aggregation
+}
+
+// Validate checks the model specs and aggregation method
+func (o EnsembleOptions) Validate() error {
+	if len(o.Models) < 2 {
+		return fmt.Errorf("ensemble requires at least two models")
+	}
+	seen := make(map[string]bool, len(o.Models))
+	for _, spec := range o.Models {
+		if _, _, err := parseModel(spec); err != nil {
+			return err
+		}
+		if seen[spec] {
+			return fmt.Errorf("duplicate ensemble model: %s", spec)
+		}
+		seen[spec] = true
+	}
+	if o.Aggregation != "" && o.Aggregation != AggregationMean && o.Aggregation != AggregationMedian {
+		return fmt.Errorf("unsupported aggregation %q, expected mean or median", o.Aggregation)
+	}
+	if o.DisagreementThreshold < 0 || o.DisagreementThreshold > 1 {
+		return fmt.Errorf("disagreement threshold must be between 0.0 and 1.0")
+	}
+	return nil
+}
+
+// EvaluateEnsemble evaluates the answers on every ensemble model in parallel and combines
+// the results per category. Failed models are recorded but do not fail the evaluation
+// unless no model succeeds.
+func (c *EnhancedAIClient) EvaluateEnsemble(ctx context.Context, req *EvaluationRequest, opts EnsembleOptions) (*EvaluationResponse, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]ModelResult, len(opts.Models))
+	responses := make([]*EvaluationResponse, len(opts.Models))
+
+	var wg sync.WaitGroup
+	for i, spec := range opts.Models {
+		providerName, modelName, _ := parseModel(spec)
+		results[i] = ModelResult{Provider: providerName, Model: modelName}
+
+		wg.Add(1)
+		go func(i int, providerName, modelName string) {
+			defer wg.Done()
+
+			provider, err := c.GetProvider(providerName)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+
+			modelReq := *req
+			modelReq.Model = modelName
+			resp, err := provider.EvaluateAnswers(ctx, &modelReq)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+
+			if err := applyRubricScoring(resp, req.Rubric); err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].OverallScore = resp.OverallScore
+			results[i].CategoryScores = resp.CategoryScores
+			results[i].Feedback = resp.Feedback
+			responses[i] = resp
+		}(i, providerName, modelName)
+	}
+	wg.Wait()
+
+	return combineEnsembleResults(req, opts, results, responses)
+}
+
+// combineEnsembleResults aggregates the successful model responses into a single evaluation
+func combineEnsembleResults(req *EvaluationRequest, opts EnsembleOptions, results []ModelResult, responses []*EvaluationResponse) (*EvaluationResponse, error) {
+	aggregation := opts.Aggregation
+	if aggregation == "" {
+		aggregation = AggregationMean
+	}
+	threshold := opts.DisagreementThreshold
+	if threshold == 0 {
+		threshold = DefaultDisagreementThreshold
+	}
+
+	var combined *EvaluationResponse
+	overallScores := []float64{}
+	categoryValues := make(map[string][]float64)
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		if combined == nil {
+			// The first successful model (in ensemble order) supplies the narrative feedback
+			copied := *resp
+			combined = &copied
+			combined.TokensUsed = TokenUsage{}
+		}
+		combined.TokensUsed.PromptTokens += resp.TokensUsed.PromptTokens
+		combined.TokensUsed.CompletionTokens += resp.TokensUsed.CompletionTokens
+		combined.TokensUsed.TotalTokens += resp.TokensUsed.TotalTokens
+
+		overallScores = append(overallScores, resp.OverallScore)
+		for key, score := range resp.CategoryScores {
+			categoryValues[key] = append(categoryValues[key], score)
+		}
+	}
+	if combined == nil {
+		return nil, fmt.Errorf("all ensemble models failed: %s", results[0].Error)
+	}
+
+	combined.CategoryScores = make(map[string]float64, len(categoryValues))
+	disagreement := scoreSpread(overallScores)
+	for key, values := range categoryValues {
+		combined.CategoryScores[key] = aggregateScores(values, aggregation)
+		disagreement = math.Max(disagreement, scoreSpread(values))
+	}
+
+	if len(req.Rubric) > 0 {
+		if err := applyRubricScoring(combined, req.Rubric); err != nil {
+			return nil, err
+		}
+	} else {
+		combined.OverallScore = aggregateScores(overallScores, aggregation)
+		combined.ScoringMethod = ScoringMethodModel
+	}
+
+	combined.Provider = "ensemble"
+	combined.Model = ""
+	combined.ModelResults = results
+	combined.Aggregation = aggregation
+	combined.Disagreement = disagreement
+	combined.NeedsReview = disagreement > threshold
+	return combined, nil
+}
+
+// aggregateScores combines scores by mean or median
+func aggregateScores(values []float64, aggregation string) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	if aggregation == AggregationMedian {
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		mid := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[mid-1] + sorted[mid]) / 2
+		}
+		return sorted[mid]
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// scoreSpread returns the difference between the highest and lowest score
+func scoreSpread(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	lowest, highest := values[0], values[0]
+	for _, v := range values[1:] {
+		lowest = math.Min(lowest, v)
+		highest = math.Max(highest, v)
+	}
+	return highest - lowest
+}
@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// scoredProvider is a mock provider that returns fixed category scores
+type scoredProvider struct {
+	*MockProvider
+	scores map[string]float64
+	err    error
+}
+
+func (p *scoredProvider) EvaluateAnswers(ctx context.Context, req *EvaluationRequest) (*EvaluationResponse, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	resp, _ := p.MockProvider.EvaluateAnswers(ctx, req)
+	resp.CategoryScores = p.scores
+	return resp, nil
+}
+
+func newEnsembleTestClient(providers map[string]AIProvider) *EnhancedAIClient {
+	client := NewEnhancedAIClient(&AIConfig{DefaultProvider: ProviderMock})
+	for name, provider := range providers {
+		client.registerProvider(name, provider)
+	}
+	return client
+}
+
+func TestEvaluateEnsemble_AggregationAndDisagreement(t *testing.T) {
+	client := newEnsembleTestClient(map[string]AIProvider{
+		"a": &scoredProvider{MockProvider: NewMockProvider(), scores: map[string]float64{"design": 0.9, "communication": 0.6}},
+		"b": &scoredProvider{MockProvider: NewMockProvider(), scores: map[string]float64{"design": 0.5, "communication": 0.7}},
+		"c": &scoredProvider{MockProvider: NewMockProvider(), scores: map[string]float64{"design": 0.6, "communication": 0.8}},
+	})
+	req := &EvaluationRequest{
+		Answers: []string{"answer"},
+		Rubric:  []RubricCriterion{{Key: "design", Weight: 1}, {Key: "communication", Weight: 1}},
+	}
+
+	resp, err := client.EvaluateEnsemble(context.Background(), req, EnsembleOptions{
+		Models:      []string{"a/m1", "b/m2", "c/m3"},
+		Aggregation: AggregationMedian,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.CategoryScores["design"] != 0.6 || resp.CategoryScores["communication"] != 0.7 {
+		t.Errorf("expected median category scores, got %v", resp.CategoryScores)
+	}
+	if math.Abs(resp.OverallScore-0.65) > 1e-9 {
+		t.Errorf("expected weighted overall 0.65, got %v", resp.OverallScore)
+	}
+	// design spread is 0.4, above the default threshold
+	if !resp.NeedsReview || math.Abs(resp.Disagreement-0.4) > 1e-9 {
+		t.Errorf("expected review flag with disagreement 0.4, got %v %v", resp.NeedsReview, resp.Disagreement)
+	}
+	if len(resp.ModelResults) != 3 || resp.ModelResults[1].Model != "m2" {
+		t.Errorf("expected per-model results in ensemble order, got %+v", resp.ModelResults)
+	}
+}
+
+func TestEvaluateEnsemble_PartialFailure(t *testing.T) {
+	client := newEnsembleTestClient(map[string]AIProvider{
+		"a": &scoredProvider{MockProvider: NewMockProvider(), scores: map[string]float64{"design": 0.8}},
+		"b": &scoredProvider{MockProvider: NewMockProvider(), err: errors.New("rate limited")},
+	})
+	req := &EvaluationRequest{Answers: []string{"answer"}, Criteria: []string{"design"}}
+
+	resp, err := client.EvaluateEnsemble(context.Background(), req, EnsembleOptions{Models: []string{"a/m1", "b/m2"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.NeedsReview || resp.CategoryScores["design"] != 0.8 {
+		t.Errorf("expected surviving model's scores without review flag, got %+v", resp)
+	}
+	if resp.ModelResults[1].Error == "" {
+		t.Errorf("expected failed model error to be recorded")
+	}
+
+	// An unconfigured provider alongside a failing one fails the evaluation
+	_, err = client.EvaluateEnsemble(context.Background(), req, EnsembleOptions{Models: []string{"b/m2", "openai/gpt-4"}})
+	if err == nil {
+		t.Error("expected error when every ensemble model fails")
+	}
+}
+
+func TestEnsembleOptions_Validate(t *testing.T) {
+	invalid := []EnsembleOptions{
+		{Models: []string{"openai/gpt-4"}},
+		{Models: []string{"openai/gpt-4", "gpt-4"}},
+		{Models: []string{"openai/gpt-4", "openai/gpt-4"}},
+		{Models: []string{"openai/gpt-4", "gemini/gemini-1.5-pro"}, Aggregation: "mode"},
+		{Models: []string{"openai/gpt-4", "gemini/gemini-1.5-pro"}, DisagreementThreshold: 2},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("expected validation error for %+v", opts)
+		}
+	}
+	valid := EnsembleOptions{Models: []string{"openai/gpt-4", "gemini/gemini-1.5-pro"}, Aggregation: AggregationMean}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
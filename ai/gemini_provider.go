@@ -222,9 +222,9 @@ func (p *GeminiProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 				Content: systemPrompt + "\n\n" + userContent,
 			},
 		},
-		Model:       p.getModelName(""),
+		Model:       p.getModelName(req.Model),
 		MaxTokens:   3000,
-		Temperature: 0.3, // Lower temperature for more consistent evaluation
+		Temperature: evaluationTemperature(req),
 	}
 
 	response, err := p.GenerateResponse(ctx, chatReq)
@@ -233,7 +233,7 @@ func (p *GeminiProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 	}
 
 	// Parse evaluation response
-	evaluation := p.parseEvaluationResponse(response.Content)
+	evaluation := p.parseEvaluationResponse(response.Content, criteriaForRequest(req))
 	evaluation.TokensUsed = response.TokensUsed
 	evaluation.Provider = ProviderGemini
 	evaluation.Model = response.Model
@@ -403,6 +403,7 @@ Generate %d relevant interview questions that:
 2. Are appropriate for the %s level
 3. Focus on %s aspects
 4. Match the difficulty level: %s
+5. Name specific past projects and employers from the resume where relevant, e.g. "Tell me about the payment migration at Acme"
 
 Format each question as:
 Question: [question text]
@@ -417,22 +418,17 @@ Provide diverse questions that thoroughly evaluate the candidate for this role.`
 }
 
 func (p *GeminiProvider) buildEvaluationPrompt(req *EvaluationRequest) string {
-	criteriaText := strings.Join(req.Criteria, ", ")
+	criteriaText := strings.Join(criteriaForRequest(req), ", ")
 
 	return fmt.Sprintf(`You are an expert interview evaluator. Evaluate the candidate's answers objectively and provide detailed feedback.
 
-Job Description: %s
+%sJob Description: %s
 Evaluation Criteria: %s
 Detail Level: %s
 
 Provide evaluation in this format:
 Overall Score: [0.0-1.0]
-Category Scores: 
-- Technical Skills: [0.0-1.0]
-- Communication: [0.0-1.0]
-- Problem Solving: [0.0-1.0]
-- Experience: [0.0-1.0]
-
+%s
 Feedback: [comprehensive feedback paragraph]
 
 Strengths:
@@ -448,7 +444,7 @@ Recommendations:
 - [specific recommendation 2]
 
 Be specific, constructive, and fair in your evaluation.`,
-		req.JobDesc, criteriaText, req.DetailLevel)
+		buildPositionPromptSection(req), req.JobDesc, criteriaText, req.DetailLevel, buildCriteriaPromptSection(req))
 }
 
 func (p *GeminiProvider) formatAnswersForEvaluation(questions, answers []string) string {
@@ -490,10 +486,10 @@ func (p *GeminiProvider) parseQuestionResponse(content string) []InterviewQuesti
 	return questions
 }
 
-func (p *GeminiProvider) parseEvaluationResponse(content string) *EvaluationResponse {
+func (p *GeminiProvider) parseEvaluationResponse(content string, criteria []string) *EvaluationResponse {
 	// Reuse the same parsing logic as OpenAI provider
 	evaluation := &EvaluationResponse{
-		OverallScore:    0.7,
+		OverallScore:    parseOverallScore(content, 0.7),
 		CategoryScores:  make(map[string]float64),
 		Strengths:       []string{},
 		Weaknesses:      []string{},
@@ -530,10 +526,7 @@ func (p *GeminiProvider) parseEvaluationResponse(content string) *EvaluationResp
 
 	evaluation.Feedback = strings.Join(feedbackLines, " ")
 
-	evaluation.CategoryScores["technical"] = 0.7
-	evaluation.CategoryScores["communication"] = 0.8
-	evaluation.CategoryScores["problem_solving"] = 0.6
-	evaluation.CategoryScores["experience"] = 0.7
+	evaluation.CategoryScores = parseCategoryScores(content, criteria)
 
 	return evaluation
 }
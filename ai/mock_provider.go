@@ -14,20 +14,38 @@ func NewMockProvider() *MockProvider {
 	return &MockProvider{}
 }
 
+// mockCandidateProfile is returned for resume parsing requests
+const mockCandidateProfile = "```json\n" + `{
+  "summary": "[MOCK] Backend engineer focused on payments",
+  "years_of_experience": 6,
+  "skills": ["Go", "PostgreSQL", "Kubernetes"],
+  "languages": ["English"],
+  "work_history": [
+    {"company": "Acme", "title": "Senior Backend Engineer", "start_date": "2021-03", "end_date": "present", "highlights": ["Led the payment migration to a new processor"]},
+    {"company": "Globex", "title": "Software Engineer", "start_date": "2018", "end_date": "2021-02", "highlights": ["Built the order tracking API"]}
+  ],
+  "education": [{"institution": "State University", "degree": "BSc", "field": "Computer Science", "graduation_year": 2018}]
+}` + "\n```"
+
 func (m *MockProvider) GenerateResponse(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
-	// Detect language from system prompt
-	var isTraditionalChinese bool
+	// Detect language and resume parsing requests from system prompt
+	var isTraditionalChinese, isResumeParsing bool
 	for _, msg := range req.Messages {
 		if msg.Role == "system" {
 			if strings.Contains(msg.Content, "Traditional Chinese") || strings.Contains(msg.Content, "繁體中文") {
 				isTraditionalChinese = true
 			}
+			if msg.Content == resumeParsingPrompt {
+				isResumeParsing = true
+			}
 		}
 	}
 
 	// Simple language-appropriate mock response
 	var mockResponse string
-	if isTraditionalChinese {
+	if isResumeParsing {
+		mockResponse = mockCandidateProfile
+	} else if isTraditionalChinese {
 		mockResponse = "[模擬] 面試問題回應 - 這是測試用的模擬回應"
 	} else {
 		mockResponse = "[MOCK] Interview response - This is a test mock response"
@@ -105,16 +123,30 @@ func (m *MockProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReque
 		recommendations = []string{"[MOCK] Test recommendation 1", "[MOCK] Test recommendation 2"}
 	}
 
+	// Score each requested criterion so rubric weighting can be exercised deterministically
+	categoryScores := map[string]float64{"technical": 0.8, "communication": 0.85, "problem_solving": 0.75}
+	if criteria := criteriaForRequest(req); len(criteria) > 0 {
+		categoryScores = make(map[string]float64, len(criteria))
+		for i, key := range criteria {
+			categoryScores[key] = 0.9 - 0.1*float64(i%3)
+		}
+	}
+
+	model := req.Model
+	if model == "" {
+		model = "mock-model"
+	}
+
 	return &EvaluationResponse{
 		OverallScore:    0.8,
-		CategoryScores:  map[string]float64{"technical": 0.8, "communication": 0.85, "problem_solving": 0.75},
+		CategoryScores:  categoryScores,
 		Feedback:        feedback,
 		Strengths:       strengths,
 		Weaknesses:      weaknesses,
 		Recommendations: recommendations,
 		TokensUsed:      TokenUsage{PromptTokens: 50, CompletionTokens: 150, TotalTokens: 200},
 		Provider:        "mock",
-		Model:           "mock-model",
+		Model:           model,
 		Timestamp:       time.Now(),
 	}, nil
 }
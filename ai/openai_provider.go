@@ -198,9 +198,9 @@ func (p *OpenAIProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 				Content: userContent,
 			},
 		},
-		Model:       p.getModelName(""),
+		Model:       p.getModelName(req.Model),
 		MaxTokens:   3000,
-		Temperature: 0.3, // Lower temperature for more consistent evaluation
+		Temperature: evaluationTemperature(req),
 	}
 
 	response, err := p.GenerateResponse(ctx, chatReq)
@@ -209,7 +209,7 @@ func (p *OpenAIProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 	}
 
 	// Parse evaluation response
-	evaluation := p.parseEvaluationResponse(response.Content)
+	evaluation := p.parseEvaluationResponse(response.Content, criteriaForRequest(req))
 	evaluation.TokensUsed = response.TokensUsed
 	evaluation.Provider = ProviderOpenAI
 	evaluation.Model = response.Model
@@ -334,6 +334,7 @@ Generate %d relevant interview questions that:
 2. Are appropriate for the %s level
 3. Focus on %s aspects
 4. Match the difficulty level: %s
+5. Name specific past projects and employers from the resume where relevant, e.g. "Tell me about the payment migration at Acme"
 
 Format each question as:
 Question: [question text]
@@ -348,22 +349,17 @@ Provide diverse questions that thoroughly evaluate the candidate for this role.`
 }
 
 func (p *OpenAIProvider) buildEvaluationPrompt(req *EvaluationRequest) string {
-	criteriaText := strings.Join(req.Criteria, ", ")
+	criteriaText := strings.Join(criteriaForRequest(req), ", ")
 
 	return fmt.Sprintf(`You are an expert interview evaluator. Evaluate the candidate's answers objectively and provide detailed feedback.
 
-Job Description: %s
+%sJob Description: %s
 Evaluation Criteria: %s
 Detail Level: %s
 
 Provide evaluation in this format:
 Overall Score: [0.0-1.0]
-Category Scores: 
-- Technical Skills: [0.0-1.0]
-- Communication: [0.0-1.0]
-- Problem Solving: [0.0-1.0]
-- Experience: [0.0-1.0]
-
+%s
 Feedback: [comprehensive feedback paragraph]
 
 Strengths:
@@ -379,7 +375,7 @@ Recommendations:
 - [specific recommendation 2]
 
 Be specific, constructive, and fair in your evaluation.`,
-		req.JobDesc, criteriaText, req.DetailLevel)
+		buildPositionPromptSection(req), req.JobDesc, criteriaText, req.DetailLevel, buildCriteriaPromptSection(req))
 }
 
 func (p *OpenAIProvider) formatAnswersForEvaluation(questions, answers []string) string {
@@ -423,10 +419,10 @@ func (p *OpenAIProvider) parseQuestionResponse(content string) []InterviewQuesti
 	return questions
 }
 
-func (p *OpenAIProvider) parseEvaluationResponse(content string) *EvaluationResponse {
+func (p *OpenAIProvider) parseEvaluationResponse(content string, criteria []string) *EvaluationResponse {
 	// Simple parsing - in production, you might want more sophisticated parsing or structured output
 	evaluation := &EvaluationResponse{
-		OverallScore:    0.7, // Default fallback
+		OverallScore:    parseOverallScore(content, 0.7), // 0.7 if the model omitted it
 		CategoryScores:  make(map[string]float64),
 		Strengths:       []string{},
 		Weaknesses:      []string{},
@@ -466,11 +462,7 @@ func (p *OpenAIProvider) parseEvaluationResponse(content string) *EvaluationResp
 
 	evaluation.Feedback = strings.Join(feedbackLines, " ")
 
-	// Set some default category scores
-	evaluation.CategoryScores["technical"] = 0.7
-	evaluation.CategoryScores["communication"] = 0.8
-	evaluation.CategoryScores["problem_solving"] = 0.6
-	evaluation.CategoryScores["experience"] = 0.7
+	evaluation.CategoryScores = parseCategoryScores(content, criteria)
 
 	return evaluation
 }
@@ -0,0 +1,217 @@
+// Structured resume parsing into candidate profiles
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WorkExperience is one role in a candidate's work history
+type WorkExperience struct {
+	Company    string   `json:"company"`
+	Title      string   `json:"title"`
+	StartDate  string   `json:"start_date"` // "YYYY" or "YYYY-MM"
+	EndDate    string   `json:"end_date"`   // "YYYY", "YYYY-MM" or "present"
+	Highlights []string `json:"highlights"` // Notable projects and achievements
+}
+
+// Education is one entry in a candidate's education history
+type Education struct {
+	Institution    string `json:"institution"`
+	Degree         string `json:"degree"`
+	Field          string `json:"field"`
+	GraduationYear int    `json:"graduation_year"`
+}
+
+// CandidateProfile is the structured form of a candidate's resume
+type CandidateProfile struct {
+	Summary           string           `json:"summary"`
+	YearsOfExperience float64          `json:"years_of_experience"`
+	Skills            []string         `json:"skills"`
+	Languages         []string         `json:"languages"` // Spoken languages
+	WorkHistory       []WorkExperience `json:"work_history"`
+	Education         []Education      `json:"education"`
+}
+
+// IsEmpty reports whether the profile holds no information
+func (p *CandidateProfile) IsEmpty() bool {
+	return p == nil || (p.Summary == "" && p.YearsOfExperience == 0 && len(p.Skills) == 0 &&
+		len(p.Languages) == 0 && len(p.WorkHistory) == 0 && len(p.Education) == 0)
+}
+
+// resumeParsingTemperature keeps extraction close to deterministic
+const resumeParsingTemperature = 0.1
+
+// resumeParsingPrompt instructs the model to return the profile as JSON; the mock provider
+// recognizes it by its first line
+const resumeParsingPrompt = `Extract a structured candidate profile from the resume provided by the user.
+
+Respond with a single JSON object and nothing else, using exactly this schema:
+{
+  "summary": "one or two sentence professional summary",
+  "years_of_experience": 0,
+  "skills": ["skill"],
+  "languages": ["spoken language"],
+  "work_history": [
+    {
+      "company": "employer name",
+      "title": "job title",
+      "start_date": "YYYY-MM",
+      "end_date": "YYYY-MM or present",
+      "highlights": ["specific project or achievement, e.g. Led the payment migration to Stripe"]
+    }
+  ],
+  "education": [
+    {"institution": "school name", "degree": "degree", "field": "field of study", "graduation_year": 2020}
+  ]
+}
+
+Rules:
+- Only include information stated in the resume; use empty strings, 0 or empty lists when unknown
+- Dates are "YYYY-MM" when the month is known, otherwise "YYYY"; a current role ends "present"
+- List work history most recent first, and name concrete projects in highlights
+- years_of_experience is the total years of professional work`
+
+// ParseResume extracts a structured candidate profile from resume text
+func (c *AIClient) ParseResume(resumeText string) (*CandidateProfile, error) {
+	return c.ParseResumeContext(context.Background(), resumeText)
+}
+
+// ParseResumeContext is ParseResume with a context bounding the provider call
+func (c *AIClient) ParseResumeContext(ctx context.Context, resumeText string) (*CandidateProfile, error) {
+	if strings.TrimSpace(resumeText) == "" {
+		return nil, fmt.Errorf("resume text is empty")
+	}
+
+	resp, err := c.enhancedClient.GenerateResponse(ctx, &ChatRequest{
+		Messages: []Message{
+			{Role: "system", Content: resumeParsingPrompt, Timestamp: time.Now()},
+			{Role: "user", Content: truncateResumeText(resumeText), Timestamp: time.Now()},
+		},
+		Temperature: resumeParsingTemperature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resume: %w", err)
+	}
+	return parseCandidateProfile(resp.Content)
+}
+
+// parseCandidateProfile decodes the JSON profile from a model response, tolerating code fences and
+// surrounding prose, and tidies the values
+func parseCandidateProfile(content string) (*CandidateProfile, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("resume parser returned no JSON object")
+	}
+
+	var profile CandidateProfile
+	if err := json.Unmarshal([]byte(content[start:end+1]), &profile); err != nil {
+		return nil, fmt.Errorf("resume parser returned invalid JSON: %w", err)
+	}
+
+	profile.Summary = strings.TrimSpace(profile.Summary)
+	profile.Skills = tidyProfileList(profile.Skills)
+	profile.Languages = tidyProfileList(profile.Languages)
+	for i := range profile.WorkHistory {
+		role := &profile.WorkHistory[i]
+		role.Company = strings.TrimSpace(role.Company)
+		role.Title = strings.TrimSpace(role.Title)
+		role.StartDate = strings.TrimSpace(role.StartDate)
+		role.EndDate = strings.TrimSpace(role.EndDate)
+		if strings.EqualFold(role.EndDate, "present") || strings.EqualFold(role.EndDate, "current") {
+			role.EndDate = "present"
+		}
+		role.Highlights = tidyProfileList(role.Highlights)
+	}
+	for i := range profile.Education {
+		entry := &profile.Education[i]
+		entry.Institution = strings.TrimSpace(entry.Institution)
+		entry.Degree = strings.TrimSpace(entry.Degree)
+		entry.Field = strings.TrimSpace(entry.Field)
+	}
+	if profile.IsEmpty() {
+		return nil, fmt.Errorf("resume parser found no profile information")
+	}
+	return &profile, nil
+}
+
+// tidyProfileList trims entries and drops blanks and case-insensitive duplicates
+func tidyProfileList(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	var tidied []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		tidied = append(tidied, item)
+	}
+	return tidied
+}
+
+// formatCandidateProfile renders a profile as prompt text, leading with the work history so the
+// model can ask about specific past projects
+func formatCandidateProfile(profile *CandidateProfile) string {
+	var text strings.Builder
+	if profile.Summary != "" {
+		text.WriteString(fmt.Sprintf("Summary: %s\n", profile.Summary))
+	}
+	if profile.YearsOfExperience > 0 {
+		text.WriteString(fmt.Sprintf("Years of experience: %g\n", profile.YearsOfExperience))
+	}
+	if len(profile.WorkHistory) > 0 {
+		text.WriteString("Work history:\n")
+		for _, role := range profile.WorkHistory {
+			line := role.Company
+			if role.Title != "" {
+				line = role.Title + " at " + role.Company
+			}
+			if role.StartDate != "" || role.EndDate != "" {
+				line += fmt.Sprintf(" (%s to %s)", role.StartDate, role.EndDate)
+			}
+			text.WriteString("- " + line + "\n")
+			for _, highlight := range role.Highlights {
+				text.WriteString("  * " + highlight + "\n")
+			}
+		}
+	}
+	if len(profile.Skills) > 0 {
+		text.WriteString(fmt.Sprintf("Skills: %s\n", strings.Join(profile.Skills, ", ")))
+	}
+	if len(profile.Languages) > 0 {
+		text.WriteString(fmt.Sprintf("Languages: %s\n", strings.Join(profile.Languages, ", ")))
+	}
+	if len(profile.Education) > 0 {
+		text.WriteString("Education:\n")
+		for _, entry := range profile.Education {
+			line := strings.TrimSpace(entry.Degree + " " + entry.Field)
+			if line != "" {
+				line += ", "
+			}
+			line += entry.Institution
+			if entry.GraduationYear > 0 {
+				line += fmt.Sprintf(" (%d)", entry.GraduationYear)
+			}
+			text.WriteString("- " + line + "\n")
+		}
+	}
+	return strings.TrimRight(text.String(), "\n")
+}
+
+// candidateResumeContent combines the parsed profile and the raw resume into the resume section of a prompt
+func candidateResumeContent(resumeText string, profile *CandidateProfile) string {
+	if profile.IsEmpty() {
+		return truncateResumeText(resumeText)
+	}
+	content := "Candidate profile:\n" + formatCandidateProfile(profile)
+	if resumeText != "" {
+		content += "\n\nFull resume:\n" + truncateResumeText(resumeText)
+	}
+	return content
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+)
+
+func TestParseCandidateProfile(t *testing.T) {
+	content := "Here is the profile:\n```json\n" + `{
+  "summary": " Payments engineer ",
+  "years_of_experience": 6,
+  "skills": ["Go", " go ", "", "SQL"],
+  "work_history": [{"company": "Acme", "title": "Engineer", "start_date": "2021-03", "end_date": "Current", "highlights": ["Led the payment migration"]}]
+}` + "\n```"
+
+	profile, err := parseCandidateProfile(content)
+	if err != nil {
+		t.Fatalf("expected the profile parsed, got %v", err)
+	}
+	if profile.Summary != "Payments engineer" || len(profile.Skills) != 2 || profile.Skills[1] != "SQL" {
+		t.Errorf("expected tidied summary and deduplicated skills, got %+v", profile)
+	}
+	if profile.WorkHistory[0].EndDate != "present" {
+		t.Errorf("expected a current role to end \"present\", got %q", profile.WorkHistory[0].EndDate)
+	}
+
+	for _, bad := range []string{"no json here", `{"skills": "Go"}`, `{"skills": []}`} {
+		if _, err := parseCandidateProfile(bad); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestParseResume_ProfileInPrompts(t *testing.T) {
+	client, err := NewAIClientFactory(config.Config{OpenAIAPIKey: "test-openai-key", GeminiAPIKey: "test-gemini-key"}).CreateDefaultClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.ParseResume("  "); err == nil {
+		t.Error("expected an error for an empty resume")
+	}
+	profile, err := client.ParseResume("Jane Doe, backend engineer at Acme")
+	if err != nil {
+		t.Fatalf("ParseResume failed: %v", err)
+	}
+	if len(profile.WorkHistory) == 0 || profile.WorkHistory[0].Company != "Acme" {
+		t.Fatalf("expected the mock work history, got %+v", profile)
+	}
+
+	// The interviewer is given the parsed projects instead of the raw resume
+	prompt := client.enhancedClient.buildInterviewSystemPrompt(buildChatContext(ChatOptions{ResumeText: "raw resume text", Profile: profile}, nil))
+	if !strings.Contains(prompt, "Senior Backend Engineer at Acme") || !strings.Contains(prompt, "Led the payment migration") {
+		t.Errorf("expected the work history in the system prompt, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "raw resume text") {
+		t.Error("expected the profile to stand in for the raw resume")
+	}
+
+	content := candidateResumeContent("raw resume text", profile)
+	if !strings.Contains(content, "Led the payment migration") || !strings.Contains(content, "raw resume text") {
+		t.Errorf("expected question generation to see both profile and resume, got:\n%s", content)
+	}
+}
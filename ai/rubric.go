@@ -0,0 +1,191 @@
+// Rubric-based evaluation prompts, score parsing and weighted scoring
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RubricAnchor describes what a given score level looks like for a criterion
+type RubricAnchor struct {
+	Score       float64 `json:"score"`       // 0.0-1.0
+	Description string  `json:"description"` // Observable behavior at this level
+}
+
+// RubricCriterion is a single weighted evaluation criterion
+type RubricCriterion struct {
+	Key         string         `json:"key"`         // Machine key used in category scores, e.g. "system_design"
+	Name        string         `json:"name"`        // Human-readable name
+	Description string         `json:"description"` // What the criterion measures
+	Weight      float64        `json:"weight"`      // Relative weight (normalized when scoring)
+	Anchors     []RubricAnchor `json:"anchors"`     // Per-level anchors
+}
+
+// ErrRubricUnscored is returned when a model response scores none of the rubric criteria, usually
+// because it could not be parsed; a weighted score of 0 would wrongly stand for the candidate
+var ErrRubricUnscored = errors.New("model response scored none of the rubric criteria")
+
+// scoreLinePattern matches "- Name: 0.85" or "Name: 0.85/1.0" style lines
+var scoreLinePattern = regexp.MustCompile(`^[-*\s]*([^:]+?)\s*:\s*\[?\s*([0-9]*\.?[0-9]+)`)
+
+// normalizeCriterionKey converts a display name like "Problem Solving" to "problem_solving"
+func normalizeCriterionKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Trim(name, "*_` ")
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
+
+// criteriaForRequest returns the criterion keys to score, preferring the rubric when present
+func criteriaForRequest(req *EvaluationRequest) []string {
+	if len(req.Rubric) == 0 {
+		return req.Criteria
+	}
+	keys := make([]string, len(req.Rubric))
+	for i, criterion := range req.Rubric {
+		keys[i] = criterion.Key
+	}
+	return keys
+}
+
+// buildPositionPromptSection names the role and its required skills, or returns "" when the
+// request has no structured position
+func buildPositionPromptSection(req *EvaluationRequest) string {
+	if req.Position == nil || req.Position.Title == "" {
+		return ""
+	}
+	title := req.Position.Title
+	if req.Position.Level != "" {
+		title = fmt.Sprintf("%s (%s)", title, req.Position.Level)
+	}
+	section := fmt.Sprintf("Position: %s\n", title)
+	if len(req.Position.RequiredSkills) > 0 {
+		section += fmt.Sprintf("Required Skills: %s\nJudge the answers against each required skill and name any the candidate did not demonstrate.\n",
+			strings.Join(req.Position.RequiredSkills, ", "))
+	}
+	return section
+}
+
+// buildCriteriaPromptSection describes the criteria (and rubric anchors, if any) and the
+// exact category score lines the model must return
+func buildCriteriaPromptSection(req *EvaluationRequest) string {
+	var section strings.Builder
+
+	if len(req.Rubric) > 0 {
+		section.WriteString("Scoring Rubric:\n")
+		for _, criterion := range req.Rubric {
+			name := criterion.Name
+			if name == "" {
+				name = criterion.Key
+			}
+			section.WriteString(fmt.Sprintf("- %s (%s): %s\n", criterion.Key, name, criterion.Description))
+
+			anchors := append([]RubricAnchor(nil), criterion.Anchors...)
+			sort.Slice(anchors, func(i, j int) bool { return anchors[i].Score < anchors[j].Score })
+			for _, anchor := range anchors {
+				section.WriteString(fmt.Sprintf("    %.2f = %s\n", anchor.Score, anchor.Description))
+			}
+		}
+		section.WriteString("\n")
+	}
+
+	section.WriteString("Category Scores (score every criterion, use the exact keys):\n")
+	for _, key := range criteriaForRequest(req) {
+		section.WriteString(fmt.Sprintf("- %s: [0.0-1.0]\n", key))
+	}
+	return section.String()
+}
+
+// parseCategoryScores extracts "key: score" lines for the requested criteria from a model response
+func parseCategoryScores(content string, criteria []string) map[string]float64 {
+	wanted := make(map[string]string, len(criteria))
+	for _, key := range criteria {
+		wanted[normalizeCriterionKey(key)] = key
+	}
+
+	scores := make(map[string]float64)
+	for _, line := range strings.Split(content, "\n") {
+		match := scoreLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		key, ok := wanted[normalizeCriterionKey(match[1])]
+		if !ok {
+			continue
+		}
+		if _, seen := scores[key]; seen {
+			continue
+		}
+		if value, err := strconv.ParseFloat(match[2], 64); err == nil {
+			scores[key] = clampScore(value)
+		}
+	}
+	return scores
+}
+
+// parseOverallScore extracts the "Overall Score:" line from a model response
+func parseOverallScore(content string, fallback float64) float64 {
+	for _, line := range strings.Split(content, "\n") {
+		match := scoreLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match != nil && normalizeCriterionKey(match[1]) == "overall_score" {
+			if value, err := strconv.ParseFloat(match[2], 64); err == nil {
+				return clampScore(value)
+			}
+		}
+	}
+	return fallback
+}
+
+// clampScore keeps a score within the 0.0-1.0 range
+func clampScore(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
+
+// ComputeWeightedScore returns the weighted mean of the category scores under the rubric.
+// Criteria without a score are excluded from the denominator and returned as unscored.
+func ComputeWeightedScore(rubric []RubricCriterion, categoryScores map[string]float64) (float64, []string) {
+	var weightedSum, totalWeight float64
+	unscored := []string{}
+
+	for _, criterion := range rubric {
+		score, ok := categoryScores[criterion.Key]
+		if !ok {
+			unscored = append(unscored, criterion.Key)
+			continue
+		}
+		weightedSum += criterion.Weight * clampScore(score)
+		totalWeight += criterion.Weight
+	}
+
+	if totalWeight == 0 {
+		return 0, unscored
+	}
+	return weightedSum / totalWeight, unscored
+}
+
+// applyRubricScoring replaces the model's overall score with the deterministic weighted score,
+// failing with ErrRubricUnscored when no criterion was scored
+func applyRubricScoring(resp *EvaluationResponse, rubric []RubricCriterion) error {
+	if len(rubric) == 0 {
+		resp.ScoringMethod = ScoringMethodModel
+		return nil
+	}
+	score, unscored := ComputeWeightedScore(rubric, resp.CategoryScores)
+	if len(unscored) == len(rubric) {
+		return ErrRubricUnscored
+	}
+	resp.OverallScore, resp.UnscoredCriteria = score, unscored
+	resp.ScoringMethod = ScoringMethodWeightedRubric
+	return nil
+}
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestComputeWeightedScore(t *testing.T) {
+	rubric := []RubricCriterion{
+		{Key: "system_design", Weight: 2},
+		{Key: "communication", Weight: 1},
+		{Key: "testing", Weight: 1},
+	}
+
+	score, unscored := ComputeWeightedScore(rubric, map[string]float64{
+		"system_design": 0.8,
+		"communication": 0.5,
+	})
+	// testing is unscored and excluded from the denominator: (2*0.8 + 1*0.5) / 3
+	if math.Abs(score-0.7) > 1e-9 {
+		t.Errorf("expected 0.7, got %v", score)
+	}
+	if len(unscored) != 1 || unscored[0] != "testing" {
+		t.Errorf("expected [testing] unscored, got %v", unscored)
+	}
+
+	score, unscored = ComputeWeightedScore(rubric, map[string]float64{})
+	if score != 0 || len(unscored) != 3 {
+		t.Errorf("expected 0 with all criteria unscored, got %v %v", score, unscored)
+	}
+}
+
+func TestApplyRubricScoring_Unparsed(t *testing.T) {
+	rubric := []RubricCriterion{{Key: "system_design", Weight: 1}, {Key: "testing", Weight: 1}}
+
+	resp := &EvaluationResponse{OverallScore: 0.6, CategoryScores: map[string]float64{"culture": 0.9}}
+	if err := applyRubricScoring(resp, rubric); !errors.Is(err, ErrRubricUnscored) {
+		t.Fatalf("expected ErrRubricUnscored, got %v", err)
+	}
+	if resp.OverallScore != 0.6 {
+		t.Errorf("expected the score left alone, got %v", resp.OverallScore)
+	}
+
+	resp = &EvaluationResponse{CategoryScores: map[string]float64{"testing": 0.4}}
+	if err := applyRubricScoring(resp, rubric); err != nil || resp.OverallScore != 0.4 || len(resp.UnscoredCriteria) != 1 {
+		t.Errorf("expected a partial rubric scored, got %+v (%v)", resp, err)
+	}
+}
+
+func TestParseCategoryScores(t *testing.T) {
+	content := `Overall Score: 0.75
+Category Scores:
+- System Design: 0.9
+- **communication**: 1.4
+- culture: 0.2
+Feedback: solid`
+
+	scores := parseCategoryScores(content, []string{"system_design", "communication", "testing"})
+	if len(scores) != 2 {
+		t.Fatalf("expected 2 parsed scores, got %v", scores)
+	}
+	if scores["system_design"] != 0.9 {
+		t.Errorf("expected system_design 0.9, got %v", scores["system_design"])
+	}
+	if scores["communication"] != 1.0 {
+		t.Errorf("expected communication clamped to 1.0, got %v", scores["communication"])
+	}
+	if overall := parseOverallScore(content, 0.5); overall != 0.75 {
+		t.Errorf("expected overall 0.75, got %v", overall)
+	}
+}
+
+func TestBuildPositionPromptSection(t *testing.T) {
+	if section := buildPositionPromptSection(&EvaluationRequest{JobDesc: "Backend role"}); section != "" {
+		t.Errorf("expected no section without a position, got %q", section)
+	}
+
+	req := &EvaluationRequest{
+		JobDesc:  "Backend role",
+		Position: &PositionInfo{Title: "Backend Engineer", Level: "senior", RequiredSkills: []string{"Go", "PostgreSQL"}},
+	}
+	prompt := (&OpenAIProvider{}).buildEvaluationPrompt(req)
+	if !strings.Contains(prompt, "Position: Backend Engineer (senior)") || !strings.Contains(prompt, "Required Skills: Go, PostgreSQL") {
+		t.Errorf("expected the position and required skills in the prompt, got:\n%s", prompt)
+	}
+}
@@ -0,0 +1,156 @@
+// Repeated-sampling evaluation for score consistency reporting
+package ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Consistency sampling defaults and limits
+const (
+	DefaultEvaluationTemperature = 0.3  // Single-run evaluations favor consistency
+	DefaultSamplingTemperature   = 0.7  // Sampling needs some randomness to measure variance
+	DefaultMaxScoreStdDev        = 0.1  // Standard deviation above which a result is low-confidence
+	MaxEvaluationSamples         = 10   // Upper bound on samples per evaluation
+	confidenceZ                  = 1.96 // z-score for a 95% confidence band
+)
+
+// SamplingOptions configures repeated evaluation of the same answers
+type SamplingOptions struct {
+	Samples     int     `json:"samples"`     // Number of evaluation runs (2-MaxEvaluationSamples)
+	Temperature float64 `json:"temperature"` // Sampling temperature; DefaultSamplingTemperature when 0
+	MaxStdDev   float64 `json:"max_std_dev"` // Low-confidence threshold; DefaultMaxScoreStdDev when 0
+}
+
+// ConsistencyStats summarizes the spread of overall scores across samples
+type ConsistencyStats struct {
+	Samples         int       `json:"samples"`          // Successful samples
+	Requested       int       `json:"requested"`        // Samples requested
+	Temperature     float64   `json:"temperature"`      // Temperature used for every sample
+	Scores          []float64 `json:"scores"`           // Overall score of each successful sample
+	Mean            float64   `json:"mean"`             // Mean overall score (reported as the evaluation score)
+	StdDev          float64   `json:"std_dev"`          // Sample standard deviation of the overall score
+	ConfidenceLow   float64   `json:"confidence_low"`   // Lower bound of the 95% confidence band for the mean
+	ConfidenceHigh  float64   `json:"confidence_high"`  // Upper bound of the 95% confidence band for the mean
+	ConfidenceLevel float64   `json:"confidence_level"` // Always 0.95
+}
+
+// Validate checks the sample count and thresholds
+func (o SamplingOptions) Validate() error {
+	if o.Samples < 2 || o.Samples > MaxEvaluationSamples {
+		return fmt.Errorf("samples must be between 2 and %d", MaxEvaluationSamples)
+	}
+	if o.Temperature < 0 || o.Temperature > 1 {
+		return fmt.Errorf("temperature must be between 0.0 and 1.0")
+	}
+	if o.MaxStdDev < 0 || o.MaxStdDev > 1 {
+		return fmt.Errorf("max standard deviation must be between 0.0 and 1.0")
+	}
+	return nil
+}
+
+// evaluationTemperature returns the request temperature or the single-run default
+func evaluationTemperature(req *EvaluationRequest) float64 {
+	if req.Temperature > 0 {
+		return req.Temperature
+	}
+	return DefaultEvaluationTemperature
+}
+
+// evaluateSampled runs evaluate once per sample in parallel at the configured temperature and
+// reports the mean score with its spread. Results whose standard deviation exceeds the maximum,
+// or that have too few successful samples to measure it, are marked low-confidence.
+func evaluateSampled(ctx context.Context, req *EvaluationRequest, opts SamplingOptions, evaluate func(context.Context, *EvaluationRequest) (*EvaluationResponse, error)) (*EvaluationResponse, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	temperature := opts.Temperature
+	if temperature == 0 {
+		temperature = DefaultSamplingTemperature
+	}
+	maxStdDev := opts.MaxStdDev
+	if maxStdDev == 0 {
+		maxStdDev = DefaultMaxScoreStdDev
+	}
+
+	responses := make([]*EvaluationResponse, opts.Samples)
+	errs := make([]error, opts.Samples)
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Samples; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sampleReq := *req
+			sampleReq.Temperature = temperature
+			responses[i], errs[i] = evaluate(ctx, &sampleReq)
+		}(i)
+	}
+	wg.Wait()
+
+	var combined *EvaluationResponse
+	scores := []float64{}
+	categoryValues := make(map[string][]float64)
+	for i, resp := range responses {
+		if errs[i] != nil || resp == nil {
+			continue
+		}
+		if combined == nil {
+			// The first successful sample supplies the narrative feedback
+			copied := *resp
+			combined = &copied
+			combined.TokensUsed = TokenUsage{}
+		}
+		combined.TokensUsed.PromptTokens += resp.TokensUsed.PromptTokens
+		combined.TokensUsed.CompletionTokens += resp.TokensUsed.CompletionTokens
+		combined.TokensUsed.TotalTokens += resp.TokensUsed.TotalTokens
+		combined.NeedsReview = combined.NeedsReview || resp.NeedsReview
+		combined.Disagreement = math.Max(combined.Disagreement, resp.Disagreement)
+
+		scores = append(scores, resp.OverallScore)
+		for key, score := range resp.CategoryScores {
+			categoryValues[key] = append(categoryValues[key], score)
+		}
+	}
+	if combined == nil {
+		return nil, fmt.Errorf("all evaluation samples failed: %w", errs[0])
+	}
+
+	combined.CategoryScores = make(map[string]float64, len(categoryValues))
+	for key, values := range categoryValues {
+		combined.CategoryScores[key] = aggregateScores(values, AggregationMean)
+	}
+
+	mean := aggregateScores(scores, AggregationMean)
+	stdDev := sampleStdDev(scores, mean)
+	margin := confidenceZ * stdDev / math.Sqrt(float64(len(scores)))
+
+	combined.OverallScore = mean
+	combined.Consistency = &ConsistencyStats{
+		Samples:         len(scores),
+		Requested:       opts.Samples,
+		Temperature:     temperature,
+		Scores:          scores,
+		Mean:            mean,
+		StdDev:          stdDev,
+		ConfidenceLow:   clampScore(mean - margin),
+		ConfidenceHigh:  clampScore(mean + margin),
+		ConfidenceLevel: 0.95,
+	}
+	combined.LowConfidence = len(scores) < 2 || stdDev > maxStdDev
+	return combined, nil
+}
+
+// sampleStdDev returns the sample (n-1) standard deviation of the values around mean
+func sampleStdDev(values []float64, mean float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	var sumSquares float64
+	for _, v := range values {
+		sumSquares += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sumSquares / float64(len(values)-1))
+}
@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+// sequenceEvaluator returns the given overall scores in turn and records the temperatures it saw
+type sequenceEvaluator struct {
+	mu           sync.Mutex
+	scores       []float64
+	calls        int
+	temperatures []float64
+}
+
+func (s *sequenceEvaluator) evaluate(ctx context.Context, req *EvaluationRequest) (*EvaluationResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.temperatures = append(s.temperatures, req.Temperature)
+	if s.calls >= len(s.scores) {
+		return nil, errors.New("no more scores")
+	}
+	score := s.scores[s.calls]
+	s.calls++
+	return &EvaluationResponse{
+		OverallScore:   score,
+		CategoryScores: map[string]float64{"design": score},
+		TokensUsed:     TokenUsage{TotalTokens: 10},
+	}, nil
+}
+
+func TestEvaluateSampled_Statistics(t *testing.T) {
+	evaluator := &sequenceEvaluator{scores: []float64{0.65, 0.7, 0.75}}
+	resp, err := evaluateSampled(context.Background(), &EvaluationRequest{}, SamplingOptions{Samples: 3, Temperature: 0.9}, evaluator.evaluate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := resp.Consistency
+	if stats == nil || stats.Samples != 3 {
+		t.Fatalf("expected consistency stats for 3 samples, got %+v", stats)
+	}
+	if math.Abs(resp.OverallScore-0.7) > 1e-9 || math.Abs(resp.CategoryScores["design"]-0.7) > 1e-9 {
+		t.Errorf("expected mean 0.7, got %v %v", resp.OverallScore, resp.CategoryScores)
+	}
+	if math.Abs(stats.StdDev-0.05) > 1e-9 {
+		t.Errorf("expected std dev 0.05, got %v", stats.StdDev)
+	}
+	if stats.ConfidenceLow >= 0.7 || stats.ConfidenceHigh <= 0.7 {
+		t.Errorf("expected confidence band around the mean, got %v-%v", stats.ConfidenceLow, stats.ConfidenceHigh)
+	}
+	if resp.LowConfidence {
+		t.Error("std dev below the threshold should not be low-confidence")
+	}
+	if resp.TokensUsed.TotalTokens != 30 {
+		t.Errorf("expected tokens summed across samples, got %d", resp.TokensUsed.TotalTokens)
+	}
+	for _, temp := range evaluator.temperatures {
+		if temp != 0.9 {
+			t.Errorf("expected every sample at temperature 0.9, got %v", temp)
+		}
+	}
+}
+
+func TestEvaluateSampled_LowConfidence(t *testing.T) {
+	evaluator := &sequenceEvaluator{scores: []float64{0.2, 0.9}}
+	resp, err := evaluateSampled(context.Background(), &EvaluationRequest{}, SamplingOptions{Samples: 2}, evaluator.evaluate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.LowConfidence {
+		t.Errorf("expected high variance to be low-confidence, got std dev %v", resp.Consistency.StdDev)
+	}
+
+	// A single surviving sample cannot measure variance
+	evaluator = &sequenceEvaluator{scores: []float64{0.8}}
+	resp, err = evaluateSampled(context.Background(), &EvaluationRequest{}, SamplingOptions{Samples: 3}, evaluator.evaluate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.LowConfidence || resp.Consistency.Samples != 1 || resp.Consistency.Requested != 3 {
+		t.Errorf("expected low-confidence single sample result, got %+v", resp.Consistency)
+	}
+
+	if _, err := evaluateSampled(context.Background(), &EvaluationRequest{}, SamplingOptions{Samples: 1}, evaluator.evaluate); err == nil {
+		t.Error("expected validation error for a single sample")
+	}
+}
@@ -57,11 +57,22 @@ type TokenUsage struct {
 type EvaluationRequest struct {
 	Questions   []string               `json:"questions"`    // Interview questions
 	Answers     []string               `json:"answers"`      // Candidate answers
-	JobDesc     string                 `json:"job_desc"`     // Job description (AI will extract job title from this)
+	JobDesc     string                 `json:"job_desc"`     // Job description
+	Position    *PositionInfo          `json:"position"`     // Optional structured role; spares the model inferring it from JobDesc
 	Criteria    []string               `json:"criteria"`     // Evaluation criteria
+	Rubric      []RubricCriterion      `json:"rubric"`       // Optional weighted rubric; overrides Criteria when set
 	Context     map[string]interface{} `json:"context"`      // Additional context
 	DetailLevel string                 `json:"detail_level"` // "brief", "detailed", "comprehensive"
 	Language    string                 `json:"language"`     // Language for evaluation ("en", "zh-TW")
+	Model       string                 `json:"model"`        // Optional model override; provider default when empty
+	Temperature float64                `json:"temperature"`  // Optional sampling temperature; DefaultEvaluationTemperature when 0
+}
+
+// PositionInfo describes the role an interview is for
+type PositionInfo struct {
+	Title          string   `json:"title"`           // e.g. "Backend Engineer"
+	Level          string   `json:"level"`           // Optional seniority, e.g. "senior"
+	RequiredSkills []string `json:"required_skills"` // Skills the evaluator checks the answers against
 }
 
 // EvaluationResponse represents an AI evaluation result
@@ -76,8 +87,27 @@ type EvaluationResponse struct {
 	Provider        string             `json:"provider"`        // Provider used
 	Model           string             `json:"model"`           // Model used
 	Timestamp       time.Time          `json:"timestamp"`       // When evaluation was done
+
+	ScoringMethod    string   `json:"scoring_method"`              // How OverallScore was derived: "weighted_rubric" or "model"
+	UnscoredCriteria []string `json:"unscored_criteria,omitempty"` // Rubric criteria the model did not score
+
+	// Ensemble evaluation details, set only when several models were combined
+	ModelResults []ModelResult `json:"model_results,omitempty"` // Per-model results, in ensemble order
+	Aggregation  string        `json:"aggregation,omitempty"`   // "mean" or "median"
+	Disagreement float64       `json:"disagreement,omitempty"`  // Largest score spread between models
+	NeedsReview  bool          `json:"needs_review,omitempty"`  // Disagreement exceeded the ensemble threshold
+
+	// Consistency details, set only when the evaluation was sampled several times
+	Consistency   *ConsistencyStats `json:"consistency,omitempty"`
+	LowConfidence bool              `json:"low_confidence,omitempty"` // Sample standard deviation exceeded the allowed maximum
 }
 
+// Scoring methods for EvaluationResponse.ScoringMethod
+const (
+	ScoringMethodWeightedRubric = "weighted_rubric"
+	ScoringMethodModel          = "model"
+)
+
 // QuestionGenerationRequest represents a request to generate interview questions
 type QuestionGenerationRequest struct {
 	JobDescription  string                 `json:"job_description"`  // Job requirements (AI will extract job title from this)
@@ -172,6 +202,13 @@ type AIConfig struct {
 	MaxCostPerDay   float64 `json:"max_cost_per_day"`
 }
 
+// Interview stages, as reported in InterviewContext.InterviewStage
+const (
+	InterviewStageIntroduction = "introduction"
+	InterviewStageQuestions    = "questions"
+	InterviewStageConclusion   = "conclusion"
+)
+
 // InterviewContext contains context for interview-related AI operations
 type InterviewContext struct {
 	JobDescription  string            `json:"job_description"` // Job description (AI will extract job title from this)
@@ -185,6 +222,27 @@ type InterviewContext struct {
 	CustomContext   map[string]string `json:"custom_context"`   // Additional custom context
 }
 
+// ChatOptions carries per-interview settings used to build the interviewer prompt
+type ChatOptions struct {
+	Language       string            `json:"language"`          // "en" or "zh-TW"
+	InterviewType  string            `json:"interview_type"`    // "general", "technical", "behavioral"
+	JobDescription string            `json:"job_description"`   // Optional job description text
+	Persona        string            `json:"persona"`           // Optional interviewer persona, e.g. "friendly senior backend engineer"
+	ResumeText     string            `json:"resume_text"`       // Optional text of the candidate's resume
+	Profile        *CandidateProfile `json:"candidate_profile"` // Optional structured resume; preferred over ResumeText
+}
+
+// EvaluationOptions carries per-interview evaluation settings
+type EvaluationOptions struct {
+	JobDescription string            `json:"job_description"` // Job description or summary for context
+	Position       *PositionInfo     `json:"position"`        // Optional structured role the candidate interviewed for
+	Language       string            `json:"language"`        // "en" or "zh-TW"
+	Criteria       []string          `json:"criteria"`        // Criterion keys; defaults to DefaultEvaluationCriteria
+	Rubric         []RubricCriterion `json:"rubric"`          // Optional weighted rubric; enables deterministic scoring
+	Ensemble       *EnsembleOptions  `json:"ensemble"`        // Optional multi-model evaluation; single default provider when nil
+	Sampling       *SamplingOptions  `json:"sampling"`        // Optional repeated sampling; single run when nil
+}
+
 // PromptTemplate represents a reusable prompt template
 type PromptTemplate struct {
 	Name        string            `json:"name"`
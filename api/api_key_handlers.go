@@ -0,0 +1,125 @@
+// HTTP handler functions for integration API keys
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// API key limits
+const (
+	apiKeyPrefixLength  = 12 // "aik_" and the first 8 random characters
+	maxAPIKeyNameLength = 255
+)
+
+// Helper: generate a random API key, returning the key and the hash to store
+func newAPIKey() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key := apiKeyPrefix + hex.EncodeToString(buf)
+	return key, hashAPIKey(key), nil
+}
+
+// Helper: convert an API key to its response DTO
+func newAPIKeyResponseDTO(key *data.APIKey) APIKeyResponseDTO {
+	return APIKeyResponseDTO{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Role:       key.Role,
+		CreatedBy:  key.CreatedBy,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// CreateAPIKeyHandler handles POST /api-keys
+// Creates a key for a server-to-server integration, acting with the given role. The key is only
+// returned here; callers send it as a bearer token or in the X-API-Key header.
+func CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateAPIKeyRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing name")
+		return
+	}
+	if len(req.Name) > maxAPIKeyNameLength {
+		writeJSONError(w, http.StatusBadRequest, "name must be at most 255 characters")
+		return
+	}
+	role := RoleRecruiter
+	if req.Role != "" {
+		var ok bool
+		if role, ok = parseStaffRole(req.Role); !ok {
+			writeJSONError(w, http.StatusBadRequest, "Invalid role. Supported values: admin, recruiter, reviewer")
+			return
+		}
+	}
+
+	key, hash, err := newAPIKey()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to generate API key")
+		return
+	}
+	apiKey := &data.APIKey{
+		ID:        data.GenerateID(),
+		Name:      req.Name,
+		Prefix:    key[:apiKeyPrefixLength],
+		KeyHash:   hash,
+		Role:      string(role),
+		CreatedBy: creatorOf(r),
+	}
+	if err := data.GlobalStore.CreateAPIKey(apiKey); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create API key", err.Error())
+		return
+	}
+
+	resp := newAPIKeyResponseDTO(apiKey)
+	resp.Key = key
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// ListAPIKeysHandler handles GET /api-keys
+// Lists every key, newest first, with when it was last used; revoked keys stay listed.
+func ListAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	keys, err := data.GlobalStore.ListAPIKeys()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch API keys", err.Error())
+		return
+	}
+
+	resp := ListAPIKeysResponseDTO{APIKeys: make([]APIKeyResponseDTO, len(keys)), Total: len(keys)}
+	for i, key := range keys {
+		resp.APIKeys[i] = newAPIKeyResponseDTO(key)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RevokeAPIKeyHandler handles DELETE /api-keys/{id}
+// Requests made with the key fail from then on; the key stays listed with its revocation time.
+func RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := data.GlobalStore.GetAPIKey(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+	if err := data.GlobalStore.RevokeAPIKey(id); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to revoke API key", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIKeyHandlers(t *testing.T) {
+	clearMemoryStore()
+	router := setupAccessControlledRouter()
+	staff := "Bearer " + testStaffToken
+
+	if w := serveWithHeader(router, "POST", "/api-keys", []byte(`{"name":"ATS"}`), "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected creating a key to need staff credentials, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "POST", "/api-keys", []byte(`{"name":"  "}`), "Authorization", staff); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a missing name rejected, got %d", w.Code)
+	}
+	w := serveWithHeader(router, "POST", "/api-keys", []byte(`{"name":"Greenhouse sync"}`), "Authorization", staff)
+	var created APIKeyResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	if w.Code != http.StatusCreated || !strings.HasPrefix(created.Key, apiKeyPrefix) || !strings.HasPrefix(created.Key, created.Prefix) {
+		t.Fatalf("expected the key returned once with its prefix, got %d: %s", w.Code, w.Body.String())
+	}
+	if created.CreatedBy != PrincipalStaffToken || created.LastUsedAt != nil {
+		t.Errorf("expected an unused key created with the staff token, got %+v", created)
+	}
+
+	// The key works as a bearer token or in its own header, and its use is recorded
+	if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", "Bearer "+created.Key); w.Code != http.StatusOK {
+		t.Errorf("expected the key accepted as a bearer token, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveWithHeader(router, "GET", "/interviews", nil, apiKeyHeader, created.Key); w.Code != http.StatusOK {
+		t.Errorf("expected the key accepted in %s, got %d", apiKeyHeader, w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/interviews", nil, apiKeyHeader, created.Key+"0"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected an unknown key refused, got %d", w.Code)
+	}
+
+	w = serveWithHeader(router, "GET", "/api-keys", nil, "Authorization", staff)
+	var list ListAPIKeysResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 1 || list.APIKeys[0].Key != "" || list.APIKeys[0].LastUsedAt == nil {
+		t.Fatalf("expected the key listed with its last use and without the key, got %s", w.Body.String())
+	}
+
+	// Revoked keys stop working
+	if w := serveWithHeader(router, "DELETE", "/api-keys/missing", nil, "Authorization", staff); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 revoking a missing key, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "DELETE", "/api-keys/"+created.ID, nil, "Authorization", staff); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the key revoked, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", "Bearer "+created.Key); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a revoked key refused, got %d", w.Code)
+	}
+	w = serveWithHeader(router, "GET", "/api-keys", nil, "Authorization", staff)
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 1 || list.APIKeys[0].RevokedAt == nil {
+		t.Errorf("expected the revoked key still listed, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,323 @@
+// Authentication of staff, integration and candidate credentials
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Kinds of principal a request can be authenticated as
+const (
+	PrincipalStaffToken = "staff_token" // The static STAFF_API_TOKEN
+	PrincipalUser       = "user"        // A staff member signed in with a JWT
+	PrincipalAPIKey     = "api_key"     // A server-to-server integration
+	PrincipalCandidate  = "candidate"   // A candidate holding an invitation token
+)
+
+// Credential formats and limits
+const (
+	apiKeyHeader        = "X-API-Key"
+	apiKeyPrefix        = "aik_"
+	apiKeyTouchInterval = time.Minute // Last use is recorded at most this often per key
+	jwtClockSkew        = time.Minute
+)
+
+// principalContextKey stores the authenticated Principal in the request context
+const principalContextKey contextKey = "principal"
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Kind       string
+	Role       Role
+	Subject    string // JWT subject, API key ID or invitation ID
+	Name       string
+	Email      string
+	Invitation *data.Invitation // The invitation a candidate was admitted with
+}
+
+// principalFromContext returns the request's authenticated principal, or nil for anonymous requests
+func principalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalContextKey).(*Principal)
+	return principal
+}
+
+// invitationFromContext returns the invitation a candidate request was admitted with, or nil for staff
+func invitationFromContext(ctx context.Context) *data.Invitation {
+	if principal := principalFromContext(ctx); principal != nil {
+		return principal.Invitation
+	}
+	return nil
+}
+
+// requestIdentity returns who a request acts as for sign-offs and ratings. Authenticated requests act
+// as their principal, and an identity claimed in the request must match it; only without a principal,
+// when authentication is disabled, is the claimed identity taken as given.
+func requestIdentity(r *http.Request, claimed string) (identity string, matches bool) {
+	claimed = strings.TrimSpace(claimed)
+	principal := principalFromContext(r.Context())
+	if principal == nil {
+		return claimed, true
+	}
+	return principal.Subject, claimed == "" || claimed == principal.Subject
+}
+
+// jwtVerifier checks staff JWTs signed with HS256 using a shared secret or RS256 using the keys
+// of a JWKS file
+type jwtVerifier struct {
+	secret   []byte
+	keys     map[string]*rsa.PublicKey // RS256 keys by key ID
+	issuer   string
+	audience string
+}
+
+// jwtAudience accepts the aud claim as a single string or an array
+type jwtAudience []string
+
+func (a *jwtAudience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = jwtAudience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// jwtClaims are the registered and profile claims read from staff JWTs
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss"`
+	Audience  jwtAudience `json:"aud"`
+	ExpiresAt int64       `json:"exp"`
+	NotBefore int64       `json:"nbf"`
+	Name      string      `json:"name"`
+	Email     string      `json:"email"`
+	Role      string      `json:"role"`
+	Roles     []string    `json:"roles"`
+}
+
+// role returns the first staff role named by the role or roles claim, or "" without one, which
+// grants no permissions
+func (c *jwtClaims) role() Role {
+	for _, name := range append([]string{c.Role}, c.Roles...) {
+		if role, ok := parseStaffRole(name); ok {
+			return role
+		}
+	}
+	return ""
+}
+
+// newJWTVerifier creates a verifier from the JWT configuration, or returns nil when neither a
+// secret nor a JWKS file is configured
+func newJWTVerifier(cfg *config.Config) (*jwtVerifier, error) {
+	if cfg.JWTSecret == "" && cfg.JWKSFile == "" {
+		return nil, nil
+	}
+	verifier := &jwtVerifier{secret: []byte(cfg.JWTSecret), issuer: cfg.JWTIssuer, audience: cfg.JWTAudience}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		verifier.keys = keys
+	}
+	return verifier, nil
+}
+
+// loadJWKS reads the RSA signing keys of a JWKS file
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &jwks); err != nil {
+		return nil, fmt.Errorf("invalid JWKS file: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range jwks.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(key.N)
+		e, errE := base64.RawURLEncoding.DecodeString(key.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA key %q in JWKS file", key.Kid)
+		}
+		keys[key.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS file has no RSA signing keys")
+	}
+	return keys, nil
+}
+
+// verify checks a JWT's signature and registered claims at the given time and returns its claims
+func (v *jwtVerifier) verify(token string, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, errors.New("malformed token header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+
+	signed := parts[0] + "." + parts[1]
+	switch header.Alg {
+	case "HS256":
+		if len(v.secret) == 0 {
+			return nil, errors.New("HS256 tokens are not accepted")
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return nil, errors.New("invalid signature")
+		}
+	case "RS256":
+		key := v.keys[header.Kid]
+		if key == nil && header.Kid == "" && len(v.keys) == 1 {
+			for _, only := range v.keys {
+				key = only
+			}
+		}
+		if key == nil {
+			return nil, errors.New("unknown signing key")
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+			return nil, errors.New("invalid signature")
+		}
+	default:
+		// Anything else, including "none", could let a forged token through
+		return nil, fmt.Errorf("unsupported algorithm %q", header.Alg)
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, errors.New("malformed token claims")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("missing sub claim")
+	}
+	if claims.ExpiresAt == 0 {
+		return nil, errors.New("missing exp claim")
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(jwtClockSkew)) {
+		return nil, errors.New("token has expired")
+	}
+	if claims.NotBefore != 0 && now.Add(jwtClockSkew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, errors.New("token is not valid yet")
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return nil, errors.New("unexpected issuer")
+	}
+	if v.audience != "" && !slices.Contains(claims.Audience, v.audience) {
+		return nil, errors.New("unexpected audience")
+	}
+	return &claims, nil
+}
+
+// Helper: decode a base64url JSON segment of a JWT
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// Helper: SHA-256 hex digest of an API key, as stored
+func hashAPIKey(key string) string {
+	return hashResumeToken(key)
+}
+
+// Helper: look up an API key and record its use, returning an error message if it is not valid
+func authenticateAPIKey(key string) (*Principal, string) {
+	apiKey, err := data.GlobalStore.GetAPIKeyByHash(hashAPIKey(key))
+	if err != nil || apiKey.RevokedAt != nil {
+		return nil, "Invalid API key"
+	}
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := data.GlobalStore.TouchAPIKey(apiKey.ID, now); err != nil {
+			utils.Warningf("Failed to record use of API key %s: %v", apiKey.ID, err)
+		}
+	}
+	return &Principal{Kind: PrincipalAPIKey, Role: Role(apiKey.Role), Subject: apiKey.ID, Name: apiKey.Name}, ""
+}
+
+// authenticate identifies the caller from the request's credentials. It returns nil for a request
+// without credentials and an error message when credentials are present but not valid.
+func (deps *HandlerDependencies) authenticate(r *http.Request) (*Principal, string) {
+	if token := r.Header.Get(invitationTokenHeader); token != "" {
+		invitation, msg := deps.verifyInvitationToken(token)
+		if msg != "" {
+			return nil, msg
+		}
+		return &Principal{Kind: PrincipalCandidate, Role: RoleCandidate, Subject: invitation.ID, Invitation: invitation}, ""
+	}
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return authenticateAPIKey(key)
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		return nil, ""
+	}
+	credential, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return nil, "Unsupported Authorization scheme; use Bearer"
+	}
+	switch {
+	case deps.StaffAPIToken != "" && subtle.ConstantTimeCompare([]byte(credential), []byte(deps.StaffAPIToken)) == 1:
+		return &Principal{Kind: PrincipalStaffToken, Role: RoleAdmin, Subject: PrincipalStaffToken}, ""
+	case strings.HasPrefix(credential, apiKeyPrefix):
+		return authenticateAPIKey(credential)
+	case deps.JWT != nil && strings.Count(credential, ".") == 2:
+		claims, err := deps.JWT.verify(credential, time.Now())
+		if err != nil {
+			return nil, "Invalid token: " + err.Error()
+		}
+		return &Principal{Kind: PrincipalUser, Role: claims.role(), Subject: claims.Subject, Name: claims.Name, Email: claims.Email}, ""
+	}
+	return nil, "Invalid credentials"
+}
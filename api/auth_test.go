@@ -0,0 +1,165 @@
+package api
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+)
+
+// signTestJWT builds a JWT with the given header and claims, signed with an HMAC secret or an RSA key
+func signTestJWT(t *testing.T, header, claims map[string]interface{}, secret []byte, key *rsa.PrivateKey) string {
+	t.Helper()
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	var signature []byte
+	if key != nil {
+		digest := sha256.Sum256([]byte(signed))
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+	} else {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTVerifier_HS256(t *testing.T) {
+	verifier, err := newJWTVerifier(&config.Config{JWTSecret: "jwt-secret", JWTIssuer: "https://idp.example.com", JWTAudience: "interviews"})
+	if err != nil || verifier == nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	now := time.Now()
+	hs256 := map[string]interface{}{"alg": "HS256", "typ": "JWT"}
+	valid := map[string]interface{}{
+		"sub": "user-1", "name": "Riley", "iss": "https://idp.example.com", "aud": []string{"other", "interviews"},
+		"exp": now.Add(time.Hour).Unix(),
+	}
+
+	claims, err := verifier.verify(signTestJWT(t, hs256, valid, []byte("jwt-secret"), nil), now)
+	if err != nil || claims.Subject != "user-1" || claims.Name != "Riley" {
+		t.Fatalf("expected a valid token, got %+v (%v)", claims, err)
+	}
+
+	with := func(key string, value interface{}) map[string]interface{} {
+		changed := make(map[string]interface{}, len(valid))
+		for k, v := range valid {
+			changed[k] = v
+		}
+		if value == nil {
+			delete(changed, key)
+		} else {
+			changed[key] = value
+		}
+		return changed
+	}
+	for name, token := range map[string]string{
+		"wrong secret":       signTestJWT(t, hs256, valid, []byte("other-secret"), nil),
+		"expired":            signTestJWT(t, hs256, with("exp", now.Add(-time.Hour).Unix()), []byte("jwt-secret"), nil),
+		"missing exp":        signTestJWT(t, hs256, with("exp", nil), []byte("jwt-secret"), nil),
+		"not yet valid":      signTestJWT(t, hs256, with("nbf", now.Add(time.Hour).Unix()), []byte("jwt-secret"), nil),
+		"wrong issuer":       signTestJWT(t, hs256, with("iss", "https://evil.example.com"), []byte("jwt-secret"), nil),
+		"wrong audience":     signTestJWT(t, hs256, with("aud", "billing"), []byte("jwt-secret"), nil),
+		"missing subject":    signTestJWT(t, hs256, with("sub", nil), []byte("jwt-secret"), nil),
+		"alg none":           signTestJWT(t, map[string]interface{}{"alg": "none"}, valid, nil, nil),
+		"RS256 without keys": signTestJWT(t, map[string]interface{}{"alg": "RS256"}, valid, []byte("jwt-secret"), nil),
+	} {
+		if _, err := verifier.verify(token, now); err == nil {
+			t.Errorf("%s: expected the token rejected", name)
+		}
+	}
+}
+
+func TestJWTVerifier_RS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA", "kid": "key-1", "use": "sig", "alg": "RS256",
+		"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatalf("failed to write JWKS file: %v", err)
+	}
+
+	verifier, err := newJWTVerifier(&config.Config{JWKSFile: path})
+	if err != nil {
+		t.Fatalf("failed to load JWKS: %v", err)
+	}
+	claims := map[string]interface{}{"sub": "user-2", "exp": time.Now().Add(time.Hour).Unix()}
+	if _, err := verifier.verify(signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "key-1"}, claims, nil, key), time.Now()); err != nil {
+		t.Errorf("expected a valid RS256 token, got %v", err)
+	}
+
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := verifier.verify(signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "key-1"}, claims, nil, other), time.Now()); err == nil {
+		t.Error("expected a token signed with another key rejected")
+	}
+	if _, err := verifier.verify(signTestJWT(t, map[string]interface{}{"alg": "RS256", "kid": "key-2"}, claims, nil, key), time.Now()); err == nil {
+		t.Error("expected a token for an unknown key ID rejected")
+	}
+	// Without a secret, HS256 tokens cannot be used to get around the RSA keys
+	if _, err := verifier.verify(signTestJWT(t, map[string]interface{}{"alg": "HS256"}, claims, []byte(""), nil), time.Now()); err == nil {
+		t.Error("expected an HS256 token rejected without a configured secret")
+	}
+
+	if _, err := newJWTVerifier(&config.Config{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected a missing JWKS file to fail")
+	}
+}
+
+func TestAuthMiddleware_JWTPrincipal(t *testing.T) {
+	clearMemoryStore()
+	router := SetupRouter(&config.Config{
+		OpenAIAPIKey: "test-openai-key",
+		GeminiAPIKey: "test-gemini-key",
+		JWTSecret:    "jwt-secret",
+	})
+
+	sign := func(claims map[string]interface{}) string {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+		return signTestJWT(t, map[string]interface{}{"alg": "HS256"}, claims, []byte("jwt-secret"), nil)
+	}
+	token := sign(map[string]interface{}{"sub": "user-1", "roles": []string{"staff", "admin"}})
+	if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", "Bearer "+token); w.Code != http.StatusOK {
+		t.Errorf("expected a signed-in user to list interviews, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveWithHeader(router, "GET", "/interviews", nil, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected credentials required once JWTs are configured, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", "Bearer "+token+"x"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a tampered token refused, got %d", w.Code)
+	}
+	// Tokens without a staff role, including ones claiming to be candidates, grant nothing
+	for _, claims := range []map[string]interface{}{{"sub": "user-2"}, {"sub": "user-3", "role": "candidate"}} {
+		if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", "Bearer "+sign(claims)); w.Code != http.StatusForbidden {
+			t.Errorf("expected a token without a staff role forbidden, got %d", w.Code)
+		}
+	}
+
+	// API keys record the signed-in user that created them
+	w := serveWithHeader(router, "POST", "/api-keys", []byte(`{"name":"ATS"}`), "Authorization", "Bearer "+token)
+	var created APIKeyResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	if w.Code != http.StatusCreated || created.CreatedBy != "user-1" {
+		t.Errorf("expected the key created by the user, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,298 @@
+// HTTP handler for creating many interviews at once from a CSV or JSON lines upload
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Bulk import limits and partial-failure modes
+const (
+	maxBulkInterviewRows  = 1000
+	maxBulkInterviewBytes = 5 << 20
+
+	bulkModeAllOrNothing = "all_or_nothing" // Create nothing if any row fails
+	bulkModeBestEffort   = "best_effort"    // Create the valid rows and report the rest
+
+	bulkRowCreated = "created"
+	bulkRowFailed  = "failed"
+	bulkRowSkipped = "skipped"
+)
+
+// bulkCSVColumns maps accepted CSV header names, including short aliases, to row fields
+var bulkCSVColumns = map[string]func(row *BulkInterviewRowDTO, value string){
+	"candidate_name":     func(row *BulkInterviewRowDTO, value string) { row.CandidateName = value },
+	"candidate_id":       func(row *BulkInterviewRowDTO, value string) { row.CandidateID = value },
+	"email":              func(row *BulkInterviewRowDTO, value string) { row.Email = value },
+	"interview_language": func(row *BulkInterviewRowDTO, value string) { row.InterviewLanguage = value },
+	"language":           func(row *BulkInterviewRowDTO, value string) { row.InterviewLanguage = value },
+	"interview_type":     func(row *BulkInterviewRowDTO, value string) { row.InterviewType = value },
+	"type":               func(row *BulkInterviewRowDTO, value string) { row.InterviewType = value },
+	"template_id":        func(row *BulkInterviewRowDTO, value string) { row.TemplateID = value },
+	"position_id":        func(row *BulkInterviewRowDTO, value string) { row.PositionID = value },
+	"job_id":             func(row *BulkInterviewRowDTO, value string) { row.PositionID = value },
+	"job_description":    func(row *BulkInterviewRowDTO, value string) { row.JobDescription = value },
+	// Questions are separated by "|" so they fit in one column
+	"questions": func(row *BulkInterviewRowDTO, value string) {
+		for _, question := range strings.Split(value, "|") {
+			if question = strings.TrimSpace(question); question != "" {
+				row.Questions = append(row.Questions, question)
+			}
+		}
+	},
+}
+
+// bulkInterviewRow is an uploaded row with the line it came from, or the reason it could not be read
+type bulkInterviewRow struct {
+	line     int
+	row      BulkInterviewRowDTO
+	parseErr string
+}
+
+// bulkInterviewImport collects the records a bulk import will create
+type bulkInterviewImport struct {
+	candidates []*data.Candidate
+	byEmail    map[string]*data.Candidate // Candidates matched or created by email so far
+	interviews []*data.Interview
+	events     []*data.WebhookEvent
+	createdBy  string // Principal the interviews are created by
+}
+
+// Helper: parse a CSV upload with a header row into bulk rows, returning an error message for malformed CSV
+func parseBulkInterviewCSV(body []byte) ([]bulkInterviewRow, string) {
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, "Invalid CSV: missing header row"
+	}
+	setters := make([]func(row *BulkInterviewRowDTO, value string), len(header))
+	for i, column := range header {
+		setter, known := bulkCSVColumns[strings.ToLower(strings.TrimSpace(column))]
+		if !known {
+			return nil, fmt.Sprintf("Invalid CSV: unknown column %q", column)
+		}
+		setters[i] = setter
+	}
+
+	var rows []bulkInterviewRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, ""
+		}
+		if err != nil {
+			return nil, "Invalid CSV: " + err.Error()
+		}
+		line, _ := reader.FieldPos(0)
+		parsed := bulkInterviewRow{line: line}
+		for i, value := range record {
+			setters[i](&parsed.row, strings.TrimSpace(value))
+		}
+		rows = append(rows, parsed)
+	}
+}
+
+// Helper: parse a JSON lines upload into bulk rows; a line that is not valid JSON fails only that row
+func parseBulkInterviewJSONLines(body []byte) ([]bulkInterviewRow, string) {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBulkInterviewBytes)
+	var rows []bulkInterviewRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		parsed := bulkInterviewRow{line: line}
+		if err := json.Unmarshal(text, &parsed.row); err != nil {
+			parsed.parseErr = "Invalid JSON: " + err.Error()
+		}
+		rows = append(rows, parsed)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "Invalid JSON lines: " + err.Error()
+	}
+	return rows, ""
+}
+
+// addRow resolves and validates one row the way POST /interviews does and adds its records to the
+// import, returning an error message or "" if the row is valid
+func (imp *bulkInterviewImport) addRow(row *BulkInterviewRowDTO) (*data.Interview, string) {
+	req := row.CreateInterviewRequestDTO
+	if msg := applyInterviewPosition(&req); msg != "" {
+		return nil, msg
+	}
+	if req.TemplateID != "" {
+		template, err := data.GlobalStore.GetInterviewTemplate(req.TemplateID)
+		if err != nil {
+			return nil, "Template not found"
+		}
+		applyInterviewTemplate(&req, template)
+	}
+
+	// A candidate named by email is matched to an existing record, or created with the interview
+	email := strings.ToLower(strings.TrimSpace(row.Email))
+	var candidate *data.Candidate
+	if email != "" {
+		if req.CandidateID != "" {
+			return nil, "Provide candidate_id or email, not both"
+		}
+		candidate = imp.byEmail[email]
+		if candidate == nil {
+			found, _, err := data.GlobalStore.ListCandidates(data.CandidateFilters{Email: email}, 1, 0)
+			if err != nil {
+				return nil, "Failed to look up candidate: " + err.Error()
+			}
+			if len(found) > 0 {
+				candidate = found[0]
+			}
+		}
+		if candidate != nil {
+			fillInterviewFromCandidate(&req, candidate)
+		}
+	} else if msg := applyInterviewCandidate(&req); msg != "" {
+		return nil, msg
+	}
+
+	if msg := validateInterviewRequest(&req); msg != "" {
+		return nil, msg
+	}
+	if len(req.QuestionIDs) > 0 {
+		if _, err := data.GlobalStore.GetQuestionsByIDs(req.QuestionIDs); err != nil {
+			return nil, "Invalid question_ids: " + err.Error()
+		}
+	}
+	if email != "" && candidate == nil {
+		candidateReq := CandidateRequestDTO{Name: req.CandidateName, Email: email, Locale: req.InterviewLanguage}
+		normalizeCandidateRequest(&candidateReq)
+		if msg := validateCandidateRequest(&candidateReq); msg != "" {
+			return nil, msg
+		}
+		candidate = &data.Candidate{ID: data.GenerateID(), CreatedBy: imp.createdBy}
+		applyCandidateRequest(candidate, &candidateReq)
+		imp.candidates = append(imp.candidates, candidate)
+	}
+
+	interview := newInterviewFromRequest(&req)
+	interview.CreatedBy = imp.createdBy
+	if candidate != nil {
+		imp.byEmail[email] = candidate
+		interview.CandidateID = &candidate.ID
+	}
+	imp.interviews = append(imp.interviews, interview)
+	imp.events = append(imp.events, newWebhookEvent(data.WebhookEventInterviewCreated, newInterviewResponseDTO(interview)))
+	return interview, ""
+}
+
+// BulkCreateInterviewsHandler handles POST /interviews/bulk
+// Accepts text/csv with a header row or application/x-ndjson (one interview request per line). Every row is
+// validated like POST /interviews; a row may give a candidate email instead of candidate_id, creating the
+// candidate if needed. ?mode=all_or_nothing (default) creates nothing if any row fails; ?mode=best_effort
+// creates the valid rows. The rows are created together, in one transaction on the database backend.
+func BulkCreateInterviewsHandler(w http.ResponseWriter, r *http.Request) {
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = bulkModeAllOrNothing
+	}
+	if mode != bulkModeAllOrNothing && mode != bulkModeBestEffort {
+		writeJSONError(w, http.StatusBadRequest, "Invalid mode. Supported values: all_or_nothing, best_effort")
+		return
+	}
+
+	var parse func([]byte) ([]bulkInterviewRow, string)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		parse = parseBulkInterviewCSV
+	case "application/x-ndjson", "application/jsonl", "application/jsonlines":
+		parse = parseBulkInterviewJSONLines
+	default:
+		writeJSONError(w, http.StatusUnsupportedMediaType, "Unsupported Content-Type. Use text/csv or application/x-ndjson")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBulkInterviewBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Import must be at most %d bytes", maxBulkInterviewBytes))
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "Failed to read request body", err.Error())
+		return
+	}
+	rows, msg := parse(body)
+	if msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if len(rows) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "No rows to import")
+		return
+	}
+	if len(rows) > maxBulkInterviewRows {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("At most %d rows can be imported at once", maxBulkInterviewRows))
+		return
+	}
+
+	resp := BulkInterviewResponseDTO{Mode: mode, Total: len(rows), Results: make([]BulkInterviewRowResultDTO, len(rows))}
+	imp := &bulkInterviewImport{byEmail: make(map[string]*data.Candidate), createdBy: creatorOf(r)}
+	for i := range rows {
+		result := &resp.Results[i]
+		result.Line = rows[i].line
+		if rows[i].parseErr != "" {
+			result.Status, result.Error = bulkRowFailed, rows[i].parseErr
+			resp.Failed++
+			continue
+		}
+		interview, msg := imp.addRow(&rows[i].row)
+		if msg != "" {
+			result.Status, result.Error = bulkRowFailed, msg
+			resp.Failed++
+			continue
+		}
+		result.Status, result.InterviewID, result.CandidateID = bulkRowCreated, interview.ID, stringValue(interview.CandidateID)
+	}
+
+	if resp.Failed > 0 && mode == bulkModeAllOrNothing {
+		for i := range resp.Results {
+			if resp.Results[i].Status == bulkRowCreated {
+				resp.Results[i] = BulkInterviewRowResultDTO{Line: resp.Results[i].Line, Status: bulkRowSkipped}
+			}
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+	if len(imp.interviews) == 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	if err := data.GlobalStore.CreateInterviewBatch(imp.candidates, imp.interviews, imp.events); err != nil {
+		if errors.Is(err, data.ErrDuplicateCandidate) {
+			writeJSONError(w, http.StatusConflict, "A candidate with one of these emails was created concurrently; retry the import")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create interviews", err.Error())
+		return
+	}
+	for _, interview := range imp.interviews {
+		if err := data.GlobalStore.IncrementQuestionUsage(interview.QuestionIDs); err != nil {
+			utils.Warningf("Failed to update question usage counts: %v", err)
+		}
+	}
+
+	resp.Created = len(imp.interviews)
+	writeJSON(w, http.StatusCreated, resp)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// postBulkInterviews uploads a bulk import and returns the recorder
+func postBulkInterviews(router http.Handler, query, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/interviews/bulk"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBulkCreateInterviews_CSV(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	existing := createTestCandidate(t, router, CandidateRequestDTO{Name: "Jane Doe", Email: "jane@example.com", Locale: "zh-TW"})
+
+	csvBody := "candidate_name,email,type,questions\n" +
+		"Jane,JANE@example.com,technical,Q1|Q2\n" +
+		"Alex Roe,alex@example.com,general,Q1\n" +
+		"Alex Roe,alex@example.com,behavioral,Q3\n"
+
+	// One bad row stops an all_or_nothing import and the valid rows are reported as skipped
+	w := postBulkInterviews(router, "", "text/csv", csvBody+"Bad Row,,unknown,Q1\n")
+	var resp BulkInterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusUnprocessableEntity || resp.Failed != 1 || resp.Created != 0 {
+		t.Fatalf("expected the import rejected, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Results[0].Status != bulkRowSkipped || resp.Results[3].Status != bulkRowFailed || resp.Results[3].Line != 5 {
+		t.Errorf("expected valid rows skipped and the bad row reported by line, got %+v", resp.Results)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews", nil))
+	var list ListInterviewsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 0 {
+		t.Fatalf("expected no interviews created, got %d", list.Total)
+	}
+
+	// A valid import matches existing candidates by email and creates new ones once
+	w = postBulkInterviews(router, "", "text/csv; charset=utf-8", csvBody)
+	resp = BulkInterviewResponseDTO{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusCreated || resp.Created != 3 || resp.Failed != 0 {
+		t.Fatalf("expected all rows created, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.Results[0].CandidateID != existing.ID {
+		t.Errorf("expected the existing candidate reused, got %+v", resp.Results[0])
+	}
+	if resp.Results[1].CandidateID == "" || resp.Results[1].CandidateID != resp.Results[2].CandidateID {
+		t.Errorf("expected one new candidate for both rows, got %+v", resp.Results)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+resp.Results[0].InterviewID, nil))
+	var interview InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interview)
+	if interview.CandidateName != "Jane" || interview.InterviewLanguage != "zh-TW" || len(interview.Questions) != 2 {
+		t.Errorf("expected the row's details with the candidate's language, got %+v", interview)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/candidates?email=alex@example.com", nil))
+	var candidates ListCandidatesResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &candidates)
+	if candidates.Total != 1 || candidates.Candidates[0].Name != "Alex Roe" {
+		t.Errorf("expected the new candidate created from the row, got %+v", candidates)
+	}
+
+	// Malformed uploads are rejected outright
+	expectBulkStatus(t, postBulkInterviews(router, "", "text/csv", "candidate_name,nickname\nA,B\n"), http.StatusBadRequest)
+	expectBulkStatus(t, postBulkInterviews(router, "", "text/csv", "candidate_name,questions\n"), http.StatusBadRequest)
+	expectBulkStatus(t, postBulkInterviews(router, "?mode=sometimes", "text/csv", csvBody), http.StatusBadRequest)
+	expectBulkStatus(t, postBulkInterviews(router, "", "application/json", csvBody), http.StatusUnsupportedMediaType)
+}
+
+func TestBulkCreateInterviews_JSONLinesBestEffort(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	position := createTestPosition(t, router, PositionRequestDTO{Title: "Backend Engineer", Description: "Build APIs"})
+
+	body := `{"candidate_name":"A","questions":["Q1"],"interview_type":"general","position_id":"` + position.ID + `"}` + "\n" +
+		"\n" +
+		`{"candidate_name":"B","questions":["Q1"],"interview_type":"general","candidate_id":"missing"}` + "\n" +
+		`not json` + "\n" +
+		`{"candidate_name":"C","questions":["Q1"],"interview_type":"general","candidate_id":"x","email":"c@example.com"}` + "\n" +
+		`{"candidate_name":"D","questions":["Q1"],"interview_type":"general"}` + "\n"
+
+	w := postBulkInterviews(router, "?mode=best_effort", "application/x-ndjson", body)
+	var resp BulkInterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if w.Code != http.StatusCreated || resp.Total != 5 || resp.Created != 2 || resp.Failed != 3 {
+		t.Fatalf("expected the valid rows created, got %d: %s", w.Code, w.Body.String())
+	}
+	lines := []int{1, 3, 4, 5, 6}
+	statuses := []string{bulkRowCreated, bulkRowFailed, bulkRowFailed, bulkRowFailed, bulkRowCreated}
+	for i, result := range resp.Results {
+		if result.Line != lines[i] || result.Status != statuses[i] {
+			t.Errorf("row %d: expected line %d %s, got %+v", i, lines[i], statuses[i], result)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+resp.Results[0].InterviewID, nil))
+	var interview InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interview)
+	if interview.PositionID != position.ID || interview.JobDescription != "Build APIs" {
+		t.Errorf("expected the position applied to the row, got %+v", interview)
+	}
+
+	// A best_effort import with no valid rows creates nothing
+	w = postBulkInterviews(router, "?mode=best_effort", "application/x-ndjson", "not json\n")
+	expectBulkStatus(t, w, http.StatusUnprocessableEntity)
+}
+
+// expectBulkStatus checks a bulk import response status
+func expectBulkStatus(t *testing.T, w *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if w.Code != status {
+		t.Errorf("expected status %d, got %d: %s", status, w.Code, w.Body.String())
+	}
+}
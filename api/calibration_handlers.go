@@ -0,0 +1,443 @@
+// HTTP handler functions for multi-rater calibration of evaluations
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Default AI score thresholds used to turn AI scores into decisions for agreement metrics
+const (
+	defaultAIAdvanceThreshold = 0.7
+	defaultAIRejectThreshold  = 0.5
+)
+
+// Helper: convert a calibration rating to its DTO
+func newRatingDTO(rating *data.EvaluationRating) RatingDTO {
+	return RatingDTO{
+		Rater:          rating.Rater,
+		Score:          rating.Score,
+		Decision:       rating.Decision,
+		CategoryScores: rating.CategoryScores,
+		Comment:        rating.Comment,
+		CreatedAt:      rating.CreatedAt,
+	}
+}
+
+// Helper: check whether every assigned rater has submitted a rating
+func calibrationComplete(raters []string, ratings []*data.EvaluationRating) bool {
+	if len(raters) < 2 {
+		return false
+	}
+	submitted := make(map[string]bool, len(ratings))
+	for _, rating := range ratings {
+		submitted[rating.Rater] = true
+	}
+	for _, rater := range raters {
+		if !submitted[rater] {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper: build the blind calibration status as seen by the given rater
+func newCalibrationStatusDTO(evaluation *data.Evaluation, ratings []*data.EvaluationRating, viewer string) CalibrationStatusDTO {
+	complete := calibrationComplete(evaluation.CalibrationRaters, ratings)
+	status := CalibrationStatusDTO{
+		EvaluationID: evaluation.ID,
+		Raters:       evaluation.CalibrationRaters,
+		Submitted:    []string{},
+		Complete:     complete,
+		Ratings:      []RatingDTO{},
+	}
+	if status.Raters == nil {
+		status.Raters = []string{}
+	}
+	for _, rating := range ratings {
+		status.Submitted = append(status.Submitted, rating.Rater)
+		if complete || rating.Rater == viewer {
+			status.Ratings = append(status.Ratings, newRatingDTO(rating))
+		}
+	}
+	return status
+}
+
+// Helper: map an AI score to a decision using the report thresholds
+func aiDecisionForScore(score float64, thresholds AIDecisionThresholdsDTO) string {
+	switch {
+	case score >= thresholds.Advance:
+		return data.DecisionAdvance
+	case score < thresholds.Reject:
+		return data.DecisionReject
+	default:
+		return data.DecisionHold
+	}
+}
+
+// Helper: return the most common decision, or "" when the top decisions tie
+func majorityDecision(counts map[string]int) string {
+	best, bestCount, tied := "", 0, false
+	for decision, count := range counts {
+		switch {
+		case count > bestCount:
+			best, bestCount, tied = decision, count, false
+		case count == bestCount:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return best
+}
+
+// Helper: wrap a metric that may be undefined
+func optionalMetric(value float64, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// AssignEvaluationRatersHandler handles PUT /evaluation/{id}/raters
+// Raters are named by their principal subject, such as the sub claim of their JWT.
+func AssignEvaluationRatersHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingEvaluationID)
+		return
+	}
+
+	evaluation, err := data.GlobalStore.GetEvaluation(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Evaluation not found")
+		return
+	}
+
+	var req AssignRatersRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	raters := []string{}
+	assigned := make(map[string]bool, len(req.Raters))
+	for _, rater := range req.Raters {
+		rater = strings.TrimSpace(rater)
+		if rater == "" || assigned[rater] {
+			continue
+		}
+		assigned[rater] = true
+		raters = append(raters, rater)
+	}
+	if len(raters) < 2 {
+		writeJSONError(w, http.StatusBadRequest, "At least two distinct raters are required")
+		return
+	}
+	// A rater could otherwise drop the pending raters to reveal the others' ratings early
+	if principal := principalFromContext(r.Context()); principal != nil && assigned[principal.Subject] {
+		writeForbidden(w, r, "Raters cannot assign their own calibration panel", PermEvaluationsWrite)
+		return
+	}
+
+	// Raters who already submitted cannot be dropped, or their rating would be orphaned
+	ratings, err := data.GlobalStore.GetEvaluationRatings([]string{id})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch ratings", err.Error())
+		return
+	}
+	for _, rating := range ratings {
+		if !assigned[rating.Rater] {
+			writeJSONError(w, http.StatusConflict, "Cannot remove a rater who has already submitted: "+rating.Rater)
+			return
+		}
+	}
+
+	if err := data.GlobalStore.AssignEvaluationRaters(id, raters); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to assign raters", err.Error())
+		return
+	}
+	evaluation.CalibrationRaters = raters
+
+	writeJSON(w, http.StatusOK, newCalibrationStatusDTO(evaluation, ratings, ""))
+}
+
+// SubmitEvaluationRatingHandler handles POST /evaluation/{id}/ratings
+func SubmitEvaluationRatingHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingEvaluationID)
+		return
+	}
+
+	evaluation, err := data.GlobalStore.GetEvaluation(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Evaluation not found")
+		return
+	}
+
+	var req RatingRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	// Ratings are recorded under the caller's own identity
+	rater, matches := requestIdentity(r, req.Rater)
+	if !matches {
+		writeForbidden(w, r, "rater does not match the signed-in principal", PermEvaluationsReview)
+		return
+	}
+	isAssigned := false
+	for _, assigned := range evaluation.CalibrationRaters {
+		if assigned == rater {
+			isAssigned = true
+			break
+		}
+	}
+	if !isAssigned {
+		writeJSONError(w, http.StatusBadRequest, "Rater is not assigned to this evaluation")
+		return
+	}
+	if req.Score == nil || *req.Score < 0 || *req.Score > 1 {
+		writeJSONError(w, http.StatusBadRequest, "Invalid score: must be between 0.0 and 1.0")
+		return
+	}
+	if !data.ValidateDecision(req.Decision) {
+		writeJSONError(w, http.StatusBadRequest, "Invalid decision. Supported values: advance, hold, reject")
+		return
+	}
+	for key, score := range req.CategoryScores {
+		if score < 0 || score > 1 {
+			writeJSONError(w, http.StatusBadRequest, "Invalid score for "+key+": must be between 0.0 and 1.0")
+			return
+		}
+	}
+
+	rating := &data.EvaluationRating{
+		ID:             data.GenerateID(),
+		EvaluationID:   id,
+		Rater:          rater,
+		Score:          *req.Score,
+		CategoryScores: req.CategoryScores,
+		Decision:       req.Decision,
+		Comment:        req.Comment,
+		CreatedAt:      time.Now(),
+	}
+	if err := data.GlobalStore.CreateEvaluationRating(rating); err != nil {
+		if errors.Is(err, data.ErrDuplicateRating) {
+			writeJSONError(w, http.StatusConflict, "Rater has already rated this evaluation")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to save rating", err.Error())
+		return
+	}
+
+	ratings, err := data.GlobalStore.GetEvaluationRatings([]string{id})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch ratings", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, newCalibrationStatusDTO(evaluation, ratings, rater))
+}
+
+// GetEvaluationRatingsHandler handles GET /evaluation/{id}/ratings
+// Ratings stay blind: until every assigned rater submits, only the caller's own rating is returned.
+// The caller is the signed-in principal; ?rater= names them only when authentication is disabled.
+func GetEvaluationRatingsHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingEvaluationID)
+		return
+	}
+
+	evaluation, err := data.GlobalStore.GetEvaluation(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Evaluation not found")
+		return
+	}
+
+	ratings, err := data.GlobalStore.GetEvaluationRatings([]string{id})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch ratings", err.Error())
+		return
+	}
+
+	// Callers only ever see their own rating early, whatever ?rater= says
+	viewer, matches := requestIdentity(r, r.URL.Query().Get("rater"))
+	if !matches {
+		writeForbidden(w, r, "rater does not match the signed-in principal", PermEvaluationsRead)
+		return
+	}
+	writeJSON(w, http.StatusOK, newCalibrationStatusDTO(evaluation, ratings, viewer))
+}
+
+// GetCalibrationReportHandler handles GET /templates/{id}/calibration-report
+// Reports inter-rater agreement across fully rated evaluations of interviews created from the template.
+func GetCalibrationReportHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingTemplateID)
+		return
+	}
+	if _, err := data.GlobalStore.GetInterviewTemplate(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Template not found")
+		return
+	}
+
+	advance, err := parseFloatQuery(r, "advance_threshold", defaultAIAdvanceThreshold)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid thresholds: "+err.Error())
+		return
+	}
+	reject, err := parseFloatQuery(r, "reject_threshold", defaultAIRejectThreshold)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid thresholds: "+err.Error())
+		return
+	}
+	thresholds := AIDecisionThresholdsDTO{Advance: advance, Reject: reject}
+	if thresholds.Reject < 0 || thresholds.Advance > 1 || thresholds.Reject > thresholds.Advance {
+		writeJSONError(w, http.StatusBadRequest, "Invalid thresholds: require 0 <= reject_threshold <= advance_threshold <= 1")
+		return
+	}
+
+	evaluations, err := data.GlobalStore.GetEvaluationsByTemplate(id)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch evaluations", err.Error())
+		return
+	}
+	evaluationIDs := make([]string, len(evaluations))
+	for i, evaluation := range evaluations {
+		evaluationIDs[i] = evaluation.ID
+	}
+	ratings, err := data.GlobalStore.GetEvaluationRatings(evaluationIDs)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch ratings", err.Error())
+		return
+	}
+	ratingsByEvaluation := make(map[string][]*data.EvaluationRating)
+	for _, rating := range ratings {
+		ratingsByEvaluation[rating.EvaluationID] = append(ratingsByEvaluation[rating.EvaluationID], rating)
+	}
+
+	writeJSON(w, http.StatusOK, buildCalibrationReport(id, evaluations, ratingsByEvaluation, thresholds))
+}
+
+// buildCalibrationReport computes agreement metrics over evaluations whose assigned raters have all submitted
+func buildCalibrationReport(templateID string, evaluations []*data.Evaluation, ratingsByEvaluation map[string][]*data.EvaluationRating, thresholds AIDecisionThresholdsDTO) CalibrationReportDTO {
+	// Per-rater item scores/decisions keyed by evaluation ID, for pairwise and vs-AI comparisons
+	raterScores := make(map[string]map[string]float64)
+	raterDecisions := make(map[string]map[string]string)
+
+	var decisionCounts []map[string]int
+	var consensusDecisions, aiConsensusDecisions []string
+	var meanHumanScores, aiScores []float64
+	aiDecisions := make(map[string]string)
+	aiScoreByEvaluation := make(map[string]float64)
+
+	for _, evaluation := range evaluations {
+		ratings := ratingsByEvaluation[evaluation.ID]
+		if !calibrationComplete(evaluation.CalibrationRaters, ratings) {
+			continue
+		}
+
+		aiDecision := aiDecisionForScore(evaluation.Score, thresholds)
+		aiDecisions[evaluation.ID] = aiDecision
+		aiScoreByEvaluation[evaluation.ID] = evaluation.Score
+
+		counts := make(map[string]int)
+		var scoreSum float64
+		for _, rating := range ratings {
+			counts[rating.Decision]++
+			scoreSum += rating.Score
+			if raterScores[rating.Rater] == nil {
+				raterScores[rating.Rater] = make(map[string]float64)
+				raterDecisions[rating.Rater] = make(map[string]string)
+			}
+			raterScores[rating.Rater][evaluation.ID] = rating.Score
+			raterDecisions[rating.Rater][evaluation.ID] = rating.Decision
+		}
+		decisionCounts = append(decisionCounts, counts)
+
+		meanHumanScores = append(meanHumanScores, scoreSum/float64(len(ratings)))
+		aiScores = append(aiScores, evaluation.Score)
+		if consensus := majorityDecision(counts); consensus != "" {
+			consensusDecisions = append(consensusDecisions, consensus)
+			aiConsensusDecisions = append(aiConsensusDecisions, aiDecision)
+		}
+	}
+
+	raters := make([]string, 0, len(raterScores))
+	for rater := range raterScores {
+		raters = append(raters, rater)
+	}
+	sort.Strings(raters)
+
+	report := CalibrationReportDTO{
+		TemplateID:           templateID,
+		Evaluations:          len(decisionCounts),
+		Raters:               raters,
+		PerRater:             []RaterAgreementDTO{},
+		AIDecisionThresholds: thresholds,
+	}
+
+	// Humans vs humans: Fleiss' kappa on decisions, mean pairwise correlation on scores
+	report.HumanAgreement.Items = len(decisionCounts)
+	report.HumanAgreement.DecisionKappa = optionalMetric(utils.FleissKappa(decisionCounts))
+	var correlationSum float64
+	var correlationCount int
+	for i := 0; i < len(raters); i++ {
+		for j := i + 1; j < len(raters); j++ {
+			var x, y []float64
+			for evaluationID, score := range raterScores[raters[i]] {
+				if other, ok := raterScores[raters[j]][evaluationID]; ok {
+					x = append(x, score)
+					y = append(y, other)
+				}
+			}
+			if r, ok := utils.PearsonCorrelation(x, y); ok {
+				correlationSum += r
+				correlationCount++
+			}
+		}
+	}
+	if correlationCount > 0 {
+		report.HumanAgreement.ScoreCorrelation = optionalMetric(correlationSum/float64(correlationCount), true)
+	}
+
+	// Humans vs AI: majority human decision and mean human score against the AI
+	report.HumanVsAI.Items = len(meanHumanScores)
+	report.HumanVsAI.DecisionKappa = optionalMetric(utils.CohenKappa(consensusDecisions, aiConsensusDecisions))
+	report.HumanVsAI.ScoreCorrelation = optionalMetric(utils.PearsonCorrelation(meanHumanScores, aiScores))
+
+	for _, rater := range raters {
+		var humanDecisions, machineDecisions []string
+		var humanScores, machineScores []float64
+		var deltaSum float64
+		for evaluationID, score := range raterScores[rater] {
+			humanDecisions = append(humanDecisions, raterDecisions[rater][evaluationID])
+			machineDecisions = append(machineDecisions, aiDecisions[evaluationID])
+			humanScores = append(humanScores, score)
+			machineScores = append(machineScores, aiScoreByEvaluation[evaluationID])
+			deltaSum += score - aiScoreByEvaluation[evaluationID]
+		}
+		report.PerRater = append(report.PerRater, RaterAgreementDTO{
+			Rater:                rater,
+			Items:                len(humanScores),
+			DecisionKappaVsAI:    optionalMetric(utils.CohenKappa(humanDecisions, machineDecisions)),
+			ScoreCorrelationVsAI: optionalMetric(utils.PearsonCorrelation(humanScores, machineScores)),
+			MeanScoreDeltaVsAI:   optionalMetric(deltaSum/float64(len(humanScores)), len(humanScores) > 0),
+		})
+	}
+
+	return report
+}
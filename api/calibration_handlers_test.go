@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// createCalibrationEvaluation stores an interview from the template and an AI evaluation with the given score
+func createCalibrationEvaluation(t *testing.T, templateID string, score float64) *data.Evaluation {
+	t.Helper()
+	interview := &data.Interview{
+		ID:            data.GenerateID(),
+		CandidateName: "Calibration Candidate",
+		Questions:     []string{"Describe a project"},
+		TemplateID:    templateID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := data.GlobalStore.CreateInterview(interview); err != nil {
+		t.Fatalf("failed to create interview: %v", err)
+	}
+	evaluation := &data.Evaluation{
+		ID:          data.GenerateID(),
+		InterviewID: interview.ID,
+		Score:       score,
+		Feedback:    "AI feedback",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := data.GlobalStore.CreateEvaluation(evaluation); err != nil {
+		t.Fatalf("failed to create evaluation: %v", err)
+	}
+	return evaluation
+}
+
+func submitRating(t *testing.T, router http.Handler, evaluationID, rater string, score float64, decision string) {
+	t.Helper()
+	doJSONRequest(t, router, "POST", "/evaluation/"+evaluationID+"/ratings",
+		RatingRequestDTO{Rater: rater, Score: &score, Decision: decision}, http.StatusCreated, nil)
+}
+
+func TestCalibrationHandlers_BlindRatings(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	evaluation := createCalibrationEvaluation(t, "", 0.8)
+	path := "/evaluation/" + evaluation.ID
+
+	// At least two distinct raters are required
+	doJSONRequest(t, router, "PUT", path+"/raters", AssignRatersRequestDTO{Raters: []string{"alice", " alice "}}, http.StatusBadRequest, nil)
+
+	var status CalibrationStatusDTO
+	doJSONRequest(t, router, "PUT", path+"/raters", AssignRatersRequestDTO{Raters: []string{"alice", "bob"}}, http.StatusOK, &status)
+	if len(status.Raters) != 2 || status.Complete {
+		t.Fatalf("expected two pending raters, got %+v", status)
+	}
+
+	// Unassigned raters, missing scores and invalid decisions are rejected
+	score := 0.7
+	doJSONRequest(t, router, "POST", path+"/ratings", RatingRequestDTO{Rater: "carol", Score: &score, Decision: "advance"}, http.StatusBadRequest, nil)
+	doJSONRequest(t, router, "POST", path+"/ratings", RatingRequestDTO{Rater: "alice", Decision: "advance"}, http.StatusBadRequest, nil)
+	doJSONRequest(t, router, "POST", path+"/ratings", RatingRequestDTO{Rater: "alice", Score: &score, Decision: "maybe"}, http.StatusBadRequest, nil)
+
+	submitRating(t, router, evaluation.ID, "alice", 0.7, data.DecisionAdvance)
+	doJSONRequest(t, router, "POST", path+"/ratings", RatingRequestDTO{Rater: "alice", Score: &score, Decision: "hold"}, http.StatusConflict, nil)
+
+	// Submitted raters cannot be removed
+	doJSONRequest(t, router, "PUT", path+"/raters", AssignRatersRequestDTO{Raters: []string{"bob", "carol"}}, http.StatusConflict, nil)
+
+	// Bob cannot see Alice's rating before submitting his own
+	doJSONRequest(t, router, "GET", path+"/ratings?rater=bob", nil, http.StatusOK, &status)
+	if len(status.Ratings) != 0 || len(status.Submitted) != 1 {
+		t.Fatalf("expected blind status for bob, got %+v", status)
+	}
+	doJSONRequest(t, router, "GET", path+"/ratings?rater=alice", nil, http.StatusOK, &status)
+	if len(status.Ratings) != 1 || status.Ratings[0].Rater != "alice" {
+		t.Fatalf("expected alice to see only her own rating, got %+v", status)
+	}
+
+	submitRating(t, router, evaluation.ID, "bob", 0.5, data.DecisionHold)
+	doJSONRequest(t, router, "GET", path+"/ratings", nil, http.StatusOK, &status)
+	if !status.Complete || len(status.Ratings) != 2 {
+		t.Fatalf("expected all ratings once complete, got %+v", status)
+	}
+}
+
+func TestCalibrationHandlers_RaterFromCredentials(t *testing.T) {
+	clearMemoryStore()
+	router := setupRoleRouter()
+	alice, bob := roleBearer(t, "alice", RoleReviewer), roleBearer(t, "bob", RoleReviewer)
+	path := "/evaluation/" + createCalibrationEvaluation(t, "", 0.8).ID
+
+	if w := serveWithHeader(router, "PUT", path+"/raters", []byte(`{"raters":["staff_token","bob"]}`), "Authorization", "Bearer "+testStaffToken); w.Code != http.StatusForbidden {
+		t.Errorf("expected assigning oneself as a rater forbidden, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "PUT", path+"/raters", []byte(`{"raters":["alice","bob"]}`), "Authorization", "Bearer "+testStaffToken); w.Code != http.StatusOK {
+		t.Fatalf("expected the raters assigned, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := serveWithHeader(router, "POST", path+"/ratings", []byte(`{"rater":"bob","score":0.7,"decision":"advance"}`), "Authorization", alice); w.Code != http.StatusForbidden {
+		t.Errorf("expected rating as someone else forbidden, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "POST", path+"/ratings", []byte(`{"score":0.7,"decision":"advance"}`), "Authorization", alice); w.Code != http.StatusCreated {
+		t.Fatalf("expected alice's rating recorded, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Naming another rater does not reveal their hidden rating
+	if w := serveWithHeader(router, "GET", path+"/ratings?rater=alice", nil, "Authorization", bob); w.Code != http.StatusForbidden {
+		t.Errorf("expected reading as another rater forbidden, got %d", w.Code)
+	}
+	var status CalibrationStatusDTO
+	_ = json.Unmarshal(serveWithHeader(router, "GET", path+"/ratings", nil, "Authorization", bob).Body.Bytes(), &status)
+	if len(status.Submitted) != 1 || len(status.Ratings) != 0 {
+		t.Errorf("expected alice's rating hidden from bob, got %+v", status)
+	}
+}
+
+func TestGetCalibrationReportHandler(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	template := createTestTemplate(t, router, InterviewTemplateRequestDTO{
+		Name:          "Calibration Template",
+		InterviewType: "technical",
+		Questions:     []string{"Describe a project"},
+	})
+
+	// AI decisions at the default thresholds: advance, hold, reject
+	strong := createCalibrationEvaluation(t, template.ID, 0.9)
+	middle := createCalibrationEvaluation(t, template.ID, 0.6)
+	weak := createCalibrationEvaluation(t, template.ID, 0.3)
+	pending := createCalibrationEvaluation(t, template.ID, 0.5)
+	for _, evaluation := range []*data.Evaluation{strong, middle, weak, pending} {
+		doJSONRequest(t, router, "PUT", "/evaluation/"+evaluation.ID+"/raters",
+			AssignRatersRequestDTO{Raters: []string{"alice", "bob"}}, http.StatusOK, nil)
+	}
+
+	submitRating(t, router, strong.ID, "alice", 0.85, data.DecisionAdvance)
+	submitRating(t, router, strong.ID, "bob", 0.8, data.DecisionAdvance)
+	submitRating(t, router, middle.ID, "alice", 0.6, data.DecisionHold)
+	submitRating(t, router, middle.ID, "bob", 0.4, data.DecisionReject)
+	submitRating(t, router, weak.ID, "alice", 0.2, data.DecisionReject)
+	submitRating(t, router, weak.ID, "bob", 0.3, data.DecisionReject)
+	// Incomplete calibrations are excluded from the report
+	submitRating(t, router, pending.ID, "alice", 0.9, data.DecisionAdvance)
+
+	path := "/templates/" + template.ID + "/calibration-report"
+	var report CalibrationReportDTO
+	doJSONRequest(t, router, "GET", path, nil, http.StatusOK, &report)
+
+	if report.Evaluations != 3 || len(report.Raters) != 2 || len(report.PerRater) != 2 {
+		t.Fatalf("expected 3 evaluations and 2 raters, got %+v", report)
+	}
+	if report.HumanAgreement.DecisionKappa == nil || report.HumanAgreement.ScoreCorrelation == nil {
+		t.Fatalf("expected human agreement metrics, got %+v", report.HumanAgreement)
+	}
+	// The tied middle evaluation has no majority decision; the other two match the AI
+	if report.HumanVsAI.DecisionKappa == nil || math.Abs(*report.HumanVsAI.DecisionKappa-1) > 1e-9 {
+		t.Errorf("expected perfect majority agreement with AI, got %+v", report.HumanVsAI)
+	}
+	alice := report.PerRater[0]
+	if alice.Rater != "alice" || alice.DecisionKappaVsAI == nil || math.Abs(*alice.DecisionKappaVsAI-1) > 1e-9 {
+		t.Errorf("expected alice to agree fully with the AI, got %+v", alice)
+	}
+	if alice.MeanScoreDeltaVsAI == nil || math.Abs(*alice.MeanScoreDeltaVsAI+0.05) > 1e-9 {
+		t.Errorf("unexpected mean score delta for alice: %+v", alice)
+	}
+	bob := report.PerRater[1]
+	if bob.DecisionKappaVsAI == nil || *bob.DecisionKappaVsAI >= 1 {
+		t.Errorf("expected bob to disagree with the AI on one evaluation, got %+v", bob)
+	}
+
+	for _, query := range []string{
+		"?advance_threshold=0.4&reject_threshold=0.6",
+		"?reject_threshold=-0.5",
+		"?reject_threshold=NaN",
+		"?advance_threshold=NaN",
+		"?advance_threshold=high",
+	} {
+		doJSONRequest(t, router, "GET", path+query, nil, http.StatusBadRequest, nil)
+	}
+	doJSONRequest(t, router, "GET", "/templates/missing/calibration-report", nil, http.StatusNotFound, nil)
+}
@@ -0,0 +1,334 @@
+// HTTP handler functions for candidates and their interview history
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Candidate listing limits
+const (
+	defaultCandidateListLimit = 50
+	maxCandidateListLimit     = 200
+	maxCandidateFieldLength   = 255
+)
+
+// phonePattern accepts international and local phone formats: digits with optional +, spaces, dashes, dots and parentheses
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ().-]{5,30}$`)
+
+// Helper: trim a candidate request and lowercase its email so lookups are case-insensitive
+func normalizeCandidateRequest(req *CandidateRequestDTO) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.Phone = strings.TrimSpace(req.Phone)
+	req.ExternalID = strings.TrimSpace(req.ExternalID)
+	req.Locale = strings.TrimSpace(req.Locale)
+}
+
+// Helper: validate a normalized candidate request, returning an error message or "" if valid
+func validateCandidateRequest(req *CandidateRequestDTO) string {
+	if req.Name == "" {
+		return "Missing name"
+	}
+	if len(req.Name) > maxCandidateFieldLength || len(req.Email) > maxCandidateFieldLength || len(req.ExternalID) > maxCandidateFieldLength {
+		return "name, email and external_id must be at most 255 characters"
+	}
+	if req.Email != "" {
+		if address, err := mail.ParseAddress(req.Email); err != nil || address.Address != req.Email {
+			return "Invalid email address"
+		}
+	}
+	if req.Phone != "" && !phonePattern.MatchString(req.Phone) {
+		return "Invalid phone number"
+	}
+	if req.Locale != "" && !data.ValidateLanguage(req.Locale) {
+		return "Invalid locale. Supported languages: en, zh-TW"
+	}
+	return ""
+}
+
+// Helper: convert a candidate to its response DTO
+func newCandidateResponseDTO(candidate *data.Candidate) CandidateResponseDTO {
+	return CandidateResponseDTO{
+		ID:         candidate.ID,
+		Name:       candidate.Name,
+		Email:      candidate.Email,
+		Phone:      candidate.Phone,
+		ExternalID: candidate.ExternalID,
+		Locale:     candidate.Locale,
+		Consent: CandidateConsentDTO{
+			DataProcessing: candidate.ConsentDataProcessing,
+			AIEvaluation:   candidate.ConsentAIEvaluation,
+			TalentPool:     candidate.ConsentTalentPool,
+			UpdatedAt:      candidate.ConsentUpdatedAt,
+		},
+		CreatedBy: candidate.CreatedBy,
+		CreatedAt: candidate.CreatedAt,
+		UpdatedAt: candidate.UpdatedAt,
+	}
+}
+
+// Helper: copy a request's details onto a candidate, stamping the consent time when any flag changes
+func applyCandidateRequest(candidate *data.Candidate, req *CandidateRequestDTO) {
+	consentChanged := candidate.ConsentDataProcessing != req.Consent.DataProcessing ||
+		candidate.ConsentAIEvaluation != req.Consent.AIEvaluation ||
+		candidate.ConsentTalentPool != req.Consent.TalentPool
+	candidate.Name = req.Name
+	candidate.Email = req.Email
+	candidate.Phone = req.Phone
+	candidate.ExternalID = req.ExternalID
+	candidate.Locale = req.Locale
+	candidate.ConsentDataProcessing = req.Consent.DataProcessing
+	candidate.ConsentAIEvaluation = req.Consent.AIEvaluation
+	candidate.ConsentTalentPool = req.Consent.TalentPool
+	if consentChanged {
+		now := time.Now()
+		candidate.ConsentUpdatedAt = &now
+	}
+}
+
+// Helper: fill an interview request from its candidate record, returning an error message if the candidate does not exist
+func applyInterviewCandidate(req *CreateInterviewRequestDTO) string {
+	if req.CandidateID == "" {
+		return ""
+	}
+	candidate, err := data.GlobalStore.GetCandidate(req.CandidateID)
+	if err != nil {
+		return "Candidate not found"
+	}
+	fillInterviewFromCandidate(req, candidate)
+	return ""
+}
+
+// Helper: copy a candidate's name and preferred language into any interview request fields left empty
+func fillInterviewFromCandidate(req *CreateInterviewRequestDTO, candidate *data.Candidate) {
+	if req.CandidateName == "" {
+		req.CandidateName = candidate.Name
+	}
+	if req.InterviewLanguage == "" {
+		req.InterviewLanguage = candidate.Locale
+	}
+}
+
+// Helper: group a candidate's history by interview, numbering each interview's sessions as attempts
+func newCandidateInterviewDTOs(history *data.CandidateHistory) []CandidateInterviewDTO {
+	sessions := make(map[string][]InterviewSessionDTO)
+	for _, session := range history.Sessions {
+		sessions[session.InterviewID] = append(sessions[session.InterviewID], InterviewSessionDTO{
+			ID:              session.ID,
+			Attempt:         len(sessions[session.InterviewID]) + 1,
+			SessionLanguage: session.SessionLanguage,
+			Status:          session.Status,
+			StartedAt:       session.StartedAt,
+			EndedAt:         session.EndedAt,
+		})
+	}
+	evaluations := make(map[string][]EvaluationResponseDTO)
+	latestEvaluation := make(map[string]string)
+	// Oldest first, so the last evaluation seen for a session is its latest
+	for _, evaluation := range history.Evaluations {
+		evaluations[evaluation.InterviewID] = append(evaluations[evaluation.InterviewID], newEvaluationResponseDTO(evaluation))
+		if evaluation.SessionID != nil {
+			latestEvaluation[*evaluation.SessionID] = evaluation.ID
+		}
+	}
+
+	interviews := make([]CandidateInterviewDTO, len(history.Interviews))
+	for i, interview := range history.Interviews {
+		interviewSessions := sessions[interview.ID]
+		for j := range interviewSessions {
+			interviewSessions[j].EvaluationID = latestEvaluation[interviewSessions[j].ID]
+		}
+		interviews[i] = CandidateInterviewDTO{
+			Interview:   newInterviewResponseDTO(interview),
+			Sessions:    append([]InterviewSessionDTO{}, interviewSessions...),
+			Evaluations: append([]EvaluationResponseDTO{}, evaluations[interview.ID]...),
+		}
+	}
+	return interviews
+}
+
+// Helper: limit a candidate's history to the interviews the caller may see, with their sessions and evaluations
+func scopeCandidateHistory(r *http.Request, history *data.CandidateHistory) *data.CandidateHistory {
+	if ownerScope(r) == "" {
+		return history
+	}
+	scoped := &data.CandidateHistory{}
+	visible := make(map[string]bool)
+	for _, interview := range history.Interviews {
+		if canAccessInterview(r, interview) {
+			visible[interview.ID] = true
+			scoped.Interviews = append(scoped.Interviews, interview)
+		}
+	}
+	for _, session := range history.Sessions {
+		if visible[session.InterviewID] {
+			scoped.Sessions = append(scoped.Sessions, session)
+		}
+	}
+	for _, evaluation := range history.Evaluations {
+		if visible[evaluation.InterviewID] {
+			scoped.Evaluations = append(scoped.Evaluations, evaluation)
+		}
+	}
+	return scoped
+}
+
+// CreateCandidateHandler handles POST /candidates
+func CreateCandidateHandler(w http.ResponseWriter, r *http.Request) {
+	var req CandidateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	normalizeCandidateRequest(&req)
+	if msg := validateCandidateRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	candidate := &data.Candidate{ID: data.GenerateID(), CreatedBy: creatorOf(r)}
+	applyCandidateRequest(candidate, &req)
+	if err := data.GlobalStore.CreateCandidate(candidate); err != nil {
+		if errors.Is(err, data.ErrDuplicateCandidate) {
+			writeJSONError(w, http.StatusConflict, "A candidate with this email or external_id already exists")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create candidate", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newCandidateResponseDTO(candidate))
+}
+
+// ListCandidatesHandler handles GET /candidates
+// Lists candidates by name, optionally filtered by ?name= (partial match), ?email= or ?external_id=.
+// Recruiters see the candidates they created and those on their interviews.
+func ListCandidatesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := parseIntQuery(r, "limit", defaultCandidateListLimit)
+	if limit <= 0 || limit > maxCandidateListLimit {
+		limit = defaultCandidateListLimit
+	}
+	filters := data.CandidateFilters{
+		Name:       strings.TrimSpace(query.Get("name")),
+		Email:      strings.ToLower(strings.TrimSpace(query.Get("email"))),
+		ExternalID: strings.TrimSpace(query.Get("external_id")),
+		CreatedBy:  ownerScope(r),
+	}
+
+	candidates, total, err := data.GlobalStore.ListCandidates(filters, limit, max(parseIntQuery(r, "offset", 0), 0))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch candidates", err.Error())
+		return
+	}
+
+	resp := ListCandidatesResponseDTO{Candidates: make([]CandidateResponseDTO, len(candidates)), Total: total}
+	for i, candidate := range candidates {
+		resp.Candidates[i] = newCandidateResponseDTO(candidate)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetCandidateHandler handles GET /candidates/{id}
+// Returns the candidate with every interview, chat session and evaluation they have had, oldest first;
+// recruiters see only the interviews they created.
+func GetCandidateHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingCandidateID)
+		return
+	}
+
+	candidate, err := data.GlobalStore.GetCandidate(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Candidate not found")
+		return
+	}
+	history, err := data.GlobalStore.GetCandidateHistory(id)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch candidate history", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, CandidateHistoryResponseDTO{
+		Candidate:  newCandidateResponseDTO(candidate),
+		Interviews: newCandidateInterviewDTOs(scopeCandidateHistory(r, history)),
+	})
+}
+
+// UpdateCandidateHandler handles PUT /candidates/{id}
+// Replaces the candidate's details and consent flags; existing interviews keep the name they were created with.
+func UpdateCandidateHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingCandidateID)
+		return
+	}
+
+	existing, err := data.GlobalStore.GetCandidate(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Candidate not found")
+		return
+	}
+
+	var req CandidateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	normalizeCandidateRequest(&req)
+	if msg := validateCandidateRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Build the replacement on a copy so a rejected update leaves the stored candidate untouched
+	candidate := *existing
+	applyCandidateRequest(&candidate, &req)
+	candidate.UpdatedAt = time.Now()
+
+	if err := data.GlobalStore.UpdateCandidate(&candidate); err != nil {
+		if errors.Is(err, data.ErrDuplicateCandidate) {
+			writeJSONError(w, http.StatusConflict, "A candidate with this email or external_id already exists")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update candidate", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newCandidateResponseDTO(&candidate))
+}
+
+// DeleteCandidateHandler handles DELETE /candidates/{id}
+// Candidates with interviews cannot be deleted; delete the interviews first.
+func DeleteCandidateHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingCandidateID)
+		return
+	}
+
+	if _, err := data.GlobalStore.GetCandidate(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Candidate not found")
+		return
+	}
+	if err := data.GlobalStore.DeleteCandidate(id); err != nil {
+		if errors.Is(err, data.ErrCandidateHasInterviews) {
+			writeJSONError(w, http.StatusConflict, "Candidate has interviews; delete them first")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to delete candidate", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
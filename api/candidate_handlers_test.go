@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// createTestCandidate creates a candidate and returns the response
+func createTestCandidate(t *testing.T, router http.Handler, req CandidateRequestDTO) CandidateResponseDTO {
+	t.Helper()
+	var resp CandidateResponseDTO
+	doJSONRequest(t, router, "POST", "/candidates", req, http.StatusCreated, &resp)
+	return resp
+}
+
+func TestCandidateHandlers_CRUD(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	candidate := createTestCandidate(t, router, CandidateRequestDTO{
+		Name:       " Jane Doe ",
+		Email:      "Jane@Example.com",
+		ExternalID: "ats-1",
+		Locale:     "zh-TW",
+		Consent:    CandidateConsentDTO{DataProcessing: true},
+	})
+	if candidate.Name != "Jane Doe" || candidate.Email != "jane@example.com" || candidate.Consent.UpdatedAt == nil {
+		t.Errorf("expected a normalized candidate with consent recorded, got %+v", candidate)
+	}
+
+	// Invalid details and duplicate emails or ATS IDs are rejected
+	for _, invalid := range []CandidateRequestDTO{
+		{Email: "a@example.com"},
+		{Name: "A", Email: "not-an-email"},
+		{Name: "A", Phone: "call me"},
+		{Name: "A", Locale: "fr"},
+	} {
+		b, _ := json.Marshal(invalid)
+		expectHTTPError(t, router, "POST", "/candidates", b, http.StatusBadRequest)
+	}
+	b, _ := json.Marshal(CandidateRequestDTO{Name: "Other", Email: "JANE@example.com"})
+	expectHTTPError(t, router, "POST", "/candidates", b, http.StatusConflict)
+	b, _ = json.Marshal(CandidateRequestDTO{Name: "Other", ExternalID: "ats-1"})
+	expectHTTPError(t, router, "POST", "/candidates", b, http.StatusConflict)
+	other := createTestCandidate(t, router, CandidateRequestDTO{Name: "Alex Roe", Phone: "+1 (555) 010-0100"})
+
+	// Listing filters by name and exact email
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/candidates?email=JANE@example.com", nil))
+	var list ListCandidatesResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 1 || list.Candidates[0].ID != candidate.ID {
+		t.Errorf("expected the candidate found by email, got %+v", list)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/candidates", nil))
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 2 || list.Candidates[0].Name != "Alex Roe" {
+		t.Errorf("expected both candidates ordered by name, got %+v", list)
+	}
+
+	// Updates cannot take another candidate's email; consent changes are timestamped
+	b, _ = json.Marshal(CandidateRequestDTO{Name: "Alex Roe", Email: "jane@example.com"})
+	expectHTTPError(t, router, "PUT", "/candidates/"+other.ID, b, http.StatusConflict)
+	b, _ = json.Marshal(CandidateRequestDTO{Name: "Alex Roe", Consent: CandidateConsentDTO{AIEvaluation: true}})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/candidates/"+other.ID, bytes.NewReader(b)))
+	var updated CandidateResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &updated)
+	if w.Code != http.StatusOK || !updated.Consent.AIEvaluation || updated.Consent.UpdatedAt == nil || updated.Phone != "" {
+		t.Errorf("expected the candidate replaced with consent recorded, got %d: %s", w.Code, w.Body.String())
+	}
+
+	expectHTTPError(t, router, "GET", "/candidates/missing", nil, http.StatusNotFound)
+	expectHTTPError(t, router, "DELETE", "/candidates/"+other.ID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "DELETE", "/candidates/"+other.ID, nil, http.StatusNotFound)
+}
+
+func TestCandidateHandlers_History(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	candidate := createTestCandidate(t, router, CandidateRequestDTO{Name: "Jane Doe", Locale: "zh-TW"})
+
+	b, _ := json.Marshal(CreateInterviewRequestDTO{CandidateID: "missing", Questions: []string{"Q1"}, InterviewType: "general"})
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusNotFound)
+
+	// The candidate record supplies the name and language
+	first := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateID: candidate.ID, Questions: []string{"Q1"}, InterviewType: "general"})
+	if first.CandidateID != candidate.ID || first.CandidateName != "Jane Doe" || first.InterviewLanguage != "zh-TW" {
+		t.Fatalf("expected the interview linked to the candidate, got %+v", first)
+	}
+	session := startChatSession(t, router, first.ID, nil)
+	sendMessage(t, router, session.ID, "I have five years of Go experience")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/chat/"+session.ID+"/end", nil))
+	evaluation := awaitEvaluation(t, router, w)
+
+	second := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateID: candidate.ID, CandidateName: "Jane D.", Questions: []string{"Q2"}, InterviewType: "technical", InterviewLanguage: "en"})
+	createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Someone else", Questions: []string{"Q1"}, InterviewType: "general"})
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/candidates/"+candidate.ID, nil))
+	var history CandidateHistoryResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &history)
+	if w.Code != http.StatusOK || history.Candidate.ID != candidate.ID || len(history.Interviews) != 2 {
+		t.Fatalf("expected both of the candidate's interviews, got %d: %s", w.Code, w.Body.String())
+	}
+	if history.Interviews[0].Interview.ID != first.ID || history.Interviews[1].Interview.ID != second.ID {
+		t.Errorf("expected interviews oldest first, got %s then %s", history.Interviews[0].Interview.ID, history.Interviews[1].Interview.ID)
+	}
+	earlier := history.Interviews[0]
+	if len(earlier.Sessions) != 1 || earlier.Sessions[0].ID != session.ID || earlier.Sessions[0].EvaluationID != evaluation.ID {
+		t.Errorf("expected the session with its evaluation, got %+v", earlier.Sessions)
+	}
+	if len(earlier.Evaluations) != 1 || earlier.Evaluations[0].ID != evaluation.ID {
+		t.Errorf("expected the evaluation in the history, got %+v", earlier.Evaluations)
+	}
+	if later := history.Interviews[1]; later.Interview.CandidateName != "Jane D." || later.Sessions == nil || len(later.Sessions) != 0 {
+		t.Errorf("expected the second interview without sessions, got %+v", later)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews?candidate_id="+candidate.ID, nil))
+	var list ListInterviewsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 2 {
+		t.Errorf("expected interviews filtered by candidate, got %d", list.Total)
+	}
+
+	// A candidate with interviews cannot be deleted
+	expectHTTPError(t, router, "DELETE", "/candidates/"+candidate.ID, nil, http.StatusConflict)
+	expectHTTPError(t, router, "DELETE", "/interviews/"+second.ID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "DELETE", "/interviews/"+first.ID+"?cascade=true", nil, http.StatusNoContent)
+	expectHTTPError(t, router, "DELETE", "/candidates/"+candidate.ID, nil, http.StatusNoContent)
+}
@@ -0,0 +1,204 @@
+// HTTP handler functions for structured candidate profiles parsed from resumes
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/ai"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Helper: convert a candidate profile DTO to its data model; source and timestamps are set by the caller
+func candidateProfileFromDTO(dto CandidateProfileDTO) data.CandidateProfile {
+	profile := data.CandidateProfile{
+		Summary:           dto.Summary,
+		YearsOfExperience: dto.YearsOfExperience,
+		Skills:            dto.Skills,
+		Languages:         dto.Languages,
+	}
+	for _, role := range dto.WorkHistory {
+		profile.WorkHistory = append(profile.WorkHistory, data.WorkExperience(role))
+	}
+	for _, entry := range dto.Education {
+		profile.Education = append(profile.Education, data.Education(entry))
+	}
+	return profile
+}
+
+// Helper: convert a candidate profile data model to its DTO
+func candidateProfileToDTO(profile data.CandidateProfile) CandidateProfileDTO {
+	dto := CandidateProfileDTO{
+		Summary:           profile.Summary,
+		YearsOfExperience: profile.YearsOfExperience,
+		Skills:            profile.Skills,
+		Languages:         profile.Languages,
+		Source:            profile.Source,
+		UpdatedAt:         profile.UpdatedAt,
+	}
+	for _, role := range profile.WorkHistory {
+		dto.WorkHistory = append(dto.WorkHistory, WorkExperienceDTO(role))
+	}
+	for _, entry := range profile.Education {
+		dto.Education = append(dto.Education, EducationDTO(entry))
+	}
+	return dto
+}
+
+// Helper: convert a candidate profile data model to the AI layer's profile, returning nil when empty
+func candidateProfileToAI(profile data.CandidateProfile) *ai.CandidateProfile {
+	if profile.IsEmpty() {
+		return nil
+	}
+	converted := &ai.CandidateProfile{
+		Summary:           profile.Summary,
+		YearsOfExperience: profile.YearsOfExperience,
+		Skills:            profile.Skills,
+		Languages:         profile.Languages,
+	}
+	for _, role := range profile.WorkHistory {
+		converted.WorkHistory = append(converted.WorkHistory, ai.WorkExperience(role))
+	}
+	for _, entry := range profile.Education {
+		converted.Education = append(converted.Education, ai.Education(entry))
+	}
+	return converted
+}
+
+// Helper: convert a profile parsed by the AI layer to its data model
+func candidateProfileFromAI(parsed *ai.CandidateProfile) data.CandidateProfile {
+	profile := data.CandidateProfile{
+		Summary:           parsed.Summary,
+		YearsOfExperience: parsed.YearsOfExperience,
+		Skills:            parsed.Skills,
+		Languages:         parsed.Languages,
+	}
+	for _, role := range parsed.WorkHistory {
+		profile.WorkHistory = append(profile.WorkHistory, data.WorkExperience(role))
+	}
+	for _, entry := range parsed.Education {
+		profile.Education = append(profile.Education, data.Education(entry))
+	}
+	return profile
+}
+
+// Helper: payload of a job parsing an interview's resume
+func resumeParsePayload(interviewID, fileID string) data.StringMap {
+	return data.StringMap{
+		"interview_id": interviewID,
+		"file_id":      fileID,
+	}
+}
+
+// runResumeParseJob parses an interview's resume into a candidate profile with the AI layer
+func runResumeParseJob(ctx context.Context, deps *HandlerDependencies, job *data.Job) (string, error) {
+	interview, err := data.GlobalStore.GetInterview(job.Payload["interview_id"])
+	if err != nil {
+		return "", permanentJobError{fmt.Errorf("failed to get interview: %w", err)}
+	}
+	fileID := job.Payload["file_id"]
+	if interview.ResumeFileID != fileID {
+		return "", permanentJobError{data.ErrResumeReplaced}
+	}
+
+	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to create AI client: %w", err)
+	}
+	parsed, err := aiClient.ParseResumeContext(ctx, interview.ResumeText)
+	if err != nil {
+		return "", err
+	}
+	// Model output varies between attempts, so a profile failing validation is retried
+	profile := candidateProfileFromAI(parsed)
+	if err := profile.Validate(); err != nil {
+		return "", fmt.Errorf("parsed profile is invalid: %w", err)
+	}
+	now := time.Now()
+	profile.Source = data.ProfileSourceAI
+	profile.UpdatedAt = &now
+
+	if err := data.GlobalStore.SetInterviewCandidateProfile(interview.ID, fileID, profile); err != nil {
+		if errors.Is(err, data.ErrResumeReplaced) {
+			return "", permanentJobError{err}
+		}
+		return "", fmt.Errorf("failed to save candidate profile: %w", err)
+	}
+	return "", nil
+}
+
+// GetCandidateProfileHandler handles GET /interviews/{id}/profile
+// Returns the structured profile parsed from the candidate's resume or edited by a recruiter.
+func GetCandidateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	interview, err := data.GlobalStore.GetInterview(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+	if interview.CandidateProfile.IsEmpty() {
+		writeJSONError(w, http.StatusNotFound, "Interview has no candidate profile")
+		return
+	}
+	writeJSON(w, http.StatusOK, candidateProfileToDTO(interview.CandidateProfile))
+}
+
+// UpdateCandidateProfileHandler handles PUT /interviews/{id}/profile
+// Replaces the candidate profile with a recruiter's corrections; the interviewer prompt and
+// question generator use the edited profile from then on.
+func UpdateCandidateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := data.GlobalStore.GetInterview(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+
+	var req CandidateProfileDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+	profile := candidateProfileFromDTO(req)
+	if profile.IsEmpty() {
+		writeJSONError(w, http.StatusBadRequest, "Candidate profile is empty")
+		return
+	}
+	if err := profile.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid candidate profile: "+err.Error())
+		return
+	}
+	now := time.Now()
+	profile.Source = data.ProfileSourceRecruiter
+	profile.UpdatedAt = &now
+
+	if err := data.GlobalStore.SetInterviewCandidateProfile(id, "", profile); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to save candidate profile", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, candidateProfileToDTO(profile))
+}
+
+// ParseCandidateProfileHandler handles POST /interviews/{id}/profile/parse
+// Queues a job re-parsing the current resume; the result replaces the profile, including any edits.
+func (deps *HandlerDependencies) ParseCandidateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	interview, err := data.GlobalStore.GetInterview(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+	if interview.ResumeFileID == "" {
+		writeJSONError(w, http.StatusBadRequest, "Interview has no resume; upload one with POST /interviews/{id}/resume")
+		return
+	}
+
+	job, err := deps.enqueueJob(data.JobTypeResumeParse, resumeParsePayload(interview.ID, interview.ResumeFileID))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to queue resume parsing", err.Error())
+		return
+	}
+	writeJobAccepted(w, job)
+}
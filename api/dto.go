@@ -19,7 +19,19 @@ type CreateInterviewRequestDTO struct {
 	InterviewType     string   `json:"interview_type"`               // Required: "general", "technical", or "behavioral"
 	InterviewLanguage string   `json:"interview_language,omitempty"` // Language preference: "en" or "zh-TW"
 	JobDescription    string   `json:"job_description,omitempty"`    // Optional: Job description text
-	// TODO: Resume file upload support will be added in future iteration
+	QuestionIDs       []string `json:"question_ids,omitempty"`       // Optional: Question bank IDs, used alongside or instead of questions
+	TemplateID        string   `json:"template_id,omitempty"`        // Optional: Template supplying any fields not set in the request
+	CandidateID       string   `json:"candidate_id,omitempty"`       // Optional: Candidate record; supplies candidate_name and interview_language when omitted
+	PositionID        string   `json:"position_id,omitempty"`        // Optional: Position; supplies job_description, template_id and rubric when omitted
+	// Optional interview configuration, normally inherited from a template
+	EndPolicy          *EndPolicyDTO `json:"end_policy,omitempty"`
+	EvaluationCriteria []string      `json:"evaluation_criteria,omitempty"`
+	InterviewerPersona string        `json:"interviewer_persona,omitempty"`
+	Rubric             *RubricDTO    `json:"rubric,omitempty"` // Weighted criteria; overrides evaluation_criteria when set
+	EvaluationEnsemble *EnsembleDTO  `json:"evaluation_ensemble,omitempty"`
+	EvaluationSampling *SamplingDTO  `json:"evaluation_sampling,omitempty"`
+	MaxRetakes         *int          `json:"max_retakes,omitempty"` // Chat sessions allowed after the first (0-10)
+	// Resumes are uploaded separately with POST /interviews/{id}/resume
 }
 
 type InterviewResponseDTO struct {
@@ -29,8 +41,91 @@ type InterviewResponseDTO struct {
 	InterviewType     string   `json:"interview_type"`            // "general", "technical", or "behavioral"
 	InterviewLanguage string   `json:"interview_language"`        // Language preference: "en" or "zh-TW"
 	JobDescription    string   `json:"job_description,omitempty"` // Optional: Job description text
-	// TODO: Resume file support will be added in future iteration
-	CreatedAt time.Time `json:"created_at"`
+	QuestionIDs       []string `json:"question_ids,omitempty"`    // Question bank references; their text is included in questions
+	TemplateID        string   `json:"template_id,omitempty"`     // Template the interview was created from
+	CandidateID       string   `json:"candidate_id,omitempty"`    // Candidate record; history at GET /candidates/{id}
+	PositionID        string   `json:"position_id,omitempty"`     // Position the candidate interviewed for
+	Status            string   `json:"status"`                    // Lifecycle status
+	// Statuses POST /interviews/{id}/transition accepts from the current status
+	AllowedTransitions []string `json:"allowed_transitions"`
+	// Interview configuration
+	EndPolicy          EndPolicyDTO `json:"end_policy"`
+	EvaluationCriteria []string     `json:"evaluation_criteria,omitempty"`
+	InterviewerPersona string       `json:"interviewer_persona,omitempty"`
+	Rubric             *RubricDTO   `json:"rubric,omitempty"`
+	EvaluationEnsemble *EnsembleDTO `json:"evaluation_ensemble,omitempty"`
+	EvaluationSampling *SamplingDTO `json:"evaluation_sampling,omitempty"`
+	MaxRetakes         int          `json:"max_retakes"`
+	ResumeFileID       string       `json:"resume_file_id,omitempty"` // Uploaded resume; details at GET /interviews/{id}/resume
+	CreatedBy          string       `json:"created_by,omitempty"`     // Principal that created the interview
+	CreatedAt          time.Time    `json:"created_at"`
+}
+
+// BulkInterviewRowDTO is one JSON line of POST /interviews/bulk: an interview request, optionally
+// naming the candidate by email instead of candidate_id
+type BulkInterviewRowDTO struct {
+	CreateInterviewRequestDTO
+	Email string `json:"email,omitempty"` // Links the candidate with this email, creating one when none exists
+}
+
+// BulkInterviewResponseDTO reports the outcome of every imported row, in input order
+type BulkInterviewResponseDTO struct {
+	Mode    string                      `json:"mode"` // "all_or_nothing" or "best_effort"
+	Total   int                         `json:"total"`
+	Created int                         `json:"created"`
+	Failed  int                         `json:"failed"`
+	Results []BulkInterviewRowResultDTO `json:"results"`
+}
+
+type BulkInterviewRowResultDTO struct {
+	Line        int    `json:"line"`   // Line of the row in the upload, counting the CSV header
+	Status      string `json:"status"` // "created", "failed", or "skipped" when another row failed in all_or_nothing mode
+	InterviewID string `json:"interview_id,omitempty"`
+	CandidateID string `json:"candidate_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// TransitionInterviewRequestDTO moves an interview to another lifecycle status
+type TransitionInterviewRequestDTO struct {
+	Status string `json:"status"` // "draft", "scheduled", "in_progress", "completed", "archived" or "cancelled"
+}
+
+// EndPolicyDTO controls when a chat interview ends automatically; zero values use the server default
+type EndPolicyDTO struct {
+	MaxUserMessages    int `json:"max_user_messages,omitempty"`
+	MaxDurationMinutes int `json:"max_duration_minutes,omitempty"`
+}
+
+// RubricDTO is a set of weighted criteria; the overall score is their weighted mean
+type RubricDTO struct {
+	Criteria []RubricCriterionDTO `json:"criteria"`
+}
+
+type RubricCriterionDTO struct {
+	Key         string            `json:"key"`
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Weight      float64           `json:"weight"`
+	Anchors     []RubricAnchorDTO `json:"anchors,omitempty"`
+}
+
+type RubricAnchorDTO struct {
+	Score       float64 `json:"score"` // 0.0-1.0
+	Description string  `json:"description"`
+}
+
+// EnsembleDTO evaluates with several models and flags disagreement for human review
+type EnsembleDTO struct {
+	Models                []string `json:"models"`                           // "provider/model", e.g. "openai/gpt-4"
+	Aggregation           string   `json:"aggregation,omitempty"`            // "mean" (default) or "median"
+	DisagreementThreshold float64  `json:"disagreement_threshold,omitempty"` // Default 0.2
+}
+
+// SamplingDTO repeats each evaluation to report score consistency
+type SamplingDTO struct {
+	Samples     int     `json:"samples"`               // 2-10 runs
+	Temperature float64 `json:"temperature,omitempty"` // Default 0.7
+	MaxStdDev   float64 `json:"max_std_dev,omitempty"` // Default 0.1; wider spreads are low-confidence
 }
 
 type ListInterviewsResponseDTO struct {
@@ -48,10 +143,251 @@ type SubmitEvaluationRequestDTO struct {
 type EvaluationResponseDTO struct {
 	ID          string            `json:"id"`
 	InterviewID string            `json:"interview_id"`
-	Answers     map[string]string `json:"answers"` // TODO: Add answers field to match frontend expectations
+	SessionID   string            `json:"session_id,omitempty"` // Chat session the evaluation came from
+	Partial     bool              `json:"partial,omitempty"`    // Evaluated from an abandoned, unfinished session
+	Answers     map[string]string `json:"answers"`              // TODO: Add answers field to match frontend expectations
 	Score       float64           `json:"score"`
 	Feedback    string            `json:"feedback"`
-	CreatedAt   time.Time         `json:"created_at"`
+	Strengths   []string          `json:"strengths,omitempty"`
+	Weaknesses  []string          `json:"weaknesses,omitempty"`
+	// Per-criterion scores and how the overall score was derived
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	ScoringMethod  string             `json:"scoring_method,omitempty"` // "weighted_rubric" or "model"
+	Rubric         *RubricDTO         `json:"rubric,omitempty"`
+	// Ensemble evaluation results
+	ModelResults []ModelResultDTO `json:"model_results,omitempty"`
+	Disagreement float64          `json:"disagreement,omitempty"`
+	NeedsReview  bool             `json:"needs_human_review"`
+	// Repeated-sampling results; score is the sample mean
+	Consistency   *ConsistencyDTO `json:"consistency,omitempty"`
+	LowConfidence bool            `json:"low_confidence"`
+	// Human review; score and category_scores above always hold the AI output
+	HumanScore          *float64           `json:"human_score,omitempty"`
+	HumanCategoryScores map[string]float64 `json:"human_category_scores,omitempty"`
+	FinalScore          float64            `json:"final_score"` // Human score when set, otherwise the AI score
+	Decision            string             `json:"decision,omitempty"`
+	ReviewedBy          string             `json:"reviewed_by,omitempty"`
+	ReviewedAt          *time.Time         `json:"reviewed_at,omitempty"`
+	Annotations         []AnnotationDTO    `json:"annotations,omitempty"`
+	SignedOff           bool               `json:"signed_off"` // A reviewer has recorded a decision
+	CreatedAt           time.Time          `json:"created_at"`
+}
+
+type ConsistencyDTO struct {
+	Samples         int       `json:"samples"`
+	Requested       int       `json:"requested"`
+	Temperature     float64   `json:"temperature"`
+	Scores          []float64 `json:"scores"`
+	Mean            float64   `json:"mean"`
+	StdDev          float64   `json:"std_dev"`
+	ConfidenceLow   float64   `json:"confidence_low"`
+	ConfidenceHigh  float64   `json:"confidence_high"`
+	ConfidenceLevel float64   `json:"confidence_level"`
+}
+
+type ModelResultDTO struct {
+	Provider       string             `json:"provider"`
+	Model          string             `json:"model"`
+	Score          float64            `json:"score"`
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Feedback       string             `json:"feedback,omitempty"`
+	Error          string             `json:"error,omitempty"`
+}
+
+// --- Evaluation Review DTOs ---
+type ReviewEvaluationRequestDTO struct {
+	Reviewer       string                 `json:"reviewer,omitempty"`        // Who is signing off; taken from the credentials, which it must match if given
+	CategoryScores map[string]float64     `json:"category_scores,omitempty"` // Per-category adjustments (0.0-1.0), merged over current scores
+	Score          *float64               `json:"score,omitempty"`           // Optional explicit overall score; otherwise derived from category scores
+	Decision       string                 `json:"decision,omitempty"`        // "advance", "hold" or "reject"
+	Comment        string                 `json:"comment,omitempty"`         // Reason for the change
+	Annotations    []AnnotationRequestDTO `json:"annotations,omitempty"`     // Comments on transcript messages
+}
+
+type AnnotationRequestDTO struct {
+	MessageID string `json:"message_id"`
+	Comment   string `json:"comment"`
+}
+
+type AnnotationDTO struct {
+	ID        string    `json:"id"`
+	MessageID string    `json:"message_id"`
+	Reviewer  string    `json:"reviewer"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type EvaluationRevisionDTO struct {
+	Revision       int                `json:"revision"` // 0 is the AI original
+	Source         string             `json:"source"`   // "ai" or "human"
+	Reviewer       string             `json:"reviewer,omitempty"`
+	Score          float64            `json:"score"`
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Decision       string             `json:"decision,omitempty"`
+	Comment        string             `json:"comment,omitempty"`
+	Annotations    []AnnotationDTO    `json:"annotations,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type EvaluationHistoryResponseDTO struct {
+	EvaluationID string                  `json:"evaluation_id"`
+	Revisions    []EvaluationRevisionDTO `json:"revisions"`
+}
+
+// --- Calibration DTOs ---
+type AssignRatersRequestDTO struct {
+	Raters []string `json:"raters"` // At least two reviewers, by principal subject, who rate independently
+}
+
+type RatingRequestDTO struct {
+	Rater          string             `json:"rater,omitempty"` // Taken from the credentials, which it must match if given
+	Score          *float64           `json:"score"`           // Required: 0.0-1.0
+	Decision       string             `json:"decision"`        // Required: "advance", "hold" or "reject"
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Comment        string             `json:"comment,omitempty"`
+}
+
+type RatingDTO struct {
+	Rater          string             `json:"rater"`
+	Score          float64            `json:"score"`
+	Decision       string             `json:"decision"`
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Comment        string             `json:"comment,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+// CalibrationStatusDTO hides other raters' ratings until every assigned rater has submitted
+type CalibrationStatusDTO struct {
+	EvaluationID string      `json:"evaluation_id"`
+	Raters       []string    `json:"raters"`
+	Submitted    []string    `json:"submitted"`
+	Complete     bool        `json:"complete"`
+	Ratings      []RatingDTO `json:"ratings"` // All ratings once complete, otherwise only the requesting rater's own
+}
+
+// AgreementDTO reports agreement metrics; a metric is null when the data cannot define it
+type AgreementDTO struct {
+	Items            int      `json:"items"`
+	DecisionKappa    *float64 `json:"decision_kappa"`
+	ScoreCorrelation *float64 `json:"score_correlation"`
+}
+
+type RaterAgreementDTO struct {
+	Rater                string   `json:"rater"`
+	Items                int      `json:"items"`
+	DecisionKappaVsAI    *float64 `json:"decision_kappa_vs_ai"`    // Cohen's kappa
+	ScoreCorrelationVsAI *float64 `json:"score_correlation_vs_ai"` // Pearson correlation
+	MeanScoreDeltaVsAI   *float64 `json:"mean_score_delta_vs_ai"`  // Mean of rater score minus AI score
+}
+
+type CalibrationReportDTO struct {
+	TemplateID  string   `json:"template_id"`
+	Evaluations int      `json:"evaluations"` // Fully rated evaluations included in the report
+	Raters      []string `json:"raters"`
+	// Fleiss' kappa on decisions and mean pairwise Pearson correlation on scores
+	HumanAgreement AgreementDTO `json:"human_agreement"`
+	// Cohen's kappa of the majority human decision and Pearson correlation of the mean human score against the AI
+	HumanVsAI            AgreementDTO            `json:"human_vs_ai"`
+	PerRater             []RaterAgreementDTO     `json:"per_rater"`
+	AIDecisionThresholds AIDecisionThresholdsDTO `json:"ai_decision_thresholds"`
+}
+
+// AIDecisionThresholdsDTO maps AI scores to decisions: advance at or above Advance, reject below Reject
+type AIDecisionThresholdsDTO struct {
+	Advance float64 `json:"advance"`
+	Reject  float64 `json:"reject"`
+}
+
+type ListEvaluationsResponseDTO struct {
+	Evaluations []EvaluationResponseDTO `json:"evaluations"`
+	Total       int                     `json:"total"`
+	Page        int                     `json:"page"`
+	Limit       int                     `json:"limit"`
+	TotalPages  int                     `json:"total_pages"`
+}
+
+// EvaluationStatsDTO aggregates AI scores of the evaluations matching the request filters
+type EvaluationStatsDTO struct {
+	TotalEvaluations  int64          `json:"total_evaluations"`
+	AverageScore      float64        `json:"average_score"`
+	MinScore          float64        `json:"min_score"`
+	MaxScore          float64        `json:"max_score"`
+	ScoreDistribution map[string]int `json:"score_distribution"` // Keyed by percentage range, e.g. "80-89"
+	// Set with ?group_by=position: the same statistics per position, leaving out interviews without one
+	ByPosition []PositionEvaluationStatsDTO `json:"by_position,omitempty"`
+}
+
+// PositionEvaluationStatsDTO aggregates the evaluations of one position's interviews
+type PositionEvaluationStatsDTO struct {
+	PositionID        string         `json:"position_id"`
+	Title             string         `json:"title"`
+	TotalEvaluations  int64          `json:"total_evaluations"`
+	AverageScore      float64        `json:"average_score"`
+	MinScore          float64        `json:"min_score"`
+	MaxScore          float64        `json:"max_score"`
+	ScoreDistribution map[string]int `json:"score_distribution"`
+}
+
+// --- Ranking DTOs ---
+
+// RankCandidatesRequestDTO selects the candidates to rank; exactly one selector must be set
+type RankCandidatesRequestDTO struct {
+	JobDescription string   `json:"job_description,omitempty"` // Interviews with this exact job description
+	TemplateID     string   `json:"template_id,omitempty"`     // Interviews created from this template
+	PositionID     string   `json:"position_id,omitempty"`     // Interviews for this position
+	InterviewIDs   []string `json:"interview_ids,omitempty"`   // An explicit set of interviews
+}
+
+type CandidateRankingDTO struct {
+	Rank           int                `json:"rank"`
+	InterviewID    string             `json:"interview_id"`
+	CandidateName  string             `json:"candidate_name"`
+	EvaluationID   string             `json:"evaluation_id"`
+	Score          float64            `json:"score"`                     // Final score: human override when set, otherwise AI
+	AIScore        float64            `json:"ai_score"`                  // Original AI score
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"` // Human-adjusted when reviewed, otherwise AI
+	Decision       string             `json:"decision,omitempty"`
+	Strengths      []string           `json:"strengths"`
+	Weaknesses     []string           `json:"weaknesses"`
+	NeedsReview    bool               `json:"needs_human_review"`
+	LowConfidence  bool               `json:"low_confidence"`
+	EvaluatedAt    time.Time          `json:"evaluated_at"`
+	EvaluationURL  string             `json:"evaluation_url"`
+	TranscriptURL  string             `json:"transcript_url,omitempty"` // Chat session the evaluation scored, when it was held by chat
+}
+
+type RankCandidatesResponseDTO struct {
+	Rankings    []CandidateRankingDTO `json:"rankings"`
+	Unevaluated []string              `json:"unevaluated_interview_ids"` // Matching interviews with no evaluation yet
+	Categories  []string              `json:"categories"`                // Union of category keys across rankings
+}
+
+type CompareCandidatesRequestDTO struct {
+	InterviewIDs []string `json:"interview_ids"` // 2-5 interviews, in display order
+}
+
+type ComparisonCandidateDTO struct {
+	InterviewID    string             `json:"interview_id"`
+	CandidateName  string             `json:"candidate_name"`
+	EvaluationID   string             `json:"evaluation_id,omitempty"`
+	Score          *float64           `json:"score"` // Final score; null when not yet evaluated
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Decision       string             `json:"decision,omitempty"`
+	Strengths      []string           `json:"strengths"`
+	Weaknesses     []string           `json:"weaknesses"`
+	TranscriptURL  string             `json:"transcript_url,omitempty"`
+}
+
+// QuestionComparisonDTO lines up every candidate's answer to one question, in candidate order
+type QuestionComparisonDTO struct {
+	Question string   `json:"question"`
+	Answers  []string `json:"answers"` // "" when the candidate was not asked or did not answer
+}
+
+type CompareCandidatesResponseDTO struct {
+	Candidates []ComparisonCandidateDTO `json:"candidates"`
+	Categories []string                 `json:"categories"`
+	Questions  []QuestionComparisonDTO  `json:"questions"`
 }
 
 // --- Chat DTOs ---
@@ -62,10 +398,11 @@ type StartChatSessionRequestDTO struct {
 }
 
 type ChatMessageDTO struct {
-	ID        string    `json:"id"`
-	Type      string    `json:"type"` // "ai" or "user"
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	ID           string    `json:"id"`
+	Type         string    `json:"type"` // "ai" or "user"
+	Content      string    `json:"content"`
+	Timestamp    time.Time `json:"timestamp"`
+	SupersededBy string    `json:"superseded_by,omitempty"` // Set on hidden revisions, listed only with include_revisions=true
 }
 
 type ChatInterviewSessionDTO struct {
@@ -73,9 +410,35 @@ type ChatInterviewSessionDTO struct {
 	InterviewID     string           `json:"interview_id"`
 	SessionLanguage string           `json:"session_language"` // Session language: "en" or "zh-TW"
 	Messages        []ChatMessageDTO `json:"messages"`
-	Status          string           `json:"status"` // "active" or "completed"
+	Status          string           `json:"status"` // "active", "paused", "completed" or "abandoned"
 	StartedAt       time.Time        `json:"started_at"`
 	CreatedAt       time.Time        `json:"created_at"`
+	PausedAt        *time.Time       `json:"paused_at,omitempty"`
+	// Returned only when the session starts or resumes; the candidate presents it to pause and resume
+	ResumeToken string `json:"resume_token,omitempty"`
+}
+
+// ChatSessionTokenRequestDTO authorizes pausing or resuming a chat session
+type ChatSessionTokenRequestDTO struct {
+	ResumeToken string `json:"resume_token"`
+}
+
+// InterviewSessionDTO summarizes one chat session of an interview
+type InterviewSessionDTO struct {
+	ID              string     `json:"id"`
+	Attempt         int        `json:"attempt"` // 1 for the first session, 2 for the first retake, ...
+	SessionLanguage string     `json:"session_language"`
+	Status          string     `json:"status"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	EvaluationID    string     `json:"evaluation_id,omitempty"` // Latest evaluation of the session
+}
+
+type ListInterviewSessionsResponseDTO struct {
+	InterviewID       string                `json:"interview_id"`
+	Sessions          []InterviewSessionDTO `json:"sessions"`
+	MaxSessions       int                   `json:"max_sessions"`       // The first session plus max_retakes
+	RemainingSessions int                   `json:"remaining_sessions"` // Sessions that can still be started
 }
 
 type SendMessageRequestDTO struct {
@@ -84,9 +447,350 @@ type SendMessageRequestDTO struct {
 }
 
 type SendMessageResponseDTO struct {
-	Message       ChatMessageDTO  `json:"message"`
-	AIResponse    *ChatMessageDTO `json:"ai_response,omitempty"`
-	SessionStatus string          `json:"session_status"` // "active" or "completed"
+	Message         ChatMessageDTO  `json:"message"`
+	AIResponse      *ChatMessageDTO `json:"ai_response,omitempty"`
+	SessionStatus   string          `json:"session_status"`              // "active" or "completed"
+	EvaluationJobID string          `json:"evaluation_job_id,omitempty"` // Set when this message completed the session
+}
+
+// --- Question Bank DTOs ---
+type QuestionRequestDTO struct {
+	Text           string            `json:"text"`
+	Translations   map[string]string `json:"translations,omitempty"` // Localized text keyed by language code: "en", "zh-TW"
+	Tags           []string          `json:"tags,omitempty"`
+	InterviewType  string            `json:"interview_type"`            // Required: "general", "technical", or "behavioral"
+	Difficulty     string            `json:"difficulty,omitempty"`      // "easy", "medium" (default), or "hard"
+	ExpectedAnswer string            `json:"expected_answer,omitempty"` // Notes on what a good answer covers
+}
+
+type QuestionResponseDTO struct {
+	ID             string            `json:"id"`
+	Text           string            `json:"text"`
+	Translations   map[string]string `json:"translations,omitempty"`
+	Tags           []string          `json:"tags"`
+	InterviewType  string            `json:"interview_type"`
+	Difficulty     string            `json:"difficulty"`
+	ExpectedAnswer string            `json:"expected_answer,omitempty"`
+	UsageCount     int               `json:"usage_count"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type ListQuestionsResponseDTO struct {
+	Questions  []QuestionResponseDTO `json:"questions"`
+	Total      int                   `json:"total"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	TotalPages int                   `json:"total_pages"`
+}
+
+// --- Interview Template DTOs ---
+type InterviewTemplateRequestDTO struct {
+	Name               string        `json:"name"` // Required, unique: e.g. "Backend Engineer L4"
+	Description        string        `json:"description,omitempty"`
+	InterviewType      string        `json:"interview_type"`               // Required: "general", "technical", or "behavioral"
+	InterviewLanguage  string        `json:"interview_language,omitempty"` // "en" (default) or "zh-TW"
+	Questions          []string      `json:"questions,omitempty"`
+	QuestionIDs        []string      `json:"question_ids,omitempty"` // Question bank references
+	JobDescription     string        `json:"job_description,omitempty"`
+	EndPolicy          *EndPolicyDTO `json:"end_policy,omitempty"`
+	EvaluationCriteria []string      `json:"evaluation_criteria,omitempty"`
+	InterviewerPersona string        `json:"interviewer_persona,omitempty"`
+	Rubric             *RubricDTO    `json:"rubric,omitempty"`
+	EvaluationEnsemble *EnsembleDTO  `json:"evaluation_ensemble,omitempty"`
+	EvaluationSampling *SamplingDTO  `json:"evaluation_sampling,omitempty"`
+	MaxRetakes         *int          `json:"max_retakes,omitempty"`
+}
+
+type InterviewTemplateResponseDTO struct {
+	ID                 string       `json:"id"`
+	Name               string       `json:"name"`
+	Description        string       `json:"description,omitempty"`
+	InterviewType      string       `json:"interview_type"`
+	InterviewLanguage  string       `json:"interview_language"`
+	Questions          []string     `json:"questions"`
+	QuestionIDs        []string     `json:"question_ids,omitempty"`
+	JobDescription     string       `json:"job_description,omitempty"`
+	EndPolicy          EndPolicyDTO `json:"end_policy"`
+	EvaluationCriteria []string     `json:"evaluation_criteria,omitempty"`
+	InterviewerPersona string       `json:"interviewer_persona,omitempty"`
+	Rubric             *RubricDTO   `json:"rubric,omitempty"`
+	EvaluationEnsemble *EnsembleDTO `json:"evaluation_ensemble,omitempty"`
+	EvaluationSampling *SamplingDTO `json:"evaluation_sampling,omitempty"`
+	MaxRetakes         int          `json:"max_retakes"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+}
+
+type ListInterviewTemplatesResponseDTO struct {
+	Templates []InterviewTemplateResponseDTO `json:"templates"`
+	Total     int                            `json:"total"`
+}
+
+// --- Background Job DTOs ---
+// JobResponseDTO reports the progress of a background job; poll GET /jobs/{id} until the status
+// is "succeeded" or "dead"
+type JobResponseDTO struct {
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	Status       string     `json:"status"` // "pending", "running", "succeeded", "dead"
+	Attempts     int        `json:"attempts"`
+	MaxAttempts  int        `json:"max_attempts"`
+	LastError    string     `json:"last_error,omitempty"`
+	EvaluationID string     `json:"evaluation_id,omitempty"` // Set once an evaluation job succeeds
+	RunAt        time.Time  `json:"run_at"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type ListJobsResponseDTO struct {
+	Jobs  []JobResponseDTO `json:"jobs"`
+	Total int              `json:"total"`
+}
+
+// --- Resume DTOs ---
+type ResumeResponseDTO struct {
+	ID            string    `json:"id"`
+	InterviewID   string    `json:"interview_id"`
+	OriginalName  string    `json:"original_name"`
+	ContentType   string    `json:"content_type"` // "application/pdf", DOCX or "text/plain"
+	FileSize      int64     `json:"file_size"`
+	ExtractedText string    `json:"extracted_text"`
+	ProfileJobID  string    `json:"profile_job_id,omitempty"` // Job parsing the resume into a candidate profile
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ResumeQuestionDTO is an interview question suggested from the candidate's resume
+type ResumeQuestionDTO struct {
+	Question   string   `json:"question"`
+	Category   string   `json:"category,omitempty"`
+	Difficulty string   `json:"difficulty,omitempty"`
+	FollowUp   []string `json:"follow_up,omitempty"`
+}
+
+type ResumeQuestionsResponseDTO struct {
+	Questions []ResumeQuestionDTO `json:"questions"`
+}
+
+// --- Candidate DTOs ---
+type CandidateRequestDTO struct {
+	Name       string              `json:"name"`
+	Email      string              `json:"email,omitempty"`
+	Phone      string              `json:"phone,omitempty"`
+	ExternalID string              `json:"external_id,omitempty"` // ID in an applicant tracking system
+	Locale     string              `json:"locale,omitempty"`      // Preferred interview language: "en" or "zh-TW"
+	Consent    CandidateConsentDTO `json:"consent"`
+}
+
+// CandidateConsentDTO records what the candidate has agreed to
+type CandidateConsentDTO struct {
+	DataProcessing bool       `json:"data_processing"`      // Storing and processing their data
+	AIEvaluation   bool       `json:"ai_evaluation"`        // AI scoring of their interviews
+	TalentPool     bool       `json:"talent_pool"`          // Being considered for other roles
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"` // Read-only: when consent last changed
+}
+
+type CandidateResponseDTO struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Email      string              `json:"email,omitempty"`
+	Phone      string              `json:"phone,omitempty"`
+	ExternalID string              `json:"external_id,omitempty"`
+	Locale     string              `json:"locale,omitempty"`
+	Consent    CandidateConsentDTO `json:"consent"`
+	CreatedBy  string              `json:"created_by,omitempty"` // Principal that created the candidate
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+type ListCandidatesResponseDTO struct {
+	Candidates []CandidateResponseDTO `json:"candidates"`
+	Total      int                    `json:"total"`
+}
+
+// CandidateHistoryResponseDTO is a candidate with their interviews over time, oldest first
+type CandidateHistoryResponseDTO struct {
+	Candidate  CandidateResponseDTO    `json:"candidate"`
+	Interviews []CandidateInterviewDTO `json:"interviews"`
+}
+
+// CandidateInterviewDTO is one of a candidate's interviews with its chat sessions and evaluations
+type CandidateInterviewDTO struct {
+	Interview   InterviewResponseDTO    `json:"interview"`
+	Sessions    []InterviewSessionDTO   `json:"sessions"`
+	Evaluations []EvaluationResponseDTO `json:"evaluations"`
+}
+
+// --- Position DTOs ---
+type PositionRequestDTO struct {
+	Title          string     `json:"title"`
+	Department     string     `json:"department,omitempty"`
+	Level          string     `json:"level,omitempty"`           // e.g. "senior" or "L4"
+	Description    string     `json:"description,omitempty"`     // Job description for its interviews
+	RequiredSkills []string   `json:"required_skills,omitempty"` // Given to the evaluator as a checklist
+	TemplateID     string     `json:"template_id,omitempty"`     // Default template for its interviews
+	Rubric         *RubricDTO `json:"rubric,omitempty"`          // Default rubric for its interviews
+}
+
+type PositionResponseDTO struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Department     string     `json:"department,omitempty"`
+	Level          string     `json:"level,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	RequiredSkills []string   `json:"required_skills"`
+	TemplateID     string     `json:"template_id,omitempty"`
+	Rubric         *RubricDTO `json:"rubric,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type ListPositionsResponseDTO struct {
+	Positions []PositionResponseDTO `json:"positions"`
+	Total     int                   `json:"total"`
+}
+
+// --- Candidate profile DTOs ---
+
+// CandidateProfileDTO is the structured form of a candidate's resume
+type CandidateProfileDTO struct {
+	Summary           string              `json:"summary,omitempty"`
+	YearsOfExperience float64             `json:"years_of_experience,omitempty"`
+	Skills            []string            `json:"skills,omitempty"`
+	Languages         []string            `json:"languages,omitempty"` // Spoken languages
+	WorkHistory       []WorkExperienceDTO `json:"work_history,omitempty"`
+	Education         []EducationDTO      `json:"education,omitempty"`
+	Source            string              `json:"source,omitempty"`     // Read-only: "ai" or "recruiter"
+	UpdatedAt         *time.Time          `json:"updated_at,omitempty"` // Read-only
+}
+
+type WorkExperienceDTO struct {
+	Company    string   `json:"company"`
+	Title      string   `json:"title,omitempty"`
+	StartDate  string   `json:"start_date,omitempty"` // "YYYY" or "YYYY-MM"
+	EndDate    string   `json:"end_date,omitempty"`   // "YYYY", "YYYY-MM" or "present"
+	Highlights []string `json:"highlights,omitempty"` // Notable projects and achievements
+}
+
+type EducationDTO struct {
+	Institution    string `json:"institution"`
+	Degree         string `json:"degree,omitempty"`
+	Field          string `json:"field,omitempty"`
+	GraduationYear int    `json:"graduation_year,omitempty"`
+}
+
+// --- Webhook DTOs ---
+type WebhookSubscriptionRequestDTO struct {
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"` // Generated when omitted on create; kept when omitted on update
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description,omitempty"`
+	Active      *bool    `json:"active,omitempty"` // Defaults to true
+}
+
+type WebhookSubscriptionResponseDTO struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"` // Only returned when the subscription is created
+	EventTypes  []string  `json:"event_types"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ListWebhookSubscriptionsResponseDTO struct {
+	Webhooks []WebhookSubscriptionResponseDTO `json:"webhooks"`
+	Total    int                              `json:"total"`
+}
+
+// WebhookEventDTO is the JSON body POSTed to subscribers. Deliveries are at-least-once, so
+// subscribers should ignore an ID they have already processed.
+type WebhookEventDTO struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookSessionDTO is the data of session.started and session.completed events
+type WebhookSessionDTO struct {
+	SessionID       string     `json:"session_id"`
+	InterviewID     string     `json:"interview_id"`
+	Status          string     `json:"status"`
+	SessionLanguage string     `json:"session_language"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+}
+
+type WebhookDeliveryDTO struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"` // "pending", "succeeded", "failed"
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"` // HTTP status of the last attempt
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"` // Set while pending
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	ReplayOf       string     `json:"replay_of,omitempty"` // Delivery this one replays
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type ListWebhookDeliveriesResponseDTO struct {
+	Deliveries []WebhookDeliveryDTO `json:"deliveries"`
+	Total      int                  `json:"total"`
+}
+
+// --- Invitation DTOs ---
+type CreateInvitationRequestDTO struct {
+	ExpiresInHours int `json:"expires_in_hours,omitempty"` // 0 uses the configured invitation lifetime
+	MaxUses        int `json:"max_uses,omitempty"`         // Chat sessions the link may start; 0 means 1
+}
+
+type InvitationResponseDTO struct {
+	ID          string     `json:"id"`
+	InterviewID string     `json:"interview_id"`
+	CandidateID string     `json:"candidate_id,omitempty"`
+	Token       string     `json:"token,omitempty"` // Only returned when the invitation is created
+	Link        string     `json:"link,omitempty"`  // Only returned when created and a base URL is configured
+	ExpiresAt   time.Time  `json:"expires_at"`
+	MaxUses     int        `json:"max_uses"`
+	Uses        int        `json:"uses"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type ListInvitationsResponseDTO struct {
+	Invitations []InvitationResponseDTO `json:"invitations"`
+	Total       int                     `json:"total"`
+}
+
+// --- API Key DTOs ---
+type CreateAPIKeyRequestDTO struct {
+	Name string `json:"name"`           // e.g. the integration using the key
+	Role string `json:"role,omitempty"` // "admin", "recruiter" (default) or "reviewer"
+}
+
+type APIKeyResponseDTO struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"` // Only returned when the key is created
+	Prefix     string     `json:"prefix"`        // Start of the key, to tell keys apart
+	Role       string     `json:"role"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ListAPIKeysResponseDTO struct {
+	APIKeys []APIKeyResponseDTO `json:"api_keys"`
+	Total   int                 `json:"total"`
 }
 
 // --- Error DTO ---
@@ -94,3 +798,11 @@ type ErrorResponseDTO struct {
 	Error   string `json:"error"`
 	Details string `json:"details,omitempty"`
 }
+
+// ForbiddenResponseDTO is the body of every 403 response
+type ForbiddenResponseDTO struct {
+	Error      string `json:"error"`
+	Code       string `json:"code"`                 // Always "forbidden"
+	Permission string `json:"permission,omitempty"` // Permission the route requires
+	Role       string `json:"role,omitempty"`       // Role the request was made with
+}
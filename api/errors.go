@@ -7,6 +7,10 @@ import "net/http"
 const (
 	ErrMsgMissingInterviewID  = "Bad Request: missing interview ID"
 	ErrMsgMissingEvaluationID = "Bad Request: missing evaluation ID"
+	ErrMsgMissingQuestionID   = "Bad Request: missing question ID"
+	ErrMsgMissingTemplateID   = "Bad Request: missing template ID"
+	ErrMsgMissingCandidateID  = "Bad Request: missing candidate ID"
+	ErrMsgMissingPositionID   = "Bad Request: missing position ID"
 	ErrMsgMethodNotAllowed    = "Method Not Allowed"
 )
 
@@ -0,0 +1,210 @@
+// DTO conversion and validation for the evaluation and session settings shared by interviews and templates
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zidane0000/AI_Interview_Backend/ai"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Helper: convert an end policy DTO to its data model, treating nil as "use defaults"
+func endPolicyFromDTO(dto *EndPolicyDTO) data.EndPolicy {
+	if dto == nil {
+		return data.EndPolicy{}
+	}
+	return data.EndPolicy{
+		MaxUserMessages:    dto.MaxUserMessages,
+		MaxDurationMinutes: dto.MaxDurationMinutes,
+	}
+}
+
+// Helper: convert an end policy data model to its DTO
+func endPolicyToDTO(policy data.EndPolicy) EndPolicyDTO {
+	return EndPolicyDTO{
+		MaxUserMessages:    policy.MaxUserMessages,
+		MaxDurationMinutes: policy.MaxDurationMinutes,
+	}
+}
+
+// Helper: convert a rubric DTO to its data model, treating nil as "no rubric"
+func rubricFromDTO(dto *RubricDTO) data.Rubric {
+	if dto == nil {
+		return data.Rubric{}
+	}
+	criteria := make([]data.RubricCriterion, len(dto.Criteria))
+	for i, c := range dto.Criteria {
+		anchors := make([]data.RubricAnchor, len(c.Anchors))
+		for j, a := range c.Anchors {
+			anchors[j] = data.RubricAnchor{Score: a.Score, Description: a.Description}
+		}
+		criteria[i] = data.RubricCriterion{
+			Key:         strings.TrimSpace(c.Key),
+			Name:        c.Name,
+			Description: c.Description,
+			Weight:      c.Weight,
+			Anchors:     anchors,
+		}
+	}
+	return data.Rubric{Criteria: criteria}
+}
+
+// Helper: convert a rubric data model to its DTO, returning nil when the rubric is empty
+func rubricToDTO(rubric data.Rubric) *RubricDTO {
+	if rubric.IsEmpty() {
+		return nil
+	}
+	criteria := make([]RubricCriterionDTO, len(rubric.Criteria))
+	for i, c := range rubric.Criteria {
+		anchors := make([]RubricAnchorDTO, len(c.Anchors))
+		for j, a := range c.Anchors {
+			anchors[j] = RubricAnchorDTO{Score: a.Score, Description: a.Description}
+		}
+		criteria[i] = RubricCriterionDTO{
+			Key:         c.Key,
+			Name:        c.Name,
+			Description: c.Description,
+			Weight:      c.Weight,
+			Anchors:     anchors,
+		}
+	}
+	return &RubricDTO{Criteria: criteria}
+}
+
+// Helper: convert a rubric data model to the AI layer's criteria
+func rubricToAI(rubric data.Rubric) []ai.RubricCriterion {
+	criteria := make([]ai.RubricCriterion, len(rubric.Criteria))
+	for i, c := range rubric.Criteria {
+		anchors := make([]ai.RubricAnchor, len(c.Anchors))
+		for j, a := range c.Anchors {
+			anchors[j] = ai.RubricAnchor{Score: a.Score, Description: a.Description}
+		}
+		criteria[i] = ai.RubricCriterion{
+			Key:         c.Key,
+			Name:        c.Name,
+			Description: c.Description,
+			Weight:      c.Weight,
+			Anchors:     anchors,
+		}
+	}
+	return criteria
+}
+
+// Helper: validate an optional rubric DTO, returning an error message or "" if valid
+func validateRubricDTO(dto *RubricDTO) string {
+	if dto == nil {
+		return ""
+	}
+	if len(dto.Criteria) == 0 {
+		return "Invalid rubric: at least one criterion is required"
+	}
+	if err := rubricFromDTO(dto).Validate(); err != nil {
+		return "Invalid rubric: " + err.Error()
+	}
+	return ""
+}
+
+// Helper: convert an ensemble DTO to its data model, treating nil as "single model"
+func ensembleFromDTO(dto *EnsembleDTO) data.EnsembleConfig {
+	if dto == nil {
+		return data.EnsembleConfig{}
+	}
+	return data.EnsembleConfig{
+		Models:                dto.Models,
+		Aggregation:           dto.Aggregation,
+		DisagreementThreshold: dto.DisagreementThreshold,
+	}
+}
+
+// Helper: convert an ensemble data model to its DTO, returning nil when disabled
+func ensembleToDTO(ensemble data.EnsembleConfig) *EnsembleDTO {
+	if ensemble.IsEmpty() {
+		return nil
+	}
+	return &EnsembleDTO{
+		Models:                ensemble.Models,
+		Aggregation:           ensemble.Aggregation,
+		DisagreementThreshold: ensemble.DisagreementThreshold,
+	}
+}
+
+// Helper: convert an ensemble data model to AI evaluation options, returning nil when disabled
+func ensembleToAI(ensemble data.EnsembleConfig) *ai.EnsembleOptions {
+	if ensemble.IsEmpty() {
+		return nil
+	}
+	return &ai.EnsembleOptions{
+		Models:                ensemble.Models,
+		Aggregation:           ensemble.Aggregation,
+		DisagreementThreshold: ensemble.DisagreementThreshold,
+	}
+}
+
+// Helper: validate an optional ensemble DTO, returning an error message or "" if valid
+func validateEnsembleDTO(dto *EnsembleDTO) string {
+	if dto == nil {
+		return ""
+	}
+	ensemble := ensembleFromDTO(dto)
+	if ensemble.IsEmpty() {
+		return "Invalid evaluation_ensemble: models is required"
+	}
+	if err := ensembleToAI(ensemble).Validate(); err != nil {
+		return "Invalid evaluation_ensemble: " + err.Error()
+	}
+	return ""
+}
+
+// Helper: convert a sampling DTO to its data model, treating nil as "single run"
+func samplingFromDTO(dto *SamplingDTO) data.SamplingConfig {
+	if dto == nil {
+		return data.SamplingConfig{}
+	}
+	return data.SamplingConfig(*dto)
+}
+
+// Helper: convert a sampling data model to its DTO, returning nil when disabled
+func samplingToDTO(sampling data.SamplingConfig) *SamplingDTO {
+	if sampling.IsEmpty() {
+		return nil
+	}
+	dto := SamplingDTO(sampling)
+	return &dto
+}
+
+// Helper: convert a sampling data model to AI evaluation options, returning nil when disabled
+func samplingToAI(sampling data.SamplingConfig) *ai.SamplingOptions {
+	if sampling.IsEmpty() {
+		return nil
+	}
+	opts := ai.SamplingOptions(sampling)
+	return &opts
+}
+
+// Helper: validate an optional sampling DTO, returning an error message or "" if valid
+func validateSamplingDTO(dto *SamplingDTO) string {
+	if dto == nil {
+		return ""
+	}
+	if err := ai.SamplingOptions(*dto).Validate(); err != nil {
+		return "Invalid evaluation_sampling: " + err.Error()
+	}
+	return ""
+}
+
+// Helper: validate an optional retake limit, returning an error message or "" if valid
+func validateMaxRetakes(maxRetakes *int) string {
+	if maxRetakes != nil && (*maxRetakes < 0 || *maxRetakes > maxInterviewRetakes) {
+		return fmt.Sprintf("Invalid max_retakes: must be between 0 and %d", maxInterviewRetakes)
+	}
+	return ""
+}
+
+// Helper: dereference an optional retake limit, treating nil as "no retakes"
+func maxRetakesFromDTO(maxRetakes *int) int {
+	if maxRetakes == nil {
+		return 0
+	}
+	return *maxRetakes
+}
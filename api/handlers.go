@@ -2,21 +2,43 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/zidane0000/AI_Interview_Backend/ai"
+	"github.com/zidane0000/AI_Interview_Backend/config"
 	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/files"
 	"github.com/zidane0000/AI_Interview_Backend/utils"
 )
 
+// Chat session limits
+const (
+	maxInterviewRetakes   = 10  // Sessions an interview may allow after the first
+	maxSessionEvaluations = 100 // Evaluations scanned when linking an interview's sessions to their results
+)
+
 // HandlerDependencies contains all dependencies needed by handlers
 type HandlerDependencies struct {
 	AIClientFactory *ai.AIClientFactory
+	JobMaxAttempts  int             // Attempts queued jobs get before they are dead-lettered
+	BlobStore       files.BlobStore // Uploaded file contents; nil disables uploads
+	MaxUploadSize   int64           // Largest accepted upload in bytes; 0 uses the default
+
+	AuthRequired      bool          // Endpoints need credentials; false, only with AUTH_DISABLED, leaves them open
+	StaffAPIToken     string        // Static bearer token accepted as staff credentials
+	JWT               *jwtVerifier  // Verifies staff JWTs; nil when none are accepted
+	InvitationSecret  []byte        // Key signing invitation tokens; empty disables invitations
+	InvitationTTL     time.Duration // Lifetime of new invitations; 0 uses the default
+	InvitationBaseURL string        // Candidate-facing page invitation links point at
 }
 
 // NewHandlerDependencies creates a new handler dependencies container
@@ -26,6 +48,34 @@ func NewHandlerDependencies(aiClientFactory *ai.AIClientFactory) *HandlerDepende
 	}
 }
 
+// NewHandlerDependenciesFromConfig creates the dependencies shared by the router and background workers;
+// main.go builds them once and passes the same instance to each
+func NewHandlerDependenciesFromConfig(cfg *config.Config) *HandlerDependencies {
+	deps := NewHandlerDependencies(ai.NewAIClientFactory(*cfg))
+	deps.JobMaxAttempts = cfg.JobMaxAttempts
+	deps.MaxUploadSize = cfg.MaxUploadSize
+	deps.StaffAPIToken = cfg.StaffAPIToken
+	// Without credentials requests are refused unless authentication was explicitly disabled
+	deps.AuthRequired = !cfg.AuthDisabled
+	if verifier, err := newJWTVerifier(cfg); err != nil {
+		utils.Warningf("JWT authentication disabled: %v", err)
+	} else {
+		deps.JWT = verifier
+	}
+	deps.InvitationSecret = []byte(cfg.InvitationSecret)
+	deps.InvitationTTL = cfg.InvitationTTL
+	deps.InvitationBaseURL = cfg.InvitationBaseURL
+	if cfg.UploadPath != "" {
+		blobStore, err := files.NewLocalBlobStore(cfg.UploadPath)
+		if err != nil {
+			utils.Warningf("File uploads disabled: %v", err)
+		} else {
+			deps.BlobStore = blobStore
+		}
+	}
+	return deps
+}
+
 // Helper: parse integer query parameter with default value
 func parseIntQuery(r *http.Request, key string, defaultValue int) int {
 	if str := r.URL.Query().Get(key); str != "" {
@@ -36,6 +86,19 @@ func parseIntQuery(r *http.Request, key string, defaultValue int) int {
 	return defaultValue
 }
 
+// Helper: parse float query parameter with default value, rejecting values that are not numbers
+func parseFloatQuery(r *http.Request, key string, defaultValue float64) (float64, error) {
+	str := r.URL.Query().Get(key)
+	if str == "" {
+		return defaultValue, nil
+	}
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(val) {
+		return 0, fmt.Errorf("%s must be a number", key)
+	}
+	return val, nil
+}
+
 // Helper: write JSON response
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -59,65 +122,323 @@ func writeJSONError(w http.ResponseWriter, status int, msg string, details ...st
 	}
 }
 
-// CreateInterviewHandler handles POST /interviews
-func CreateInterviewHandler(w http.ResponseWriter, r *http.Request) {
-	var req CreateInterviewRequestDTO
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
-		return
+// Helper: convert an interview to its response DTO, resolving question bank references
+func newInterviewResponseDTO(interview *data.Interview) InterviewResponseDTO {
+	return InterviewResponseDTO{
+		ID:                 interview.ID,
+		CandidateName:      interview.CandidateName,
+		Questions:          resolveInterviewQuestions(interview),
+		InterviewType:      interview.InterviewType,
+		InterviewLanguage:  interview.InterviewLanguage,
+		JobDescription:     interview.JobDescription,
+		QuestionIDs:        interview.QuestionIDs,
+		TemplateID:         interview.TemplateID,
+		CandidateID:        stringValue(interview.CandidateID),
+		PositionID:         stringValue(interview.PositionID),
+		Status:             interview.Status,
+		AllowedTransitions: data.NextInterviewStatuses(interview.Status),
+		EndPolicy:          endPolicyToDTO(interview.EndPolicy),
+		EvaluationCriteria: interview.EvalCriteria,
+		InterviewerPersona: interview.Persona,
+		Rubric:             rubricToDTO(interview.Rubric),
+		EvaluationEnsemble: ensembleToDTO(interview.Ensemble),
+		EvaluationSampling: samplingToDTO(interview.Sampling),
+		MaxRetakes:         interview.MaxRetakes,
+		ResumeFileID:       interview.ResumeFileID,
+		CreatedBy:          interview.CreatedBy,
+		CreatedAt:          interview.CreatedAt,
 	}
-	if req.CandidateName == "" || len(req.Questions) == 0 {
-		writeJSONError(w, http.StatusBadRequest, "Missing candidate_name or questions")
-		return
+}
+
+// Helper: convert a chat session and its messages to the session DTO
+func newChatSessionDTO(session *data.ChatSession, messages []*data.ChatMessage) ChatInterviewSessionDTO {
+	messageDTOs := make([]ChatMessageDTO, len(messages))
+	for i, msg := range messages {
+		messageDTOs[i] = ChatMessageDTO{
+			ID:           msg.ID,
+			Type:         msg.Type,
+			Content:      msg.Content,
+			Timestamp:    msg.Timestamp,
+			SupersededBy: msg.SupersededBy,
+		}
+	}
+	return ChatInterviewSessionDTO{
+		ID:              session.ID,
+		InterviewID:     session.InterviewID,
+		SessionLanguage: session.SessionLanguage,
+		Messages:        messageDTOs,
+		Status:          session.Status,
+		StartedAt:       session.StartedAt,
+		CreatedAt:       session.CreatedAt,
+		PausedAt:        session.PausedAt,
+	}
+}
+
+// Helper: dereference an optional string, treating nil as ""
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// Helper: convert an evaluation to its response DTO
+func newEvaluationResponseDTO(evaluation *data.Evaluation) EvaluationResponseDTO {
+	return EvaluationResponseDTO{
+		ID:             evaluation.ID,
+		InterviewID:    evaluation.InterviewID,
+		SessionID:      stringValue(evaluation.SessionID),
+		Partial:        evaluation.Partial,
+		Answers:        evaluation.Answers,
+		Score:          evaluation.Score,
+		Feedback:       evaluation.Feedback,
+		Strengths:      evaluation.Strengths,
+		Weaknesses:     evaluation.Weaknesses,
+		CategoryScores: evaluation.CategoryScores,
+		ScoringMethod:  evaluation.ScoringMethod,
+		Rubric:         rubricToDTO(evaluation.Rubric),
+		ModelResults:   modelResultsToDTO(evaluation.ModelResults),
+		Disagreement:   evaluation.Disagreement,
+		NeedsReview:    evaluation.NeedsReview,
+		Consistency:    consistencyToDTO(evaluation.Consistency),
+		LowConfidence:  evaluation.LowConfidence,
+
+		HumanScore:          evaluation.HumanScore,
+		HumanCategoryScores: evaluation.HumanCategoryScores,
+		FinalScore:          evaluation.FinalScore(),
+		Decision:            evaluation.Decision,
+		ReviewedBy:          evaluation.ReviewedBy,
+		ReviewedAt:          evaluation.ReviewedAt,
+		Annotations:         annotationsToDTO(evaluation.Annotations),
+		SignedOff:           evaluation.Decision != "",
+		CreatedAt:           evaluation.CreatedAt,
+	}
+}
+
+// Helper: convert stored consistency statistics to their DTO, returning nil for single-run evaluations
+func consistencyToDTO(stats data.ConsistencyStats) *ConsistencyDTO {
+	if stats.IsEmpty() {
+		return nil
+	}
+	dto := ConsistencyDTO(stats)
+	return &dto
+}
+
+// Helper: convert consistency statistics from the AI layer to their data model
+func consistencyFromAI(stats *ai.ConsistencyStats) data.ConsistencyStats {
+	if stats == nil {
+		return data.ConsistencyStats{}
+	}
+	return data.ConsistencyStats(*stats)
+}
+
+// Helper: convert stored per-model ensemble results to DTOs
+func modelResultsToDTO(results data.ModelResults) []ModelResultDTO {
+	if len(results) == 0 {
+		return nil
+	}
+	dtos := make([]ModelResultDTO, len(results))
+	for i, result := range results {
+		dtos[i] = ModelResultDTO(result)
+	}
+	return dtos
+}
+
+// Helper: convert per-model ensemble results from the AI layer to their data model
+func modelResultsFromAI(results []ai.ModelResult) data.ModelResults {
+	if len(results) == 0 {
+		return nil
+	}
+	stored := make(data.ModelResults, len(results))
+	for i, result := range results {
+		stored[i] = data.ModelResult{
+			Provider:       result.Provider,
+			Model:          result.Model,
+			Score:          result.OverallScore,
+			CategoryScores: result.CategoryScores,
+			Feedback:       result.Feedback,
+			Error:          result.Error,
+		}
 	}
+	return stored
+}
+
+// Helper: build AI evaluation options from the interview configuration
+func evaluationOptionsForInterview(interview *data.Interview, language string) ai.EvaluationOptions {
+	jobDesc := interview.JobDescription
+	if jobDesc == "" {
+		jobDesc = fmt.Sprintf("General %s interview", interview.InterviewType)
+	}
+
+	opts := ai.EvaluationOptions{
+		JobDescription: jobDesc,
+		Language:       language,
+		Criteria:       interview.EvalCriteria,
+		Rubric:         rubricToAI(interview.Rubric),
+		Ensemble:       ensembleToAI(interview.Ensemble),
+		Sampling:       samplingToAI(interview.Sampling),
+	}
+	// The position's title and required skills are read at evaluation time, so edits to them apply
+	if interview.PositionID != nil {
+		if position, err := data.GlobalStore.GetPosition(*interview.PositionID); err == nil {
+			opts.Position = positionToAI(position)
+		} else {
+			utils.Warningf("Failed to get position %s for interview %s: %v", *interview.PositionID, interview.ID, err)
+		}
+	}
+	return opts
+}
 
+// Helper: build AI chat options from the interview configuration
+func chatOptionsForInterview(interview *data.Interview, language string) ai.ChatOptions {
+	return ai.ChatOptions{
+		Language:       language,
+		InterviewType:  interview.InterviewType,
+		JobDescription: interview.JobDescription,
+		Persona:        interview.Persona,
+		ResumeText:     interview.ResumeText,
+		Profile:        candidateProfileToAI(interview.CandidateProfile),
+	}
+}
+
+// Helper: decide whether a chat session should end under the interview's end policy
+func shouldEndSession(aiClient *ai.AIClient, interview *data.Interview, session *data.ChatSession, userMessageCount int) bool {
+	policy := interview.EndPolicy
+	// Paused time does not count toward the duration limit
+	if policy.MaxDurationMinutes > 0 && session.ActiveDuration(time.Now()) >= time.Duration(policy.MaxDurationMinutes)*time.Minute {
+		return true
+	}
+	if policy.MaxUserMessages > 0 {
+		return userMessageCount >= policy.MaxUserMessages
+	}
+	return aiClient.ShouldEndInterview(userMessageCount)
+}
+
+// Helper: validate a create/update interview request after template defaults are applied,
+// returning an error message or "" if valid
+func validateInterviewRequest(req *CreateInterviewRequestDTO) string {
+	if req.CandidateName == "" || (len(req.Questions) == 0 && len(req.QuestionIDs) == 0) {
+		return "Missing candidate_name or questions"
+	}
 	// Validate required interview_type field
 	if req.InterviewType == "" {
-		writeJSONError(w, http.StatusBadRequest, "Missing interview_type field")
-		return
+		return "Missing interview_type field"
 	}
 	if !data.ValidateInterviewType(req.InterviewType) {
-		writeJSONError(w, http.StatusBadRequest, "Invalid interview_type. Supported types: general, technical, behavioral")
-		return
+		return "Invalid interview_type. Supported types: general, technical, behavioral"
 	}
-
 	// Validate language if provided
 	if req.InterviewLanguage != "" && !data.ValidateLanguage(req.InterviewLanguage) {
-		writeJSONError(w, http.StatusBadRequest, "Invalid language code. Supported languages: en, zh-TW")
+		return "Invalid language code. Supported languages: en, zh-TW"
+	}
+	if req.EndPolicy != nil && (req.EndPolicy.MaxUserMessages < 0 || req.EndPolicy.MaxDurationMinutes < 0) {
+		return "Invalid end_policy: values cannot be negative"
+	}
+	if msg := validateRubricDTO(req.Rubric); msg != "" {
+		return msg
+	}
+	if msg := validateEnsembleDTO(req.EvaluationEnsemble); msg != "" {
+		return msg
+	}
+	if msg := validateSamplingDTO(req.EvaluationSampling); msg != "" {
+		return msg
+	}
+	return validateMaxRetakes(req.MaxRetakes)
+}
+
+// Helper: move an in-progress interview to completed once its chat session ends
+func completeInterview(interviewID string) {
+	interview, err := data.GlobalStore.GetInterview(interviewID)
+	if err != nil || interview.Status != data.InterviewStatusInProgress {
 		return
 	}
-	// Process language parameter with default fallback
-	interviewLanguage := data.GetValidatedLanguage(req.InterviewLanguage)
+	if _, err := data.GlobalStore.TransitionInterview(interviewID, data.InterviewStatusCompleted); err != nil {
+		utils.Warningf("Failed to move interview %s to completed: %v", interviewID, err)
+	}
+}
 
-	// Generate unique ID and create interview record
-	interviewID := data.GenerateID()
+// Helper: build a new draft interview from a validated create request
+func newInterviewFromRequest(req *CreateInterviewRequestDTO) *data.Interview {
 	interview := &data.Interview{
-		ID:                interviewID,
+		ID:                data.GenerateID(),
 		CandidateName:     req.CandidateName,
 		Questions:         req.Questions,
 		InterviewType:     req.InterviewType,
-		InterviewLanguage: interviewLanguage,
-		JobDescription:    req.JobDescription, // Add job description (optional)
+		InterviewLanguage: data.GetValidatedLanguage(req.InterviewLanguage), // Default language when unset
+		JobDescription:    req.JobDescription,                               // Add job description (optional)
+		QuestionIDs:       req.QuestionIDs,
+		TemplateID:        req.TemplateID,
+		Status:            data.InterviewStatusDraft,
+		EndPolicy:         endPolicyFromDTO(req.EndPolicy),
+		EvalCriteria:      req.EvaluationCriteria,
+		Persona:           req.InterviewerPersona,
+		Rubric:            rubricFromDTO(req.Rubric),
+		Ensemble:          ensembleFromDTO(req.EvaluationEnsemble),
+		Sampling:          samplingFromDTO(req.EvaluationSampling),
+		MaxRetakes:        maxRetakesFromDTO(req.MaxRetakes),
 		CreatedAt:         time.Now(),
 		UpdatedAt:         time.Now(),
 	}
+	if req.CandidateID != "" {
+		interview.CandidateID = &req.CandidateID
+	}
+	if req.PositionID != "" {
+		interview.PositionID = &req.PositionID
+	}
+	return interview
+}
+
+// CreateInterviewHandler handles POST /interviews
+func CreateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	var req CreateInterviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	// Fill unspecified fields from the position and then the template, if given
+	if msg := applyInterviewPosition(&req); msg != "" {
+		writeJSONError(w, http.StatusNotFound, msg)
+		return
+	}
+	if req.TemplateID != "" {
+		template, err := data.GlobalStore.GetInterviewTemplate(req.TemplateID)
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, "Template not found")
+			return
+		}
+		applyInterviewTemplate(&req, template)
+	}
+	if msg := applyInterviewCandidate(&req); msg != "" {
+		writeJSONError(w, http.StatusNotFound, msg)
+		return
+	}
+
+	if msg := validateInterviewRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+	// Referenced bank questions must exist; their text is resolved at read time
+	if len(req.QuestionIDs) > 0 {
+		if _, err := data.GlobalStore.GetQuestionsByIDs(req.QuestionIDs); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid question_ids", err.Error())
+			return
+		}
+	}
+
+	interview := newInterviewFromRequest(&req)
+	interview.CreatedBy = creatorOf(r)
 	// Store interview in hybrid store
-	err := data.GlobalStore.CreateInterview(interview)
+	err := data.GlobalStore.CreateInterview(interview, newWebhookEvent(data.WebhookEventInterviewCreated, newInterviewResponseDTO(interview)))
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "Failed to create interview", err.Error())
 		return
 	}
-
-	resp := InterviewResponseDTO{
-		ID:                interview.ID,
-		CandidateName:     interview.CandidateName,
-		Questions:         interview.Questions,
-		InterviewType:     interview.InterviewType,
-		InterviewLanguage: interview.InterviewLanguage,
-		JobDescription:    interview.JobDescription, // Include job description in response
-		CreatedAt:         interview.CreatedAt,
+	if err := data.GlobalStore.IncrementQuestionUsage(req.QuestionIDs); err != nil {
+		utils.Warningf("Failed to update question usage counts: %v", err)
 	}
-	writeJSON(w, http.StatusCreated, resp)
+
+	writeJSON(w, http.StatusCreated, newInterviewResponseDTO(interview))
 }
 
 // ListInterviewsHandler handles GET /interviews
@@ -136,6 +457,11 @@ func ListInterviewsHandler(w http.ResponseWriter, r *http.Request) {
 	if status := r.URL.Query().Get("status"); status != "" {
 		opts.Status = status
 	}
+	opts.TemplateID = r.URL.Query().Get("template_id")
+	opts.CandidateID = r.URL.Query().Get("candidate_id")
+	opts.PositionID = r.URL.Query().Get("position_id")
+	// Recruiters only see the interviews they created
+	opts.CreatedBy = ownerScope(r)
 	if dateFrom := r.URL.Query().Get("date_from"); dateFrom != "" {
 		if parsed, err := time.Parse("2006-01-02", dateFrom); err == nil {
 			opts.DateFrom = parsed
@@ -163,15 +489,7 @@ func ListInterviewsHandler(w http.ResponseWriter, r *http.Request) {
 	// Convert to DTOs
 	interviewDTOs := make([]InterviewResponseDTO, len(result.Interviews))
 	for i, interview := range result.Interviews {
-		interviewDTOs[i] = InterviewResponseDTO{
-			ID:                interview.ID,
-			CandidateName:     interview.CandidateName,
-			Questions:         interview.Questions,
-			InterviewType:     interview.InterviewType,
-			InterviewLanguage: interview.InterviewLanguage,
-			JobDescription:    interview.JobDescription, // Include job description
-			CreatedAt:         interview.CreatedAt,
-		}
+		interviewDTOs[i] = newInterviewResponseDTO(interview)
 	}
 
 	resp := ListInterviewsResponseDTO{
@@ -196,96 +514,228 @@ func GetInterviewHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := InterviewResponseDTO{
-		ID:                interview.ID,
-		CandidateName:     interview.CandidateName,
-		Questions:         interview.Questions,
-		InterviewType:     interview.InterviewType,
-		InterviewLanguage: interview.InterviewLanguage,
-		JobDescription:    interview.JobDescription, // Include job description
-		CreatedAt:         interview.CreatedAt,
-	}
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusOK, newInterviewResponseDTO(interview))
 }
 
-// SubmitEvaluationHandler handles POST /evaluation
-func (deps *HandlerDependencies) SubmitEvaluationHandler(w http.ResponseWriter, r *http.Request) {
-	var req SubmitEvaluationRequestDTO
+// UpdateInterviewHandler handles PUT /interviews/{id}
+// Replaces the interview's editable fields. Edits are rejected while a chat session is active.
+func UpdateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingInterviewID)
+		return
+	}
+
+	existing, err := data.GlobalStore.GetInterview(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+
+	var req CreateInterviewRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
 		return
 	}
-	if req.InterviewID == "" || len(req.Answers) == 0 {
-		writeJSONError(w, http.StatusBadRequest, "Missing interview_id or answers")
+	if msg := applyInterviewPosition(&req); msg != "" {
+		writeJSONError(w, http.StatusNotFound, msg)
 		return
 	}
-	// Validate interview exists before creating evaluation
-	interview, err := data.GlobalStore.GetInterview(req.InterviewID)
-	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Interview not found")
+	if req.TemplateID != "" {
+		template, err := data.GlobalStore.GetInterviewTemplate(req.TemplateID)
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, "Template not found")
+			return
+		}
+		applyInterviewTemplate(&req, template)
+	}
+	if msg := applyInterviewCandidate(&req); msg != "" {
+		writeJSONError(w, http.StatusNotFound, msg)
+		return
+	}
+	if msg := validateInterviewRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
 		return
 	}
+	if len(req.QuestionIDs) > 0 {
+		if _, err := data.GlobalStore.GetQuestionsByIDs(req.QuestionIDs); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid question_ids", err.Error())
+			return
+		}
+	}
 
-	// Convert answers map to arrays for AI evaluation
-	questions := interview.Questions
-	answers := make([]string, len(questions))
+	// Build the replacement on a copy so a rejected edit leaves the stored interview untouched
+	interview := *existing
+	interview.CandidateName = req.CandidateName
+	interview.Questions = req.Questions
+	interview.InterviewType = req.InterviewType
+	interview.InterviewLanguage = data.GetValidatedLanguage(req.InterviewLanguage)
+	interview.JobDescription = req.JobDescription
+	interview.QuestionIDs = req.QuestionIDs
+	interview.TemplateID = req.TemplateID
+	interview.CandidateID = nil
+	if req.CandidateID != "" {
+		interview.CandidateID = &req.CandidateID
+	}
+	interview.PositionID = nil
+	if req.PositionID != "" {
+		interview.PositionID = &req.PositionID
+	}
+	interview.EndPolicy = endPolicyFromDTO(req.EndPolicy)
+	interview.EvalCriteria = req.EvaluationCriteria
+	interview.Persona = req.InterviewerPersona
+	interview.Rubric = rubricFromDTO(req.Rubric)
+	interview.Ensemble = ensembleFromDTO(req.EvaluationEnsemble)
+	interview.Sampling = samplingFromDTO(req.EvaluationSampling)
+	interview.MaxRetakes = maxRetakesFromDTO(req.MaxRetakes)
+	interview.UpdatedAt = time.Now()
+
+	if err := data.GlobalStore.UpdateInterview(&interview); err != nil {
+		if errors.Is(err, data.ErrInterviewSessionActive) {
+			writeJSONError(w, http.StatusConflict, "Interview cannot be edited while a chat session is active")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update interview", err.Error())
+		return
+	}
 
-	// Map answers from the request to the questions order
-	for i := range questions {
-		answerKey := fmt.Sprintf("question_%d", i)
-		if answer, exists := req.Answers[answerKey]; exists {
-			answers[i] = answer
-		} else {
-			answers[i] = "" // Empty answer if not provided
+	// Only newly referenced bank questions count as new usage
+	previous := make(map[string]bool, len(existing.QuestionIDs))
+	for _, questionID := range existing.QuestionIDs {
+		previous[questionID] = true
+	}
+	var added []string
+	for _, questionID := range req.QuestionIDs {
+		if !previous[questionID] {
+			added = append(added, questionID)
 		}
 	}
-	// Generate AI evaluation using the same method as chat evaluation
-	jobDesc := interview.JobDescription
-	if jobDesc == "" {
-		jobDesc = fmt.Sprintf("General %s interview", interview.InterviewType)
+	if err := data.GlobalStore.IncrementQuestionUsage(added); err != nil {
+		utils.Warningf("Failed to update question usage counts: %v", err)
 	}
-	interviewLanguage := interview.InterviewLanguage // Use interview language for evaluation
 
-	// Create AI client for this request
-	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	writeJSON(w, http.StatusOK, newInterviewResponseDTO(&interview))
+}
+
+// TransitionInterviewHandler handles POST /interviews/{id}/transition
+func TransitionInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingInterviewID)
+		return
+	}
+
+	var req TransitionInterviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if !data.ValidateInterviewStatus(req.Status) {
+		writeJSONError(w, http.StatusBadRequest, "Invalid status. Supported values: draft, scheduled, in_progress, completed, archived, cancelled")
+		return
+	}
+
+	if _, err := data.GlobalStore.GetInterview(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+
+	interview, err := data.GlobalStore.TransitionInterview(id, req.Status)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to create AI client")
+		if errors.Is(err, data.ErrInvalidStatusTransition) {
+			writeJSONError(w, http.StatusConflict, "Status transition not allowed", err.Error())
+			return
+		}
+		if errors.Is(err, data.ErrInterviewSessionActive) {
+			writeJSONError(w, http.StatusConflict, "Interview cannot be completed or cancelled while a chat session is open")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update interview status", err.Error())
 		return
 	}
 
-	score, feedback, err := aiClient.EvaluateAnswersWithContext(questions, answers, jobDesc, interviewLanguage)
+	writeJSON(w, http.StatusOK, newInterviewResponseDTO(interview))
+}
+
+// DeleteInterviewHandler handles DELETE /interviews/{id}?cascade=true
+// Without cascade, an interview that has chat sessions or evaluations is not deleted.
+func (deps *HandlerDependencies) DeleteInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingInterviewID)
+		return
+	}
+
+	cascade := false
+	if str := r.URL.Query().Get("cascade"); str != "" {
+		parsed, err := strconv.ParseBool(str)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid cascade: must be true or false")
+			return
+		}
+		cascade = parsed
+	}
+
+	interview, err := data.GlobalStore.GetInterview(id)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate evaluation")
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+	// Look up the stored files first, including replaced resumes whose contents could not be removed;
+	// their records go with the interview but their contents are removed here
+	storedFiles, err := data.GlobalStore.ListInterviewFiles(interview.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to list interview files", err.Error())
 		return
 	}
 
-	// Create evaluation record
-	evaluationID := data.GenerateID()
-	evaluation := &data.Evaluation{
-		ID:          evaluationID,
-		InterviewID: req.InterviewID,
-		Answers:     req.Answers,
-		Score:       score,
-		Feedback:    feedback,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	if err := data.GlobalStore.DeleteInterview(id, cascade); err != nil {
+		switch {
+		case errors.Is(err, data.ErrInterviewSessionActive):
+			writeJSONError(w, http.StatusConflict, "Interview cannot be deleted while a chat session is active")
+		case errors.Is(err, data.ErrInterviewHasDependents):
+			writeJSONError(w, http.StatusConflict, "Interview has chat sessions or evaluations; retry with cascade=true to delete them too")
+		default:
+			writeJSONError(w, http.StatusInternalServerError, "Failed to delete interview", err.Error())
+		}
+		return
+	}
+	for _, file := range storedFiles {
+		deps.deleteBlob(file.StorageKey)
 	}
 
-	err = data.GlobalStore.CreateEvaluation(evaluation)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SubmitEvaluationHandler handles POST /evaluation
+// Queues the evaluation and responds 202 with a job to poll at GET /jobs/{id}.
+func (deps *HandlerDependencies) SubmitEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	var req SubmitEvaluationRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if req.InterviewID == "" || len(req.Answers) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "Missing interview_id or answers")
+		return
+	}
+	// Validate interview exists before creating evaluation
+	interview, err := data.GlobalStore.GetInterview(req.InterviewID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save evaluation")
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+	if !canAccessInterview(r, interview) {
+		writeForbidden(w, r, "Recruiters may only access their own interviews", PermEvaluationsWrite)
 		return
 	}
 
-	resp := EvaluationResponseDTO{
-		ID:          evaluationID,
-		InterviewID: req.InterviewID,
-		Answers:     req.Answers,
-		Score:       score,
-		Feedback:    feedback,
-		CreatedAt:   evaluation.CreatedAt,
+	job, err := deps.enqueueJob(data.JobTypeAnswerEvaluation, answerEvaluationPayload(interview.ID, req.Answers))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to queue evaluation", err.Error())
+		return
 	}
-	writeJSON(w, http.StatusOK, resp)
+	writeJobAccepted(w, job)
 }
 
 // GetEvaluationHandler handles GET /evaluation/{id}
@@ -302,17 +752,167 @@ func GetEvaluationHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := EvaluationResponseDTO{
-		ID:          evaluation.ID,
-		InterviewID: evaluation.InterviewID,
-		Answers:     evaluation.Answers,
-		Score:       evaluation.Score,
-		Feedback:    evaluation.Feedback,
-		CreatedAt:   evaluation.CreatedAt,
+	writeJSON(w, http.StatusOK, newEvaluationResponseDTO(evaluation))
+}
+
+// Helper: parse evaluation list/stats filters from the query string, returning an error message or "" if valid.
+// Recruiters are limited to the evaluations of their own interviews.
+func parseEvaluationFilters(r *http.Request) (data.EvaluationFilters, string) {
+	query := r.URL.Query()
+	filters := data.EvaluationFilters{
+		InterviewID: query.Get("interview_id"),
+		PositionID:  query.Get("position_id"),
+		CreatedBy:   ownerScope(r),
+		Decision:    query.Get("decision"),
+	}
+
+	for key, target := range map[string]**float64{"min_score": &filters.MinScore, "max_score": &filters.MaxScore} {
+		if str := query.Get(key); str != "" {
+			val, err := strconv.ParseFloat(str, 64)
+			if err != nil || val < 0 || val > 1 {
+				return filters, "Invalid " + key + ": must be between 0.0 and 1.0"
+			}
+			*target = &val
+		}
+	}
+	if filters.MinScore != nil && filters.MaxScore != nil && *filters.MinScore > *filters.MaxScore {
+		return filters, "Invalid score range: min_score exceeds max_score"
+	}
+
+	if dateFrom := query.Get("date_from"); dateFrom != "" {
+		parsed, err := time.Parse("2006-01-02", dateFrom)
+		if err != nil {
+			return filters, "Invalid date_from: expected YYYY-MM-DD"
+		}
+		filters.CreatedAfter = parsed
+	}
+	if dateTo := query.Get("date_to"); dateTo != "" {
+		parsed, err := time.Parse("2006-01-02", dateTo)
+		if err != nil {
+			return filters, "Invalid date_to: expected YYYY-MM-DD"
+		}
+		// Include the whole end day
+		filters.CreatedBefore = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	if filters.Decision != "" && filters.Decision != data.DecisionNone && !data.ValidateDecision(filters.Decision) {
+		return filters, "Invalid decision. Supported values: advance, hold, reject, none"
+	}
+	for key, target := range map[string]**bool{"needs_review": &filters.NeedsReview, "low_confidence": &filters.LowConfidence} {
+		if str := query.Get(key); str != "" {
+			val, err := strconv.ParseBool(str)
+			if err != nil {
+				return filters, "Invalid " + key + ": must be true or false"
+			}
+			*target = &val
+		}
+	}
+	return filters, ""
+}
+
+// ListEvaluationsHandler handles GET /evaluation
+func ListEvaluationsHandler(w http.ResponseWriter, r *http.Request) {
+	filters, msg := parseEvaluationFilters(r)
+	if msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	opts := data.ListEvaluationsOptions{
+		Limit:     parseIntQuery(r, "limit", 10),
+		Offset:    parseIntQuery(r, "offset", 0),
+		Page:      parseIntQuery(r, "page", 0),
+		Filters:   filters,
+		SortBy:    r.URL.Query().Get("sort_by"),
+		SortOrder: r.URL.Query().Get("sort_order"),
+	}
+	if !data.ValidEvaluationSort(opts.SortBy, opts.SortOrder) {
+		writeJSONError(w, http.StatusBadRequest, "Invalid sort. Supported sort_by: date, score; sort_order: asc, desc")
+		return
+	}
+
+	result, err := data.GlobalStore.ListEvaluations(opts)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch evaluations", err.Error())
+		return
+	}
+
+	evaluationDTOs := make([]EvaluationResponseDTO, len(result.Evaluations))
+	for i, evaluation := range result.Evaluations {
+		evaluationDTOs[i] = newEvaluationResponseDTO(evaluation)
+	}
+	writeJSON(w, http.StatusOK, ListEvaluationsResponseDTO{
+		Evaluations: evaluationDTOs,
+		Total:       result.Total,
+		Page:        result.Page,
+		Limit:       result.Limit,
+		TotalPages:  result.TotalPages,
+	})
+}
+
+// GetEvaluationStatsHandler handles GET /evaluation/stats
+// With ?group_by=position the statistics are also broken down per position, ordered by title.
+func GetEvaluationStatsHandler(w http.ResponseWriter, r *http.Request) {
+	filters, msg := parseEvaluationFilters(r)
+	if msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "" && groupBy != "position" {
+		writeJSONError(w, http.StatusBadRequest, "Invalid group_by. Supported values: position")
+		return
+	}
+
+	stats, err := data.GlobalStore.GetEvaluationStatistics(filters)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to compute evaluation statistics", err.Error())
+		return
+	}
+	resp := EvaluationStatsDTO{
+		TotalEvaluations:  stats.TotalEvaluations,
+		AverageScore:      stats.AverageScore,
+		MinScore:          stats.MinScore,
+		MaxScore:          stats.MaxScore,
+		ScoreDistribution: stats.ScoreDistribution,
+	}
+	if groupBy == "position" {
+		groups, err := data.GlobalStore.GetEvaluationStatisticsByPosition(filters)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to compute evaluation statistics", err.Error())
+			return
+		}
+		resp.ByPosition = newPositionEvaluationStatsDTOs(groups)
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// Helper: convert per-position statistics to DTOs ordered by position title
+func newPositionEvaluationStatsDTOs(groups map[string]*data.EvaluationStatistics) []PositionEvaluationStatsDTO {
+	dtos := make([]PositionEvaluationStatsDTO, 0, len(groups))
+	for positionID, stats := range groups {
+		dto := PositionEvaluationStatsDTO{
+			PositionID:        positionID,
+			TotalEvaluations:  stats.TotalEvaluations,
+			AverageScore:      stats.AverageScore,
+			MinScore:          stats.MinScore,
+			MaxScore:          stats.MaxScore,
+			ScoreDistribution: stats.ScoreDistribution,
+		}
+		if position, err := data.GlobalStore.GetPosition(positionID); err == nil {
+			dto.Title = position.Title
+		}
+		dtos = append(dtos, dto)
+	}
+	sort.Slice(dtos, func(i, j int) bool {
+		if dtos[i].Title != dtos[j].Title {
+			return dtos[i].Title < dtos[j].Title
+		}
+		return dtos[i].PositionID < dtos[j].PositionID
+	})
+	return dtos
+}
+
 // StartChatSessionHandler handles POST /interviews/{id}/chat/start
 func (deps *HandlerDependencies) StartChatSessionHandler(w http.ResponseWriter, r *http.Request) {
 	interviewID := chi.URLParam(r, "id")
@@ -328,6 +928,11 @@ func (deps *HandlerDependencies) StartChatSessionHandler(w http.ResponseWriter,
 		return
 	}
 
+	if !data.CanStartInterviewSession(interview.Status) {
+		writeJSONError(w, http.StatusConflict, "Cannot start a chat session for an interview in status "+interview.Status)
+		return
+	}
+
 	// Parse optional request body for language preference
 	var req StartChatSessionRequestDTO
 	if r.ContentLength > 0 {
@@ -340,73 +945,71 @@ func (deps *HandlerDependencies) StartChatSessionHandler(w http.ResponseWriter,
 		sessionLanguage = data.GetValidatedLanguage(req.SessionLanguage)
 	}
 
-	// Create chat session
+	// Generate the greeting first so a failure leaves no session behind and the invitation unused
 	sessionID := data.GenerateID()
-	session := &data.ChatSession{
-		ID:              sessionID,
-		InterviewID:     interviewID,
-		SessionLanguage: sessionLanguage,
-		Status:          "active",
-		StartedAt:       time.Now(),
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
-	}
-	err = data.GlobalStore.CreateChatSession(session)
+	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to create chat session")
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create AI client")
 		return
 	}
-
-	// Create AI client for this request
-	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	aiResponse, err := aiClient.GenerateChatResponseWithOptions(sessionID, []map[string]string{}, "", chatOptionsForInterview(interview, sessionLanguage))
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to create AI client")
+		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response")
 		return
 	}
 
-	// Generate initial AI greeting message
-	aiResponse, err := aiClient.GenerateChatResponseWithLanguage(sessionID, []map[string]string{}, "", sessionLanguage)
+	// Only the candidate starting the session receives the token needed to pause and resume it
+	resumeToken, resumeTokenHash, err := newResumeToken()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response")
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create resume token")
 		return
 	}
 
-	// Create initial AI message
-	messageID := data.GenerateID()
-	aiMessage := &data.ChatMessage{
-		ID:        messageID,
+	// Create the chat session with its greeting; a candidate's invitation gives up one of its uses
+	// for each session started with it, in the same step
+	now := time.Now()
+	session := &data.ChatSession{
+		ID:              sessionID,
+		InterviewID:     interviewID,
+		SessionLanguage: sessionLanguage,
+		ResumeTokenHash: resumeTokenHash,
+		Status:          "active",
+		StartedAt:       now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	greeting := &data.ChatMessage{
+		ID:        data.GenerateID(),
 		SessionID: sessionID,
 		Type:      "ai",
-		Content:   aiResponse, Timestamp: time.Now(), CreatedAt: time.Now(),
+		Content:   aiResponse,
+		Timestamp: now,
+		CreatedAt: now,
 	}
-
-	err = data.GlobalStore.AddChatMessage(sessionID, aiMessage)
+	err = data.GlobalStore.CreateInterviewSession(session, requestInvitationID(r), greeting, newSessionWebhookEvent(data.WebhookEventSessionStarted, session))
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save AI message")
+		switch {
+		case errors.Is(err, data.ErrInterviewSessionActive):
+			writeJSONError(w, http.StatusConflict, "Interview already has an active chat session")
+		case errors.Is(err, data.ErrSessionLimitReached):
+			writeJSONError(w, http.StatusConflict, "Interview has no retakes remaining")
+		case errors.Is(err, data.ErrInvitationUnusable):
+			writeForbidden(w, r, "Invitation has no uses remaining", "")
+		default:
+			writeJSONError(w, http.StatusInternalServerError, "Failed to create chat session")
+		}
 		return
 	}
-
-	// Convert to DTO format
-	messages, _ := data.GlobalStore.GetChatMessages(sessionID)
-	messageDTOs := make([]ChatMessageDTO, len(messages))
-	for i, msg := range messages {
-		messageDTOs[i] = ChatMessageDTO{
-			ID:        msg.ID,
-			Type:      msg.Type,
-			Content:   msg.Content,
-			Timestamp: msg.Timestamp,
+	if interview.Status != data.InterviewStatusInProgress {
+		if _, err := data.GlobalStore.TransitionInterview(interviewID, data.InterviewStatusInProgress); err != nil {
+			utils.Warningf("Failed to move interview %s to in_progress: %v", interviewID, err)
 		}
 	}
 
-	response := ChatInterviewSessionDTO{
-		ID:              session.ID,
-		InterviewID:     session.InterviewID,
-		SessionLanguage: session.SessionLanguage,
-		Messages:        messageDTOs,
-		Status:          session.Status,
-		StartedAt:       session.StartedAt,
-		CreatedAt:       session.CreatedAt,
-	}
+	// Convert to DTO format; the resume token is only ever returned here and on resume
+	messages, _ := data.GlobalStore.GetChatMessages(sessionID)
+	response := newChatSessionDTO(session, messages)
+	response.ResumeToken = resumeToken
 
 	writeJSON(w, http.StatusCreated, response)
 }
@@ -449,6 +1052,13 @@ func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Get interview configuration for prompt and end policy
+	interview, err := data.GlobalStore.GetInterview(session.InterviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get interview details")
+		return
+	}
+
 	// Create user message
 	userMessageID := data.GenerateID()
 	userMessage := &data.ChatMessage{
@@ -486,7 +1096,7 @@ func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *ht
 		}
 	}
 
-	shouldEndInterview := aiClient.ShouldEndInterview(userMessageCount)
+	shouldEndInterview := shouldEndSession(aiClient, interview, session, userMessageCount)
 
 	// Build structured conversation history excluding the current user message
 	conversationHistory := make([]map[string]string, 0)
@@ -502,10 +1112,11 @@ func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *ht
 
 	// Generate AI response - use closing context if interview should end
 	var aiResponse string
+	chatOptions := chatOptionsForInterview(interview, session.SessionLanguage)
 	if shouldEndInterview {
-		aiResponse, err = aiClient.GenerateClosingMessageWithLanguage(sessionID, conversationHistory, req.Message, session.SessionLanguage)
+		aiResponse, err = aiClient.GenerateClosingMessageWithOptions(sessionID, conversationHistory, req.Message, chatOptions)
 	} else {
-		aiResponse, err = aiClient.GenerateChatResponseWithLanguage(sessionID, conversationHistory, req.Message, session.SessionLanguage)
+		aiResponse, err = aiClient.GenerateChatResponseWithOptions(sessionID, conversationHistory, req.Message, chatOptions)
 	}
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response")
@@ -528,13 +1139,13 @@ func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *ht
 	}
 
 	// Update session status if interview should end
+	sessionStatus := session.Status
+	evaluationJobID := ""
 	if shouldEndInterview {
-		session.Status = "completed"
-		session.UpdatedAt = time.Now()
-		endedAt := time.Now()
-		session.EndedAt = &endedAt
-		if err := data.GlobalStore.UpdateChatSession(session); err != nil {
-			utils.Errorf("Failed to update chat session: %v", err)
+		if ended, job, err := deps.completeChatSession(session); err != nil {
+			utils.Errorf("Failed to complete chat session: %v", err)
+		} else {
+			sessionStatus, evaluationJobID = ended.Status, job.ID
 		}
 	}
 
@@ -553,15 +1164,17 @@ func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *ht
 		Timestamp: aiMessage.Timestamp,
 	}
 	response := SendMessageResponseDTO{
-		Message:       userMessageDTO,
-		AIResponse:    &aiMessageDTO,
-		SessionStatus: session.Status,
+		Message:         userMessageDTO,
+		AIResponse:      &aiMessageDTO,
+		SessionStatus:   sessionStatus,
+		EvaluationJobID: evaluationJobID,
 	}
 
 	writeJSON(w, http.StatusOK, response)
 }
 
 // GetChatSessionHandler handles GET /chat/{sessionId}
+// Superseded message revisions are listed too when include_revisions=true.
 func GetChatSessionHandler(w http.ResponseWriter, r *http.Request) {
 	sessionID := chi.URLParam(r, "sessionId")
 	if sessionID == "" {
@@ -575,77 +1188,190 @@ func GetChatSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Superseded revisions are hidden unless requested for auditing
+	includeRevisions := false
+	if str := r.URL.Query().Get("include_revisions"); str != "" {
+		parsed, err := strconv.ParseBool(str)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid include_revisions: must be true or false")
+			return
+		}
+		includeRevisions = parsed
+	}
+
 	// Get all messages for the session
-	messages, err := data.GlobalStore.GetChatMessages(sessionID)
+	var messages []*data.ChatMessage
+	if includeRevisions {
+		messages, err = data.GlobalStore.GetChatMessageHistory(sessionID)
+	} else {
+		messages, err = data.GlobalStore.GetChatMessages(sessionID)
+	}
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
 		return
 	}
 
-	// Convert to DTO format
-	messageDTOs := make([]ChatMessageDTO, len(messages))
-	for i, msg := range messages {
-		messageDTOs[i] = ChatMessageDTO{
-			ID:        msg.ID,
-			Type:      msg.Type,
-			Content:   msg.Content,
-			Timestamp: msg.Timestamp,
-		}
-	}
-	response := ChatInterviewSessionDTO{
-		ID:              session.ID,
-		InterviewID:     session.InterviewID,
-		SessionLanguage: session.SessionLanguage,
-		Messages:        messageDTOs,
-		Status:          session.Status,
-		StartedAt:       session.StartedAt,
-		CreatedAt:       session.CreatedAt,
-	}
-
-	writeJSON(w, http.StatusOK, response)
+	writeJSON(w, http.StatusOK, newChatSessionDTO(session, messages))
 }
 
-// EndChatSessionHandler handles POST /chat/{sessionId}/end
-func (deps *HandlerDependencies) EndChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+// DeleteChatSessionHandler handles DELETE /chat/{sessionId}
+// Removes a finished or abandoned session and its transcript; evaluations of it are kept.
+func DeleteChatSessionHandler(w http.ResponseWriter, r *http.Request) {
 	sessionID := chi.URLParam(r, "sessionId")
 	if sessionID == "" {
 		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
 		return
 	}
-
-	// Get chat session
-	session, err := data.GlobalStore.GetChatSession(sessionID)
-	if err != nil {
+	if _, err := data.GlobalStore.GetChatSession(sessionID); err != nil {
 		writeJSONError(w, http.StatusNotFound, "Chat session not found")
 		return
 	}
 
-	// Mark session as completed
-	session.Status = "completed"
-	session.UpdatedAt = time.Now()
-	endedAt := time.Now()
-	session.EndedAt = &endedAt
+	if err := data.GlobalStore.DeleteChatSession(sessionID); err != nil {
+		if errors.Is(err, data.ErrChatSessionActive) {
+			writeJSONError(w, http.StatusConflict, "Chat session is still active; end it before deleting")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to delete chat session", err.Error())
+		return
+	}
 
-	err = data.GlobalStore.UpdateChatSession(session)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListInterviewSessionsHandler handles GET /interviews/{id}/sessions
+// Lists every chat session of an interview, oldest first, with how many more may be started.
+func ListInterviewSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	interviewID := chi.URLParam(r, "id")
+	if interviewID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrMsgMissingInterviewID)
+		return
+	}
+	interview, err := data.GlobalStore.GetInterview(interviewID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to update session")
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
 		return
 	}
 
-	// Get all messages for evaluation
-	messages, err := data.GlobalStore.GetChatMessages(sessionID)
+	sessions, err := data.GlobalStore.ListChatSessions(interviewID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch chat sessions", err.Error())
 		return
 	}
-
-	// Get interview details for context
-	interview, err := data.GlobalStore.GetInterview(session.InterviewID)
+	evaluations, err := data.GlobalStore.ListEvaluations(data.ListEvaluationsOptions{
+		Limit:     maxSessionEvaluations,
+		Filters:   data.EvaluationFilters{InterviewID: interviewID},
+		SortBy:    data.EvaluationSortDate,
+		SortOrder: data.SortOrderDesc,
+	})
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to get interview details")
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch evaluations", err.Error())
 		return
 	}
+	// Newest first, so the first evaluation seen for a session is its latest
+	sessionEvaluations := make(map[string]string)
+	for _, evaluation := range evaluations.Evaluations {
+		sessionID := stringValue(evaluation.SessionID)
+		if _, seen := sessionEvaluations[sessionID]; sessionID != "" && !seen {
+			sessionEvaluations[sessionID] = evaluation.ID
+		}
+	}
+
+	resp := ListInterviewSessionsResponseDTO{
+		InterviewID: interviewID,
+		Sessions:    make([]InterviewSessionDTO, len(sessions)),
+		MaxSessions: interview.MaxSessions(),
+	}
+	for i, session := range sessions {
+		resp.Sessions[i] = InterviewSessionDTO{
+			ID:              session.ID,
+			Attempt:         i + 1,
+			SessionLanguage: session.SessionLanguage,
+			Status:          session.Status,
+			StartedAt:       session.StartedAt,
+			EndedAt:         session.EndedAt,
+			EvaluationID:    sessionEvaluations[session.ID],
+		}
+	}
+	if remaining := resp.MaxSessions - len(sessions); remaining > 0 {
+		resp.RemainingSessions = remaining
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Helper: evaluate answers submitted for an interview's questions, keyed "question_<index>",
+// and store the result as the outcome of the job's current attempt
+func (deps *HandlerDependencies) evaluateAnswers(ctx context.Context, job *data.Job, interview *data.Interview, submitted map[string]string) (*data.Evaluation, error) {
+	// Convert answers map to arrays for AI evaluation
+	questions := resolveInterviewQuestions(interview)
+	answers := make([]string, len(questions))
+
+	// Map answers from the request to the questions order
+	for i := range questions {
+		answerKey := fmt.Sprintf("question_%d", i)
+		if answer, exists := submitted[answerKey]; exists {
+			answers[i] = answer
+		} else {
+			answers[i] = "" // Empty answer if not provided
+		}
+	}
+	// Generate AI evaluation using the same method as chat evaluation,
+	// in the interview language
+	evalOptions := evaluationOptionsForInterview(interview, interview.InterviewLanguage)
+
+	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AI client: %w", err)
+	}
+
+	result, err := aiClient.EvaluateContext(ctx, questions, answers, evalOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate evaluation: %w", err)
+	}
+
+	// Create evaluation record
+	evaluation := &data.Evaluation{
+		ID:             data.GenerateID(),
+		InterviewID:    interview.ID,
+		JobID:          job.ID,
+		Answers:        submitted,
+		Score:          result.OverallScore,
+		Feedback:       result.Feedback,
+		Strengths:      result.Strengths,
+		Weaknesses:     result.Weaknesses,
+		CategoryScores: result.CategoryScores,
+		Rubric:         interview.Rubric,
+		ScoringMethod:  result.ScoringMethod,
+		ModelResults:   modelResultsFromAI(result.ModelResults),
+		Disagreement:   result.Disagreement,
+		NeedsReview:    result.NeedsReview,
+		Consistency:    consistencyFromAI(result.Consistency),
+		LowConfidence:  result.LowConfidence,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := saveJobEvaluation(job, evaluation); err != nil {
+		return nil, err
+	}
+	return evaluation, nil
+}
+
+// Helper: store an evaluation produced by a job's current attempt along with its webhook event.
+// An attempt that timed out and was superseded stores nothing, so a rerun never duplicates it.
+func saveJobEvaluation(job *data.Job, evaluation *data.Evaluation) error {
+	err := data.GlobalStore.CreateJobEvaluation(evaluation, job.Attempts, newWebhookEvent(data.WebhookEventEvaluationReady, newEvaluationResponseDTO(evaluation)))
+	if err != nil {
+		return fmt.Errorf("failed to save evaluation: %w", err)
+	}
+	return nil
+}
 
+// Helper: evaluate a chat session's transcript and store the result as the outcome of the job's
+// current attempt. Partial evaluations come from sessions that ended without the candidate
+// finishing, e.g. abandoned ones.
+func (deps *HandlerDependencies) evaluateChatSession(ctx context.Context, job *data.Job, session *data.ChatSession, interview *data.Interview, messages []*data.ChatMessage, partial bool) (*data.Evaluation, error) {
 	// Convert chat messages to evaluation format
 	answers := make(map[string]string)
 	questions := make([]string, 0)
@@ -661,52 +1387,94 @@ func (deps *HandlerDependencies) EndChatSessionHandler(w http.ResponseWriter, r
 			answers[fmt.Sprintf("question_%d", questionIndex)] = msg.Content
 		}
 	}
-	// Generate evaluation using AI service with interview context
-	jobDesc := interview.JobDescription
-	if jobDesc == "" {
-		jobDesc = fmt.Sprintf("General %s interview", interview.InterviewType)
-	}
-	sessionLanguage := session.SessionLanguage // Use session language for evaluation
+	// Generate evaluation using AI service with interview context, in the session language
+	evalOptions := evaluationOptionsForInterview(interview, session.SessionLanguage)
 
 	// Create AI client for this request
 	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to create AI client")
-		return
+		return nil, fmt.Errorf("failed to create AI client: %w", err)
 	}
 
-	score, feedback, err := aiClient.EvaluateAnswersWithContext(questions, userAnswers, jobDesc, sessionLanguage)
+	result, err := aiClient.EvaluateContext(ctx, questions, userAnswers, evalOptions)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate evaluation")
-		return
+		return nil, fmt.Errorf("failed to generate evaluation: %w", err)
 	}
 
 	// Create evaluation record
-	evaluationID := data.GenerateID()
+	sessionID := session.ID
 	evaluation := &data.Evaluation{
-		ID:          evaluationID,
-		InterviewID: session.InterviewID, Answers: answers,
-		Score:     score,
-		Feedback:  feedback,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:             data.GenerateID(),
+		InterviewID:    session.InterviewID,
+		SessionID:      &sessionID,
+		Partial:        partial,
+		JobID:          job.ID,
+		Answers:        answers,
+		Score:          result.OverallScore,
+		Feedback:       result.Feedback,
+		Strengths:      result.Strengths,
+		Weaknesses:     result.Weaknesses,
+		CategoryScores: result.CategoryScores,
+		Rubric:         interview.Rubric,
+		ScoringMethod:  result.ScoringMethod,
+		ModelResults:   modelResultsFromAI(result.ModelResults),
+		Disagreement:   result.Disagreement,
+		NeedsReview:    result.NeedsReview,
+		Consistency:    consistencyFromAI(result.Consistency),
+		LowConfidence:  result.LowConfidence,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := saveJobEvaluation(job, evaluation); err != nil {
+		return nil, err
+	}
+	return evaluation, nil
+}
+
+// EndChatSessionHandler handles POST /chat/{sessionId}/end
+// Ends an active or paused session and responds 202 with an evaluation job to poll at GET /jobs/{id};
+// a session that already ended is a 409, so it is evaluated only once.
+func (deps *HandlerDependencies) EndChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
+		return
 	}
 
-	err = data.GlobalStore.CreateEvaluation(evaluation)
+	// Get chat session
+	session, err := data.GlobalStore.GetChatSession(sessionID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save evaluation")
+		writeJSONError(w, http.StatusNotFound, "Chat session not found")
 		return
 	}
 
-	// Convert to DTO format
-	response := EvaluationResponseDTO{
-		ID:          evaluation.ID,
-		InterviewID: evaluation.InterviewID,
-		Answers:     evaluation.Answers,
-		Score:       evaluation.Score,
-		Feedback:    evaluation.Feedback,
-		CreatedAt:   evaluation.CreatedAt,
+	_, job, err := deps.completeChatSession(session)
+	if errors.Is(err, data.ErrChatSessionEnded) {
+		writeJSONError(w, http.StatusConflict, "Chat session has already ended")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update session", err.Error())
+		return
 	}
+	writeJobAccepted(w, job)
+}
 
-	writeJSON(w, http.StatusOK, response)
+// Helper: mark an open chat session completed and queue the evaluation of its transcript in the
+// same store call, so a completed session is never left unevaluated. Evaluating a long transcript
+// can outlast the request, so it runs as a background job. Returns data.ErrChatSessionEnded when
+// the session already ended.
+func (deps *HandlerDependencies) completeChatSession(session *data.ChatSession) (*data.ChatSession, *data.Job, error) {
+	now := time.Now()
+	ended := *session
+	ended.Status = "completed"
+	ended.EndedAt = &now
+	ended.UpdatedAt = now
+	job := deps.newJob(data.JobTypeSessionEvaluation, sessionEvaluationPayload(session.ID, false))
+	if err := data.GlobalStore.CompleteChatSession(session.ID, now, job, newSessionWebhookEvent(data.WebhookEventSessionCompleted, &ended)); err != nil {
+		return nil, nil, err
+	}
+	completeInterview(session.InterviewID)
+	return &ended, job, nil
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -23,6 +24,7 @@ func setupTestRouter() http.Handler {
 		OpenAIAPIKey:    "test-openai-key",
 		GeminiAPIKey:    "test-gemini-key",
 		ShutdownTimeout: 30 * time.Second,
+		AuthDisabled:    true,
 	}
 	return SetupRouter(testConfig)
 }
@@ -111,6 +113,67 @@ func expectHTTPError(t *testing.T, router http.Handler, method, path string, bod
 	}
 }
 
+// doJSONRequest sends body as JSON, fails unless the status matches and decodes the response into out when set
+func doJSONRequest(t *testing.T, router http.Handler, method, path string, body interface{}, expectedStatus int, out interface{}) {
+	t.Helper()
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != expectedStatus {
+		t.Fatalf("%s %s: expected %d, got %d: %s", method, path, expectedStatus, w.Code, w.Body.String())
+	}
+	if out != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+	}
+}
+
+// runQueuedJobs runs every due background job until the queue is drained, as the worker pool would
+func runQueuedJobs() {
+	cfg := &config.Config{
+		OpenAIAPIKey:   "test-openai-key",
+		GeminiAPIKey:   "test-gemini-key",
+		JobMaxAttempts: 1,
+	}
+	pool := NewJobWorkerPool(cfg, NewHandlerDependenciesFromConfig(cfg))
+	for pool.runNext(time.Now()) {
+	}
+}
+
+// awaitEvaluation checks that a request queued an evaluation job, runs it and returns the evaluation
+func awaitEvaluation(t *testing.T, router http.Handler, w *httptest.ResponseRecorder) EvaluationResponseDTO {
+	t.Helper()
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 Accepted, got %d: %s", w.Code, w.Body.String())
+	}
+	var job JobResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil {
+		t.Fatalf("failed to unmarshal job: %v", err)
+	}
+	runQueuedJobs()
+
+	req := httptest.NewRequest("GET", "/jobs/"+job.ID, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if err := json.Unmarshal(w.Body.Bytes(), &job); err != nil || job.Status != data.JobStatusSucceeded {
+		t.Fatalf("expected succeeded job, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/evaluation/"+job.EvaluationID, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("failed to get evaluation %s, got %d: %s", job.EvaluationID, w.Code, w.Body.String())
+	}
+	return evaluation
+}
+
 // create interview and start chat session for tests
 func createTestInterviewAndSession(t *testing.T, router http.Handler) struct {
 	InterviewID string
@@ -507,8 +570,9 @@ func TestSubmitEvaluationHandler_Success(t *testing.T) {
 	req := httptest.NewRequest("POST", "/evaluation", bytes.NewReader(b))
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-	if w.Code != http.StatusOK {
-		t.Errorf("expected 200 OK, got %d", w.Code)
+	evaluation := awaitEvaluation(t, router, w)
+	if evaluation.InterviewID != interview.ID || evaluation.Answers["question_1"] != "I am a developer" {
+		t.Errorf("unexpected evaluation: %+v", evaluation)
 	}
 }
 
@@ -539,8 +603,8 @@ func TestGetEvaluationHandler_BadRequest(t *testing.T) {
 	req := httptest.NewRequest("GET", "/evaluation/", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-	if w.Code != http.StatusMethodNotAllowed {
-		t.Errorf("expected 405 Method Not Allowed, got %d", w.Code)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 OK from the evaluation list, got %d", w.Code)
 	}
 }
 
@@ -734,16 +798,7 @@ func TestEndChatSessionHandler_Success(t *testing.T) {
 	req := httptest.NewRequest("POST", "/chat/"+interview.SessionID+"/end", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("expected 200 OK, got %d", w.Code)
-		return
-	}
-
-	var response EvaluationResponseDTO
-	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
-		t.Fatalf("failed to unmarshal evaluation response: %v", err)
-	}
+	response := awaitEvaluation(t, router, w)
 
 	if response.Score <= 0 {
 		t.Errorf("expected score > 0, got %f", response.Score)
@@ -756,6 +811,28 @@ func TestEndChatSessionHandler_Success(t *testing.T) {
 	}
 }
 
+func TestEndChatSessionHandler_AlreadyEnded(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	ended := createTestInterviewAndSession(t, router)
+	expectHTTPError(t, router, "POST", "/chat/"+ended.SessionID+"/end", nil, http.StatusAccepted)
+	expectHTTPError(t, router, "POST", "/chat/"+ended.SessionID+"/end", nil, http.StatusConflict)
+
+	// Sessions abandoned by the sweeper cannot be completed afterwards either
+	abandoned := createTestInterviewAndSession(t, router)
+	if _, err := data.GlobalStore.AbandonInactiveChatSessions(time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("failed to abandon sessions: %v", err)
+	}
+	expectHTTPError(t, router, "POST", "/chat/"+abandoned.SessionID+"/end", nil, http.StatusConflict)
+
+	// Only the first end queued an evaluation
+	jobs, _ := data.GlobalStore.ListJobs(data.JobFilters{Type: data.JobTypeSessionEvaluation}, 10)
+	if len(jobs) != 1 || jobs[0].Payload["session_id"] != ended.SessionID {
+		t.Errorf("expected one evaluation job for the ended session, got %+v", jobs)
+	}
+}
+
 func TestEndChatSessionHandler_NotFound(t *testing.T) {
 	clearMemoryStore()
 	router := setupTestRouter()
@@ -792,3 +869,388 @@ func TestParseIntQuery_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestSubmitEvaluationHandler_WeightedRubric(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	// Invalid rubrics are rejected
+	invalid := CreateInterviewRequestDTO{
+		CandidateName: "Rubric Candidate",
+		Questions:     []string{"Design a cache"},
+		InterviewType: "technical",
+		Rubric:        &RubricDTO{Criteria: []RubricCriterionDTO{{Key: "design", Weight: 0}}},
+	}
+	b, _ := json.Marshal(invalid)
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusBadRequest)
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Rubric Candidate",
+		Questions:     []string{"Design a cache"},
+		InterviewType: "technical",
+		Rubric: &RubricDTO{Criteria: []RubricCriterionDTO{
+			{Key: "design", Weight: 3, Anchors: []RubricAnchorDTO{{Score: 1, Description: "Covers eviction and consistency"}}},
+			{Key: "communication", Weight: 1},
+		}},
+	})
+	if interview.Rubric == nil || len(interview.Rubric.Criteria) != 2 {
+		t.Fatalf("expected rubric with 2 criteria on interview, got %+v", interview.Rubric)
+	}
+
+	b, _ = json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "An LRU cache with write-through"},
+	})
+	req := httptest.NewRequest("POST", "/evaluation", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	resp := awaitEvaluation(t, router, w)
+	if resp.ScoringMethod != "weighted_rubric" {
+		t.Errorf("expected weighted_rubric scoring, got %q", resp.ScoringMethod)
+	}
+	// Mock provider scores design 0.9 and communication 0.8: (3*0.9 + 1*0.8) / 4
+	if math.Abs(resp.Score-0.875) > 1e-9 {
+		t.Errorf("expected weighted score 0.875, got %v", resp.Score)
+	}
+	if len(resp.CategoryScores) != 2 || resp.Rubric == nil {
+		t.Errorf("expected category scores and rubric snapshot, got %+v", resp)
+	}
+}
+
+func TestSubmitEvaluationHandler_Ensemble(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	invalid := CreateInterviewRequestDTO{
+		CandidateName:      "Ensemble Candidate",
+		Questions:          []string{"Design a cache"},
+		InterviewType:      "technical",
+		EvaluationEnsemble: &EnsembleDTO{Models: []string{"mock/mock-a"}},
+	}
+	b, _ := json.Marshal(invalid)
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusBadRequest)
+
+	// An ensemble without models is rejected rather than treated as a single model
+	for _, body := range []string{`{}`, `{"aggregation":"mean"}`} {
+		b = []byte(`{"candidate_name":"Ensemble Candidate","questions":["Design a cache"],"interview_type":"technical","evaluation_ensemble":` + body + `}`)
+		expectHTTPError(t, router, "POST", "/interviews", b, http.StatusBadRequest)
+		b = []byte(`{"name":"Ensemble Template","questions":["Design a cache"],"interview_type":"technical","evaluation_ensemble":` + body + `}`)
+		expectHTTPError(t, router, "POST", "/templates", b, http.StatusBadRequest)
+	}
+
+	invalid.EvaluationEnsemble = &EnsembleDTO{Models: []string{"mock/mock-a", "mock/mock-b"}, Aggregation: "median"}
+	interview := createTestInterview(t, router, invalid)
+
+	b, _ = json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "An LRU cache"},
+	})
+	req := httptest.NewRequest("POST", "/evaluation", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	resp := awaitEvaluation(t, router, w)
+	if len(resp.ModelResults) != 2 || resp.ModelResults[0].Model != "mock-a" || resp.ModelResults[1].Model != "mock-b" {
+		t.Errorf("expected per-model results for mock-a and mock-b, got %+v", resp.ModelResults)
+	}
+	// Identical mock models agree, so no human review is needed
+	if resp.NeedsReview || resp.Disagreement != 0 {
+		t.Errorf("expected no disagreement, got %v %v", resp.NeedsReview, resp.Disagreement)
+	}
+}
+
+func TestSubmitEvaluationHandler_ConsistencySampling(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	req := CreateInterviewRequestDTO{
+		CandidateName:      "Sampling Candidate",
+		Questions:          []string{"Describe a past project"},
+		InterviewType:      "behavioral",
+		EvaluationSampling: &SamplingDTO{Samples: 1},
+	}
+	b, _ := json.Marshal(req)
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusBadRequest)
+
+	req.EvaluationSampling = &SamplingDTO{Samples: 3, Temperature: 0.5}
+	interview := createTestInterview(t, router, req)
+	if interview.EvaluationSampling == nil || interview.EvaluationSampling.Samples != 3 {
+		t.Fatalf("expected sampling config on interview, got %+v", interview.EvaluationSampling)
+	}
+
+	b, _ = json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "I led a migration to Postgres"},
+	})
+	httpReq := httptest.NewRequest("POST", "/evaluation", bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+	resp := awaitEvaluation(t, router, w)
+	if resp.Consistency == nil || resp.Consistency.Samples != 3 || len(resp.Consistency.Scores) != 3 {
+		t.Fatalf("expected consistency stats for 3 samples, got %+v", resp.Consistency)
+	}
+	// The mock provider is deterministic, so the spread is zero
+	if resp.LowConfidence || resp.Consistency.StdDev > 1e-9 || math.Abs(resp.Score-resp.Consistency.Mean) > 1e-9 {
+		t.Errorf("expected confident result with score equal to the mean, got %+v", resp)
+	}
+}
+
+func TestListEvaluationsHandler_FilterSortAndStats(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	base := time.Now().Add(-time.Hour)
+	for i, score := range []float64{0.9, 0.4, 0.7} {
+		evaluation := &data.Evaluation{
+			ID:          fmt.Sprintf("list-eval-%d", i),
+			InterviewID: "list-interview",
+			Score:       score,
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+		}
+		if err := data.GlobalStore.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("failed to create evaluation: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/evaluation?sort_by=score&sort_order=desc&limit=2&page=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var list ListEvaluationsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal list: %v", err)
+	}
+	if list.Total != 3 || list.Page != 2 || list.TotalPages != 2 || len(list.Evaluations) != 1 || list.Evaluations[0].Score != 0.4 {
+		t.Errorf("unexpected second page: %+v", list)
+	}
+
+	req = httptest.NewRequest("GET", "/evaluation/stats?min_score=0.5", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var stats EvaluationStatsDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if stats.TotalEvaluations != 2 || math.Abs(stats.AverageScore-0.8) > 1e-9 || stats.ScoreDistribution["70-79"] != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	for _, query := range []string{"sort_by=name", "min_score=2", "min_score=0.8&max_score=0.2", "decision=maybe", "needs_review=perhaps", "date_from=yesterday"} {
+		expectHTTPError(t, router, "GET", "/evaluation?"+query, nil, http.StatusBadRequest)
+	}
+	expectHTTPError(t, router, "GET", "/evaluation/stats?date_to=01-02-2026", nil, http.StatusBadRequest)
+}
+
+func TestUpdateAndDeleteInterviewHandlers(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Original Name",
+		Questions:     []string{"Q1"},
+		InterviewType: "general",
+	})
+	update := CreateInterviewRequestDTO{
+		CandidateName:  "Updated Name",
+		Questions:      []string{"Q1", "Q2"},
+		InterviewType:  "technical",
+		JobDescription: "Platform Engineer",
+	}
+	b, _ := json.Marshal(update)
+
+	// Edits are rejected while a session is active
+	session := startChatSession(t, router, interview.ID, nil)
+	expectHTTPError(t, router, "PUT", "/interviews/"+interview.ID, b, http.StatusConflict)
+
+	req := httptest.NewRequest("POST", "/chat/"+session.ID+"/end", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("failed to end session: %d", w.Code)
+	}
+
+	req = httptest.NewRequest("PUT", "/interviews/"+interview.ID, bytes.NewReader(b))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var updated InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("failed to unmarshal interview: %v", err)
+	}
+	if updated.CandidateName != "Updated Name" || len(updated.Questions) != 2 || updated.InterviewType != "technical" || updated.ID != interview.ID {
+		t.Errorf("unexpected updated interview: %+v", updated)
+	}
+
+	invalid, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "X", Questions: []string{"Q"}, InterviewType: "unknown"})
+	expectHTTPError(t, router, "PUT", "/interviews/"+interview.ID, invalid, http.StatusBadRequest)
+	expectHTTPError(t, router, "PUT", "/interviews/missing", b, http.StatusNotFound)
+
+	// The ended session and its evaluation block a plain delete
+	expectHTTPError(t, router, "DELETE", "/interviews/"+interview.ID, nil, http.StatusConflict)
+	expectHTTPError(t, router, "DELETE", "/interviews/"+interview.ID+"?cascade=maybe", nil, http.StatusBadRequest)
+
+	req = httptest.NewRequest("DELETE", "/interviews/"+interview.ID+"?cascade=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 No Content, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := data.GlobalStore.GetChatSession(session.ID); err == nil {
+		t.Error("expected chat session to be deleted with the interview")
+	}
+	if evaluations, _ := data.GlobalStore.GetLatestEvaluations([]string{interview.ID}); len(evaluations) != 0 {
+		t.Error("expected evaluation to be deleted with the interview")
+	}
+	expectHTTPError(t, router, "GET", "/interviews/"+interview.ID, nil, http.StatusNotFound)
+	expectHTTPError(t, router, "DELETE", "/interviews/"+interview.ID, nil, http.StatusNotFound)
+}
+
+func TestInterviewLifecycleTransitions(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Lifecycle Candidate",
+		Questions:     []string{"Q1"},
+		InterviewType: "general",
+	})
+	if interview.Status != data.InterviewStatusDraft || len(interview.AllowedTransitions) == 0 {
+		t.Fatalf("expected new interview to be a draft with transitions, got %+v", interview)
+	}
+
+	transition := func(status string, expected int) InterviewResponseDTO {
+		t.Helper()
+		b, _ := json.Marshal(TransitionInterviewRequestDTO{Status: status})
+		req := httptest.NewRequest("POST", "/interviews/"+interview.ID+"/transition", bytes.NewReader(b))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Fatalf("transition to %s: expected %d, got %d: %s", status, expected, w.Code, w.Body.String())
+		}
+		var resp InterviewResponseDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	if resp := transition(data.InterviewStatusScheduled, http.StatusOK); resp.Status != data.InterviewStatusScheduled {
+		t.Errorf("expected scheduled, got %s", resp.Status)
+	}
+	transition(data.InterviewStatusArchived, http.StatusConflict)
+	transition("active", http.StatusBadRequest)
+
+	// Starting and ending a chat session move the interview automatically
+	session := startChatSession(t, router, interview.ID, nil)
+	stored, _ := data.GlobalStore.GetInterview(interview.ID)
+	if stored.Status != data.InterviewStatusInProgress {
+		t.Errorf("expected in_progress after chat start, got %s", stored.Status)
+	}
+	// The interview cannot be closed out from under an open session
+	transition(data.InterviewStatusCancelled, http.StatusConflict)
+	transition(data.InterviewStatusCompleted, http.StatusConflict)
+	req := httptest.NewRequest("POST", "/chat/"+session.ID+"/end", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	stored, _ = data.GlobalStore.GetInterview(interview.ID)
+	if stored.Status != data.InterviewStatusCompleted {
+		t.Errorf("expected completed after chat end, got %s", stored.Status)
+	}
+
+	// Without retakes a completed interview cannot start another session, and archiving is final
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, http.StatusConflict)
+	if resp := transition(data.InterviewStatusArchived, http.StatusOK); len(resp.AllowedTransitions) != 0 {
+		t.Errorf("expected no transitions from archived, got %v", resp.AllowedTransitions)
+	}
+
+	// Status filter now reflects real lifecycle values
+	req = httptest.NewRequest("GET", "/interviews?status=archived", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var list ListInterviewsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 1 {
+		t.Errorf("expected 1 archived interview, got %d", list.Total)
+	}
+}
+
+func TestInterviewSessionsAndRetakes(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	maxRetakes := 1
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Retake Candidate",
+		Questions:     []string{"Q1"},
+		InterviewType: "general",
+		MaxRetakes:    &maxRetakes,
+	})
+	if interview.MaxRetakes != 1 {
+		t.Fatalf("expected max_retakes 1, got %d", interview.MaxRetakes)
+	}
+
+	listSessions := func() ListInterviewSessionsResponseDTO {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/interviews/"+interview.ID+"/sessions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 listing sessions, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp ListInterviewSessionsResponseDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+	endSession := func(sessionID string) EvaluationResponseDTO {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/chat/"+sessionID+"/end", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return awaitEvaluation(t, router, w)
+	}
+
+	if resp := listSessions(); len(resp.Sessions) != 0 || resp.MaxSessions != 2 || resp.RemainingSessions != 2 {
+		t.Fatalf("expected no sessions and 2 remaining, got %+v", resp)
+	}
+
+	// Only one session may be active at a time
+	first := startChatSession(t, router, interview.ID, nil)
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, http.StatusConflict)
+
+	// Evaluations record the session they came from
+	evaluation := endSession(first.ID)
+	if evaluation.SessionID != first.ID {
+		t.Errorf("expected evaluation session_id %s, got %q", first.ID, evaluation.SessionID)
+	}
+
+	// A completed interview can be retaken until the limit is used up
+	second := startChatSession(t, router, interview.ID, nil)
+	stored, _ := data.GlobalStore.GetInterview(interview.ID)
+	if stored.Status != data.InterviewStatusInProgress {
+		t.Errorf("expected in_progress during retake, got %s", stored.Status)
+	}
+	endSession(second.ID)
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, http.StatusConflict)
+
+	resp := listSessions()
+	if len(resp.Sessions) != 2 || resp.RemainingSessions != 0 {
+		t.Fatalf("expected 2 sessions and none remaining, got %+v", resp)
+	}
+	if resp.Sessions[0].ID != first.ID || resp.Sessions[0].Attempt != 1 || resp.Sessions[1].Attempt != 2 {
+		t.Errorf("expected sessions oldest first, got %+v", resp.Sessions)
+	}
+	if resp.Sessions[0].EvaluationID != evaluation.ID || resp.Sessions[0].Status != "completed" {
+		t.Errorf("expected first session linked to evaluation %s, got %+v", evaluation.ID, resp.Sessions[0])
+	}
+
+	// Retake limits are validated
+	invalid := -1
+	b, _ := json.Marshal(CreateInterviewRequestDTO{
+		CandidateName: "Invalid Retakes",
+		Questions:     []string{"Q1"},
+		InterviewType: "general",
+		MaxRetakes:    &invalid,
+	})
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusBadRequest)
+	expectHTTPError(t, router, "GET", "/interviews/missing/sessions", nil, http.StatusNotFound)
+}
@@ -0,0 +1,238 @@
+// HTTP handler functions for candidate invitation links
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Invitation lifetime and use limits
+const (
+	defaultInvitationTTL = 7 * 24 * time.Hour
+	maxInvitationHours   = 90 * 24
+	maxInvitationUses    = maxInterviewRetakes + 1 // Every session an interview can have
+)
+
+// invitationClaims is the signed payload of an invitation token. The expiry is checked before the
+// invitation is looked up, so expired or forged tokens never reach the store.
+type invitationClaims struct {
+	InvitationID string `json:"inv"`
+	InterviewID  string `json:"itv"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// Helper: HMAC-SHA256 of an encoded token payload
+func invitationSignature(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// Helper: sign an invitation, producing "<base64url claims>.<base64url signature>"
+func signInvitationToken(secret []byte, invitation *data.Invitation) (string, error) {
+	claims, err := json.Marshal(invitationClaims{
+		InvitationID: invitation.ID,
+		InterviewID:  invitation.InterviewID,
+		ExpiresAt:    invitation.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(invitationSignature(secret, payload)), nil
+}
+
+// Helper: check an invitation token's signature and expiry and load its invitation, returning an
+// error message if the token does not grant access
+func (deps *HandlerDependencies) verifyInvitationToken(token string) (*data.Invitation, string) {
+	if len(deps.InvitationSecret) == 0 {
+		return nil, "Invitations are not enabled"
+	}
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, "Invalid invitation token"
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(decoded, invitationSignature(deps.InvitationSecret, payload)) {
+		return nil, "Invalid invitation token"
+	}
+	var claims invitationClaims
+	if raw, err := base64.RawURLEncoding.DecodeString(payload); err != nil || json.Unmarshal(raw, &claims) != nil {
+		return nil, "Invalid invitation token"
+	}
+	now := time.Now()
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, "Invitation has expired"
+	}
+
+	invitation, err := data.GlobalStore.GetInvitation(claims.InvitationID)
+	if err != nil || invitation.InterviewID != claims.InterviewID {
+		return nil, "Invalid invitation token"
+	}
+	if invitation.RevokedAt != nil {
+		return nil, "Invitation has been revoked"
+	}
+	if !now.Before(invitation.ExpiresAt) {
+		return nil, "Invitation has expired"
+	}
+	return invitation, ""
+}
+
+// Helper: convert an invitation to its response DTO
+func newInvitationResponseDTO(invitation *data.Invitation) InvitationResponseDTO {
+	return InvitationResponseDTO{
+		ID:          invitation.ID,
+		InterviewID: invitation.InterviewID,
+		CandidateID: stringValue(invitation.CandidateID),
+		ExpiresAt:   invitation.ExpiresAt,
+		MaxUses:     invitation.MaxUses,
+		Uses:        invitation.Uses,
+		LastUsedAt:  invitation.LastUsedAt,
+		RevokedAt:   invitation.RevokedAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+// Helper: the candidate-facing link for an invitation token, or "" without a configured base URL
+func (deps *HandlerDependencies) invitationLink(interviewID, token string) string {
+	if deps.InvitationBaseURL == "" {
+		return ""
+	}
+	query := url.Values{"interview_id": {interviewID}, "token": {token}}
+	separator := "?"
+	if strings.Contains(deps.InvitationBaseURL, "?") {
+		separator = "&"
+	}
+	return deps.InvitationBaseURL + separator + query.Encode()
+}
+
+// CreateInvitationHandler handles POST /interviews/{id}/invitations
+// Issues a signed link that lets the interview's candidate start and take its chat sessions. The
+// token is only returned here; the candidate sends it in the X-Invitation-Token header.
+func (deps *HandlerDependencies) CreateInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	interviewID := chi.URLParam(r, "id")
+	if interviewID == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingInterviewID)
+		return
+	}
+	if len(deps.InvitationSecret) == 0 {
+		writeJSONError(w, http.StatusServiceUnavailable, "Invitations are not enabled: INVITATION_SECRET is not set")
+		return
+	}
+
+	var req CreateInvitationRequestDTO
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+			return
+		}
+	}
+	if req.ExpiresInHours < 0 || req.ExpiresInHours > maxInvitationHours {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("expires_in_hours must be between 1 and %d, or 0 for the default", maxInvitationHours))
+		return
+	}
+	if req.MaxUses < 0 || req.MaxUses > maxInvitationUses {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("max_uses must be between 1 and %d, or 0 for the default", maxInvitationUses))
+		return
+	}
+
+	interview, err := data.GlobalStore.GetInterview(interviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+	if !data.CanStartInterviewSession(interview.Status) {
+		writeJSONError(w, http.StatusConflict, "Cannot invite a candidate to an interview in status "+interview.Status)
+		return
+	}
+
+	ttl := deps.InvitationTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	} else if ttl <= 0 {
+		ttl = defaultInvitationTTL
+	}
+	invitation := &data.Invitation{
+		ID:          data.GenerateID(),
+		InterviewID: interview.ID,
+		CandidateID: interview.CandidateID,
+		ExpiresAt:   time.Now().Add(ttl),
+		MaxUses:     max(req.MaxUses, 1),
+	}
+	token, err := signInvitationToken(deps.InvitationSecret, invitation)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to sign invitation", err.Error())
+		return
+	}
+	if err := data.GlobalStore.CreateInvitation(invitation); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create invitation", err.Error())
+		return
+	}
+
+	resp := newInvitationResponseDTO(invitation)
+	resp.Token = token
+	resp.Link = deps.invitationLink(interview.ID, token)
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// ListInvitationsHandler handles GET /interviews/{id}/invitations
+func ListInvitationsHandler(w http.ResponseWriter, r *http.Request) {
+	interviewID := chi.URLParam(r, "id")
+	if interviewID == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingInterviewID)
+		return
+	}
+	if _, err := data.GlobalStore.GetInterview(interviewID); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+
+	invitations, err := data.GlobalStore.ListInvitations(interviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch invitations", err.Error())
+		return
+	}
+
+	resp := ListInvitationsResponseDTO{Invitations: make([]InvitationResponseDTO, len(invitations)), Total: len(invitations)}
+	for i, invitation := range invitations {
+		resp.Invitations[i] = newInvitationResponseDTO(invitation)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RevokeInvitationHandler handles DELETE /interviews/{id}/invitations/{invitationId}
+// The invitation stays listed with its revocation time; sessions already started keep running
+// but the link no longer grants access to them.
+func RevokeInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	interviewID := chi.URLParam(r, "id")
+	invitation, err := data.GlobalStore.GetInvitation(chi.URLParam(r, "invitationId"))
+	if err != nil || invitation.InterviewID != interviewID {
+		writeJSONError(w, http.StatusNotFound, "Invitation not found")
+		return
+	}
+	if err := data.GlobalStore.RevokeInvitation(invitation.ID); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to revoke invitation", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Helper: the ID of the invitation the request is made with, or "" for staff; starting a chat
+// session with it uses one of its uses
+func requestInvitationID(r *http.Request) string {
+	if invitation := invitationFromContext(r.Context()); invitation != nil {
+		return invitation.ID
+	}
+	return ""
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+const (
+	testStaffToken       = "test-staff-token"
+	testInvitationSecret = "test-invitation-secret"
+)
+
+// setupAccessControlledRouter creates a router that requires staff credentials and accepts invitations
+func setupAccessControlledRouter() http.Handler {
+	return SetupRouter(&config.Config{
+		OpenAIAPIKey:      "test-openai-key",
+		GeminiAPIKey:      "test-gemini-key",
+		StaffAPIToken:     testStaffToken,
+		InvitationSecret:  testInvitationSecret,
+		InvitationBaseURL: "https://app.example.com/invite",
+	})
+}
+
+// serveWithHeader sends a request with one extra header and returns the recorder
+func serveWithHeader(router http.Handler, method, path string, body []byte, header, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// createTestInvitation issues an invitation as staff and returns the response
+func createTestInvitation(t *testing.T, router http.Handler, interviewID string, req CreateInvitationRequestDTO) InvitationResponseDTO {
+	t.Helper()
+	b, _ := json.Marshal(req)
+	w := serveWithHeader(router, "POST", "/interviews/"+interviewID+"/invitations", b, "Authorization", "Bearer "+testStaffToken)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("failed to create invitation, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp InvitationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal invitation response: %v", err)
+	}
+	return resp
+}
+
+func TestInvitations_StaffCredentials(t *testing.T) {
+	clearMemoryStore()
+	router := setupAccessControlledRouter()
+
+	for _, header := range []string{"", "Bearer wrong-token", "Basic " + testStaffToken} {
+		if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", header); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401 with Authorization %q, got %d", header, w.Code)
+		}
+	}
+	if w := serveWithHeader(router, "GET", "/interviews", nil, "Authorization", "Bearer "+testStaffToken); w.Code != http.StatusOK {
+		t.Errorf("expected staff to list interviews, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveWithHeader(router, "GET", "/health", nil, "", ""); w.Code != http.StatusOK {
+		t.Errorf("expected the health check to stay open, got %d", w.Code)
+	}
+
+	// Without any credentials configured requests are refused unless authentication was disabled
+	unconfigured := SetupRouter(&config.Config{OpenAIAPIKey: "test-openai-key", GeminiAPIKey: "test-gemini-key"})
+	if w := serveWithHeader(unconfigured, "GET", "/interviews", nil, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without configured credentials, got %d", w.Code)
+	}
+
+	// Invitations cannot be issued without a signing secret
+	open := setupTestRouter()
+	interview := createTestInterview(t, open, CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "general"})
+	expectHTTPError(t, open, "POST", "/interviews/"+interview.ID+"/invitations", nil, http.StatusServiceUnavailable)
+}
+
+func TestInvitations_CandidateAccess(t *testing.T) {
+	clearMemoryStore()
+	router := setupAccessControlledRouter()
+	staff := "Bearer " + testStaffToken
+
+	b, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "general"})
+	w := serveWithHeader(router, "POST", "/interviews", b, "Authorization", staff)
+	var interview, other InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interview)
+	w = serveWithHeader(router, "POST", "/interviews", b, "Authorization", staff)
+	_ = json.Unmarshal(w.Body.Bytes(), &other)
+
+	b, _ = json.Marshal(CreateInvitationRequestDTO{MaxUses: 12})
+	if w := serveWithHeader(router, "POST", "/interviews/"+interview.ID+"/invitations", b, "Authorization", staff); w.Code != http.StatusBadRequest {
+		t.Errorf("expected too many uses rejected, got %d", w.Code)
+	}
+	invitation := createTestInvitation(t, router, interview.ID, CreateInvitationRequestDTO{ExpiresInHours: 24})
+	if invitation.Token == "" || invitation.MaxUses != 1 || !strings.Contains(invitation.Link, "token="+invitation.Token) {
+		t.Fatalf("expected a single-use invitation with its token and link, got %+v", invitation)
+	}
+	if remaining := time.Until(invitation.ExpiresAt); remaining < 23*time.Hour || remaining > 24*time.Hour {
+		t.Errorf("expected the invitation to expire in 24 hours, got %s", remaining)
+	}
+
+	// The token reaches only the bound interview's chat, never recruiter endpoints
+	token := invitation.Token
+	if w := serveWithHeader(router, "GET", "/interviews/"+interview.ID, nil, invitationTokenHeader, token); w.Code != http.StatusForbidden {
+		t.Errorf("expected recruiter endpoints closed to candidates, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "POST", "/interviews/"+other.ID+"/chat/start", nil, invitationTokenHeader, token); w.Code != http.StatusForbidden {
+		t.Errorf("expected another interview closed to the candidate, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected starting a session to need credentials, got %d", w.Code)
+	}
+	w = serveWithHeader(router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, invitationTokenHeader, token)
+	var session ChatInterviewSessionDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &session)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected the candidate to start the session, got %d: %s", w.Code, w.Body.String())
+	}
+
+	b, _ = json.Marshal(SendMessageRequestDTO{Message: "I have five years of Go experience"})
+	if w := serveWithHeader(router, "POST", "/chat/"+session.ID+"/message", b, invitationTokenHeader, token); w.Code != http.StatusOK {
+		t.Errorf("expected the candidate to send a message, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveWithHeader(router, "POST", "/chat/"+session.ID+"/message", b, "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a bare session ID to be refused, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/chat/"+session.ID, nil, invitationTokenHeader, token); w.Code != http.StatusOK {
+		t.Errorf("expected the candidate to read the session, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "DELETE", "/chat/"+session.ID, nil, invitationTokenHeader, token); w.Code != http.StatusForbidden {
+		t.Errorf("expected deleting the session closed to candidates, got %d", w.Code)
+	}
+
+	// Other interviews' sessions and forged tokens are refused
+	w = serveWithHeader(router, "POST", "/interviews/"+other.ID+"/chat/start", nil, "Authorization", staff)
+	var otherSession ChatInterviewSessionDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &otherSession)
+	if w := serveWithHeader(router, "GET", "/chat/"+otherSession.ID, nil, invitationTokenHeader, token); w.Code != http.StatusForbidden {
+		t.Errorf("expected another interview's session closed to the candidate, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/chat/"+session.ID, nil, invitationTokenHeader, token+"x"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a tampered token refused, got %d", w.Code)
+	}
+
+	// The single use is spent, and an expired invitation is refused even with a valid signature
+	if w := serveWithHeader(router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, invitationTokenHeader, token); w.Code != http.StatusForbidden {
+		t.Errorf("expected no uses remaining, got %d: %s", w.Code, w.Body.String())
+	}
+	expired := &data.Invitation{ID: "expired", InterviewID: interview.ID, ExpiresAt: time.Now().Add(-time.Minute), MaxUses: 1}
+	_ = data.GlobalStore.CreateInvitation(expired)
+	expiredToken, _ := signInvitationToken([]byte(testInvitationSecret), expired)
+	if w := serveWithHeader(router, "GET", "/chat/"+session.ID, nil, invitationTokenHeader, expiredToken); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected an expired invitation refused, got %d", w.Code)
+	}
+
+	// Revoked invitations stop working and stay listed
+	if w := serveWithHeader(router, "DELETE", "/interviews/"+other.ID+"/invitations/"+invitation.ID, nil, "Authorization", staff); w.Code != http.StatusNotFound {
+		t.Errorf("expected the invitation not found under another interview, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "DELETE", "/interviews/"+interview.ID+"/invitations/"+invitation.ID, nil, "Authorization", staff); w.Code != http.StatusNoContent {
+		t.Fatalf("expected the invitation revoked, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/chat/"+session.ID, nil, invitationTokenHeader, token); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a revoked invitation refused, got %d", w.Code)
+	}
+	w = serveWithHeader(router, "GET", "/interviews/"+interview.ID+"/invitations", nil, "Authorization", staff)
+	var list ListInvitationsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 2 {
+		t.Fatalf("expected both invitations listed, got %s", w.Body.String())
+	}
+	for _, listed := range list.Invitations {
+		if listed.ID == invitation.ID && (listed.Uses != 1 || listed.RevokedAt == nil || listed.LastUsedAt == nil || listed.Token != "") {
+			t.Errorf("expected the used, revoked invitation without its token, got %+v", listed)
+		}
+	}
+}
+
+func TestInvitations_FailedStartKeepsUse(t *testing.T) {
+	clearMemoryStore()
+	router := setupAccessControlledRouter()
+	b, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "general"})
+	w := serveWithHeader(router, "POST", "/interviews", b, "Authorization", "Bearer "+testStaffToken)
+	var interview InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interview)
+	invitation := createTestInvitation(t, router, interview.ID, CreateInvitationRequestDTO{ExpiresInHours: 24})
+
+	// A greeting that cannot be generated leaves no session, the interview as it was and the use unspent
+	t.Setenv("AI_DEFAULT_PROVIDER", "unavailable")
+	if w := serveWithHeader(router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, invitationTokenHeader, invitation.Token); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected the start to fail without an AI client, got %d: %s", w.Code, w.Body.String())
+	}
+	if sessions, _ := data.GlobalStore.ListChatSessions(interview.ID); len(sessions) != 0 {
+		t.Errorf("expected no session left behind, got %d", len(sessions))
+	}
+	if stored, _ := data.GlobalStore.GetInterview(interview.ID); stored.Status != data.InterviewStatusDraft {
+		t.Errorf("expected the interview still a draft, got %s", stored.Status)
+	}
+	if stored, _ := data.GlobalStore.GetInvitation(invitation.ID); stored.Uses != 0 {
+		t.Errorf("expected the invitation unused, got %d uses", stored.Uses)
+	}
+
+	// The candidate can then start the interview with the same single-use invitation
+	t.Setenv("AI_DEFAULT_PROVIDER", "mock")
+	w = serveWithHeader(router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, invitationTokenHeader, invitation.Token)
+	var session ChatInterviewSessionDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &session)
+	if w.Code != http.StatusCreated || len(session.Messages) != 1 {
+		t.Fatalf("expected the session started with its greeting, got %d: %s", w.Code, w.Body.String())
+	}
+	if stored, _ := data.GlobalStore.GetInvitation(invitation.ID); stored.Uses != 1 {
+		t.Errorf("expected the invitation used once, got %d uses", stored.Uses)
+	}
+}
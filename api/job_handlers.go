@@ -0,0 +1,224 @@
+// HTTP handler functions and runners for background jobs
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Background job listing limits
+const (
+	defaultJobListLimit = 50
+	maxJobListLimit     = 200
+)
+
+// jobRunner performs one attempt of a job, returning the ID of the record it produced; ctx ends
+// when the attempt times out
+type jobRunner func(ctx context.Context, deps *HandlerDependencies, job *data.Job) (string, error)
+
+// jobRunners maps each job type to the runner that performs it
+var jobRunners = map[string]jobRunner{
+	data.JobTypeSessionEvaluation: runSessionEvaluationJob,
+	data.JobTypeAnswerEvaluation:  runAnswerEvaluationJob,
+	data.JobTypeResumeParse:       runResumeParseJob,
+}
+
+// permanentJobError marks a failure that retrying cannot fix, such as a deleted session;
+// the job is dead-lettered without using its remaining attempts
+type permanentJobError struct {
+	err error
+}
+
+func (e permanentJobError) Error() string { return e.err.Error() }
+func (e permanentJobError) Unwrap() error { return e.err }
+
+// Helper: payload of a job evaluating a chat session
+func sessionEvaluationPayload(sessionID string, partial bool) data.StringMap {
+	return data.StringMap{
+		"session_id": sessionID,
+		"partial":    strconv.FormatBool(partial),
+	}
+}
+
+// Helper: payload of a job evaluating answers submitted to POST /evaluation
+func answerEvaluationPayload(interviewID string, answers map[string]string) data.StringMap {
+	// Answers are stored JSON encoded since the payload is a flat string map
+	encoded, _ := json.Marshal(answers)
+	return data.StringMap{
+		"interview_id": interviewID,
+		"answers":      string(encoded),
+	}
+}
+
+// Helper: build a pending job for the worker pool to run as soon as a worker is free
+func (deps *HandlerDependencies) newJob(jobType string, payload data.StringMap) *data.Job {
+	now := time.Now()
+	return &data.Job{
+		ID:          data.GenerateID(),
+		Type:        jobType,
+		Status:      data.JobStatusPending,
+		Payload:     payload,
+		MaxAttempts: max(deps.JobMaxAttempts, 1),
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Helper: queue a job for the worker pool to run as soon as a worker is free
+func (deps *HandlerDependencies) enqueueJob(jobType string, payload data.StringMap) (*data.Job, error) {
+	job := deps.newJob(jobType, payload)
+	if err := data.GlobalStore.CreateJob(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Helper: convert a job to its response DTO
+func newJobResponseDTO(job *data.Job) JobResponseDTO {
+	return JobResponseDTO{
+		ID:           job.ID,
+		Type:         job.Type,
+		Status:       job.Status,
+		Attempts:     job.Attempts,
+		MaxAttempts:  job.MaxAttempts,
+		LastError:    job.LastError,
+		EvaluationID: job.ResultID,
+		RunAt:        job.RunAt,
+		StartedAt:    job.StartedAt,
+		FinishedAt:   job.FinishedAt,
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+	}
+}
+
+// Helper: respond 202 with a queued job and where to poll it
+func writeJobAccepted(w http.ResponseWriter, job *data.Job) {
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, newJobResponseDTO(job))
+}
+
+// Helper: the ID of the evaluation an earlier attempt of the job stored before it could be marked
+// succeeded, e.g. when the process died in between, or "" if there is none to reuse
+func storedJobEvaluationID(job *data.Job) (string, error) {
+	evaluation, err := data.GlobalStore.GetJobEvaluation(job.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up evaluation of job: %w", err)
+	}
+	if evaluation == nil {
+		return "", nil
+	}
+	return evaluation.ID, nil
+}
+
+// runSessionEvaluationJob evaluates the transcript of an ended chat session
+func runSessionEvaluationJob(ctx context.Context, deps *HandlerDependencies, job *data.Job) (string, error) {
+	if id, err := storedJobEvaluationID(job); err != nil || id != "" {
+		return id, err
+	}
+	session, err := data.GlobalStore.GetChatSession(job.Payload["session_id"])
+	if err != nil {
+		return "", permanentJobError{fmt.Errorf("failed to get chat session: %w", err)}
+	}
+	interview, err := data.GlobalStore.GetInterview(session.InterviewID)
+	if err != nil {
+		return "", permanentJobError{fmt.Errorf("failed to get interview: %w", err)}
+	}
+	messages, err := data.GlobalStore.GetChatMessages(session.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get chat messages: %w", err)
+	}
+	partial, _ := strconv.ParseBool(job.Payload["partial"])
+	evaluation, err := deps.evaluateChatSession(ctx, job, session, interview, messages, partial)
+	if err != nil {
+		return "", err
+	}
+	return evaluation.ID, nil
+}
+
+// runAnswerEvaluationJob evaluates answers submitted to POST /evaluation
+func runAnswerEvaluationJob(ctx context.Context, deps *HandlerDependencies, job *data.Job) (string, error) {
+	if id, err := storedJobEvaluationID(job); err != nil || id != "" {
+		return id, err
+	}
+	var answers map[string]string
+	if err := json.Unmarshal([]byte(job.Payload["answers"]), &answers); err != nil {
+		return "", permanentJobError{fmt.Errorf("invalid answers payload: %w", err)}
+	}
+	interview, err := data.GlobalStore.GetInterview(job.Payload["interview_id"])
+	if err != nil {
+		return "", permanentJobError{fmt.Errorf("failed to get interview: %w", err)}
+	}
+	evaluation, err := deps.evaluateAnswers(ctx, job, interview, answers)
+	if err != nil {
+		return "", err
+	}
+	return evaluation.ID, nil
+}
+
+// GetJobHandler handles GET /jobs/{id}
+func GetJobHandler(w http.ResponseWriter, r *http.Request) {
+	job, err := data.GlobalStore.GetJob(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, newJobResponseDTO(job))
+}
+
+// ListJobsHandler handles GET /jobs
+// Lists the most recent jobs; ?status=dead shows the dead-letter queue.
+func ListJobsHandler(w http.ResponseWriter, r *http.Request) {
+	filters := data.JobFilters{
+		Status: r.URL.Query().Get("status"),
+		Type:   r.URL.Query().Get("type"),
+	}
+	if filters.Status != "" && !data.ValidateJobStatus(filters.Status) {
+		writeJSONError(w, http.StatusBadRequest, "Invalid status", "status must be one of: pending, running, succeeded, dead")
+		return
+	}
+	if _, known := jobRunners[filters.Type]; filters.Type != "" && !known {
+		writeJSONError(w, http.StatusBadRequest, "Invalid type")
+		return
+	}
+	limit := min(parseIntQuery(r, "limit", defaultJobListLimit), maxJobListLimit)
+
+	jobs, err := data.GlobalStore.ListJobs(filters, limit)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to list jobs")
+		return
+	}
+	resp := ListJobsResponseDTO{Jobs: make([]JobResponseDTO, 0, len(jobs)), Total: len(jobs)}
+	for _, job := range jobs {
+		resp.Jobs = append(resp.Jobs, newJobResponseDTO(job))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RetryJobHandler handles POST /jobs/{id}/retry
+// Requeues a dead-lettered job with a fresh set of attempts.
+func RetryJobHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := data.GlobalStore.GetJob(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	job, err := data.GlobalStore.RetryJob(id)
+	if err != nil {
+		if errors.Is(err, data.ErrJobNotDead) {
+			writeJSONError(w, http.StatusConflict, "Only dead jobs can be retried")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to retry job")
+		return
+	}
+	writeJobAccepted(w, job)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+func TestEvaluationJobs(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	getJob := func(id string) JobResponseDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/jobs/"+id, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 getting job, got %d: %s", w.Code, w.Body.String())
+		}
+		var job JobResponseDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &job)
+		return job
+	}
+
+	// Ending a session queues its evaluation instead of running it in the request
+	ids := createTestInterviewAndSession(t, router)
+	sendMessage(t, router, ids.SessionID, "I have built several Go services")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/chat/"+ids.SessionID+"/end", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 ending session, got %d: %s", w.Code, w.Body.String())
+	}
+	var queued JobResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &queued)
+	if queued.Status != data.JobStatusPending || queued.Type != data.JobTypeSessionEvaluation || w.Header().Get("Location") != "/jobs/"+queued.ID {
+		t.Fatalf("expected a pending session evaluation job, got %+v", queued)
+	}
+	if job := getJob(queued.ID); job.Status != data.JobStatusPending || job.EvaluationID != "" {
+		t.Errorf("expected job still pending before a worker runs, got %+v", job)
+	}
+
+	runQueuedJobs()
+	done := getJob(queued.ID)
+	if done.Status != data.JobStatusSucceeded || done.Attempts != 1 || done.FinishedAt == nil {
+		t.Fatalf("expected succeeded job, got %+v", done)
+	}
+	expectHTTPError(t, router, "GET", "/evaluation/"+done.EvaluationID, nil, http.StatusOK)
+
+	// Failing attempts are retried with backoff, then dead-lettered
+	cfg := &config.Config{JobRetryBackoff: time.Minute, JobTimeout: time.Minute}
+	pool := NewJobWorkerPool(cfg, NewHandlerDependenciesFromConfig(cfg))
+	attempts := 0
+	pool.runners = map[string]jobRunner{
+		data.JobTypeSessionEvaluation: func(ctx context.Context, _ *HandlerDependencies, _ *data.Job) (string, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("expected the attempt bounded by the job timeout")
+			}
+			attempts++
+			return "", errors.New("provider unavailable")
+		},
+	}
+	deps := &HandlerDependencies{JobMaxAttempts: 2}
+	failing, _ := deps.enqueueJob(data.JobTypeSessionEvaluation, sessionEvaluationPayload(ids.SessionID, false))
+	now := time.Now()
+	pool.runNext(now)
+	retrying := getJob(failing.ID)
+	if retrying.Status != data.JobStatusPending || retrying.LastError != "provider unavailable" || !retrying.RunAt.After(now.Add(59*time.Second)) {
+		t.Fatalf("expected job rescheduled about a minute out, got %+v", retrying)
+	}
+	if pool.runNext(now) {
+		t.Fatal("expected no job due before the retry time")
+	}
+	pool.runNext(now.Add(2 * time.Minute))
+	if dead := getJob(failing.ID); dead.Status != data.JobStatusDead || dead.Attempts != 2 || attempts != 2 {
+		t.Fatalf("expected job dead after 2 attempts, got %+v after %d runs", dead, attempts)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/jobs?status=dead", nil))
+	var list ListJobsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 1 || list.Jobs[0].ID != failing.ID {
+		t.Errorf("expected the failing job in the dead-letter list, got %+v", list)
+	}
+
+	// Dead jobs can be retried by hand; the real runner then succeeds
+	expectHTTPError(t, router, "POST", "/jobs/"+failing.ID+"/retry", nil, http.StatusAccepted)
+	expectHTTPError(t, router, "POST", "/jobs/"+failing.ID+"/retry", nil, http.StatusConflict)
+	runQueuedJobs()
+	if job := getJob(failing.ID); job.Status != data.JobStatusSucceeded || job.Attempts != 1 {
+		t.Errorf("expected retried job to succeed, got %+v", job)
+	}
+
+	// A rerun of a job whose earlier attempt stored its evaluation reuses it instead of evaluating again
+	rerun, _ := deps.enqueueJob(data.JobTypeSessionEvaluation, sessionEvaluationPayload(ids.SessionID, false))
+	claimed, _ := data.GlobalStore.ClaimNextJob(time.Now())
+	stored := &data.Evaluation{ID: data.GenerateID(), InterviewID: ids.InterviewID, JobID: rerun.ID}
+	if err := data.GlobalStore.CreateJobEvaluation(stored, claimed.Attempts); err != nil {
+		t.Fatalf("CreateJobEvaluation failed: %v", err)
+	}
+	_, _, _ = data.GlobalStore.RequeueStaleJobs(time.Now().Add(time.Minute))
+	runQueuedJobs()
+	if job := getJob(rerun.ID); job.Status != data.JobStatusSucceeded || job.EvaluationID != stored.ID {
+		t.Errorf("expected the rerun to reuse evaluation %s, got %+v", stored.ID, job)
+	}
+
+	// Jobs for records that no longer exist fail permanently without retrying
+	missing, _ := deps.enqueueJob(data.JobTypeSessionEvaluation, sessionEvaluationPayload("deleted-session", false))
+	runQueuedJobs()
+	if job := getJob(missing.ID); job.Status != data.JobStatusDead || job.Attempts != 1 {
+		t.Errorf("expected job for a missing session dead after one attempt, got %+v", job)
+	}
+
+	expectHTTPError(t, router, "GET", "/jobs/nonexistent", nil, http.StatusNotFound)
+	expectHTTPError(t, router, "POST", "/jobs/nonexistent/retry", nil, http.StatusNotFound)
+	expectHTTPError(t, router, "GET", "/jobs?status=stuck", nil, http.StatusBadRequest)
+	expectHTTPError(t, router, "GET", "/jobs?type=email", nil, http.StatusBadRequest)
+
+	// Stop is safe to call on a pool that was never started
+	disabled := NewJobWorkerPool(&config.Config{}, NewHandlerDependenciesFromConfig(&config.Config{}))
+	disabled.Start()
+	disabled.Stop()
+}
@@ -0,0 +1,176 @@
+// In-process worker pool for background jobs
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// maxRetryBackoffDoublings caps the exponential retry delay
+const maxRetryBackoffDoublings = 10
+
+// JobWorkerPool runs queued jobs on a fixed number of workers, retrying failed attempts with
+// exponential backoff and dead-lettering jobs that run out of attempts
+type JobWorkerPool struct {
+	deps         *HandlerDependencies
+	runners      map[string]jobRunner
+	workers      int
+	pollInterval time.Duration
+	retryBackoff time.Duration
+	timeout      time.Duration
+
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewJobWorkerPool creates a worker pool from the background job configuration, running jobs with deps
+func NewJobWorkerPool(cfg *config.Config, deps *HandlerDependencies) *JobWorkerPool {
+	return &JobWorkerPool{
+		deps:         deps,
+		runners:      jobRunners,
+		workers:      cfg.JobWorkers,
+		pollInterval: cfg.JobPollInterval,
+		retryBackoff: cfg.JobRetryBackoff,
+		timeout:      cfg.JobTimeout,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start runs the workers in the background until Stop is called
+func (p *JobWorkerPool) Start() {
+	if p.workers <= 0 || p.pollInterval <= 0 {
+		utils.Warningf("Job workers disabled; queued evaluations will not run")
+		return
+	}
+	for worker := 0; worker < p.workers; worker++ {
+		p.wg.Add(1)
+		go p.work(worker)
+	}
+	utils.Infof("Started %d job workers (poll interval %s, job timeout %s)", p.workers, p.pollInterval, p.timeout)
+}
+
+// Stop signals the workers to exit and waits for in-flight jobs to finish
+func (p *JobWorkerPool) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+	p.wg.Wait()
+}
+
+// stopping reports whether Stop has been called
+func (p *JobWorkerPool) stopping() bool {
+	select {
+	case <-p.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// work runs due jobs until none are left, then waits for the next poll
+func (p *JobWorkerPool) work(worker int) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+	for {
+		for !p.stopping() && p.runNext(time.Now()) {
+		}
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			// One worker is enough to recover jobs lost to a crash or restart
+			if worker == 0 && p.timeout > 0 {
+				p.requeueStale(now)
+			}
+		}
+	}
+}
+
+// requeueStale puts jobs that have been running longer than the timeout back on the queue, or
+// dead-letters them when that was their last attempt
+func (p *JobWorkerPool) requeueStale(now time.Time) {
+	requeued, dead, err := data.GlobalStore.RequeueStaleJobs(now.Add(-p.timeout))
+	if err != nil {
+		utils.Errorf("Failed to requeue stale jobs: %v", err)
+		return
+	}
+	if requeued > 0 {
+		utils.Warningf("Requeued %d jobs that ran longer than %s", requeued, p.timeout)
+	}
+	if dead > 0 {
+		utils.Errorf("Dead-lettered %d jobs whose last attempt ran longer than %s", dead, p.timeout)
+	}
+}
+
+// runNext claims and runs the next due job, reporting whether there was one
+func (p *JobWorkerPool) runNext(now time.Time) bool {
+	job, err := data.GlobalStore.ClaimNextJob(now)
+	if err != nil {
+		utils.Errorf("Failed to claim job: %v", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+	resultID, err := p.run(job)
+	p.finish(job, resultID, err)
+	return true
+}
+
+// run performs one attempt of a job within the job timeout, turning a panic into a failed attempt
+func (p *JobWorkerPool) run(job *data.Job) (resultID string, err error) {
+	runner, ok := p.runners[job.Type]
+	if !ok {
+		return "", permanentJobError{fmt.Errorf("unknown job type %q", job.Type)}
+	}
+	ctx := context.Background()
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return runner(ctx, p.deps, job)
+}
+
+// finish records the outcome of an attempt: success, a scheduled retry, or dead-lettering. An attempt
+// that outlived the timeout has already been requeued or dead-lettered, so its outcome is dropped.
+func (p *JobWorkerPool) finish(job *data.Job, resultID string, err error) {
+	if err == nil {
+		p.record(job, "mark succeeded", data.GlobalStore.CompleteJob(job.ID, job.Attempts, resultID))
+		return
+	}
+
+	var permanent permanentJobError
+	if errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts {
+		utils.Errorf("Job %s (%s) dead-lettered after %d attempts: %v", job.ID, job.Type, job.Attempts, err)
+		p.record(job, "dead-letter", data.GlobalStore.FailJob(job.ID, job.Attempts, err.Error(), nil))
+		return
+	}
+
+	retryAt := time.Now().Add(p.retryBackoff << min(job.Attempts-1, maxRetryBackoffDoublings))
+	utils.Warningf("Job %s (%s) attempt %d of %d failed, retrying at %s: %v", job.ID, job.Type, job.Attempts, job.MaxAttempts, retryAt.Format(time.RFC3339), err)
+	p.record(job, "reschedule", data.GlobalStore.FailJob(job.ID, job.Attempts, err.Error(), &retryAt))
+}
+
+// record logs a failure to store the outcome of an attempt
+func (p *JobWorkerPool) record(job *data.Job, action string, err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, data.ErrJobAttemptSuperseded):
+		utils.Warningf("Job %s (%s) attempt %d finished after it timed out; its outcome was discarded", job.ID, job.Type, job.Attempts)
+	default:
+		utils.Errorf("Failed to %s job %s: %v", action, job.ID, err)
+	}
+}
@@ -2,12 +2,19 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/zidane0000/AI_Interview_Backend/utils"
 )
 
+// invitationTokenHeader carries a candidate's invitation token
+const invitationTokenHeader = "X-Invitation-Token"
+
+// contextKey namespaces values the middleware stores in the request context
+type contextKey string
+
 // LoggingMiddleware logs the HTTP method, path, status, and duration for each request.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -62,7 +69,7 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		}
 
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, "+invitationTokenHeader+", "+apiKeyHeader)
 		w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 
@@ -76,6 +83,24 @@ func CORSMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// AuthMiddleware identifies the caller from an Authorization bearer credential (the staff token,
+// an API key or a JWT), an X-API-Key header or an X-Invitation-Token header, and stores the
+// Principal in the request context. Invalid credentials are rejected here; requests without any
+// continue anonymously and are left to the route's Authorize check.
+func (deps *HandlerDependencies) AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, msg := deps.authenticate(r)
+		if msg != "" {
+			writeJSONError(w, http.StatusUnauthorized, msg)
+			return
+		}
+		if principal != nil {
+			r = r.WithContext(context.WithValue(r.Context(), principalContextKey, principal))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // TODO: Implement additional middleware for production readiness:
 
 // TODO: RequestIDMiddleware - Essential for distributed tracing
@@ -84,11 +109,9 @@ func CORSMiddleware(next http.Handler) http.Handler {
 // - Enables request tracing across microservices
 // - Helps with debugging and monitoring
 
-// TODO: AuthMiddleware - Required for user authentication
-// - Validates JWT tokens or API keys
-// - Extracts user information from tokens
-// - Handles token refresh logic
-// - Protects secured endpoints
+// TODO: AuthMiddleware improvements:
+// - Refresh JWKS keys periodically to follow key rotation
+// - Support token refresh for browser sessions
 
 // TODO: RecoveryMiddleware - Critical for application stability
 // - Catches panics and prevents server crashes
@@ -0,0 +1,269 @@
+// HTTP handler functions for positions (the roles candidates interview for)
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/ai"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Position listing and field limits
+const (
+	defaultPositionListLimit = 50
+	maxPositionListLimit     = 200
+	maxPositionFieldLength   = 255
+	maxPositionLevelLength   = 50
+	maxRequiredSkills        = 50
+	maxRequiredSkillLength   = 100
+)
+
+// Helper: trim a position request and drop blank or repeated required skills
+func normalizePositionRequest(req *PositionRequestDTO) {
+	req.Title = strings.TrimSpace(req.Title)
+	req.Department = strings.TrimSpace(req.Department)
+	req.Level = strings.TrimSpace(req.Level)
+	req.TemplateID = strings.TrimSpace(req.TemplateID)
+
+	skills := make([]string, 0, len(req.RequiredSkills))
+	seen := make(map[string]bool, len(req.RequiredSkills))
+	for _, skill := range req.RequiredSkills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" || seen[strings.ToLower(skill)] {
+			continue
+		}
+		seen[strings.ToLower(skill)] = true
+		skills = append(skills, skill)
+	}
+	req.RequiredSkills = skills
+}
+
+// Helper: validate a normalized position request, returning an error message or "" if valid
+func validatePositionRequest(req *PositionRequestDTO) string {
+	if req.Title == "" {
+		return "Missing title"
+	}
+	if len(req.Title) > maxPositionFieldLength || len(req.Department) > maxPositionFieldLength {
+		return "title and department must be at most 255 characters"
+	}
+	if len(req.Level) > maxPositionLevelLength {
+		return "level must be at most 50 characters"
+	}
+	if len(req.RequiredSkills) > maxRequiredSkills {
+		return "At most 50 required_skills are allowed"
+	}
+	for _, skill := range req.RequiredSkills {
+		if len(skill) > maxRequiredSkillLength {
+			return "Each required skill must be at most 100 characters"
+		}
+	}
+	if msg := validateRubricDTO(req.Rubric); msg != "" {
+		return msg
+	}
+	if req.TemplateID != "" {
+		if _, err := data.GlobalStore.GetInterviewTemplate(req.TemplateID); err != nil {
+			return "Invalid template_id: template not found"
+		}
+	}
+	return ""
+}
+
+// Helper: convert a position to its response DTO
+func newPositionResponseDTO(position *data.Position) PositionResponseDTO {
+	skills := position.RequiredSkills
+	if skills == nil {
+		skills = data.StringArray{}
+	}
+	return PositionResponseDTO{
+		ID:             position.ID,
+		Title:          position.Title,
+		Department:     position.Department,
+		Level:          position.Level,
+		Description:    position.Description,
+		RequiredSkills: skills,
+		TemplateID:     position.TemplateID,
+		Rubric:         rubricToDTO(position.Rubric),
+		CreatedBy:      position.CreatedBy,
+		CreatedAt:      position.CreatedAt,
+		UpdatedAt:      position.UpdatedAt,
+	}
+}
+
+// Helper: copy a request's details onto a position
+func applyPositionRequest(position *data.Position, req *PositionRequestDTO) {
+	position.Title = req.Title
+	position.Department = req.Department
+	position.Level = req.Level
+	position.Description = req.Description
+	position.RequiredSkills = req.RequiredSkills
+	position.TemplateID = req.TemplateID
+	position.Rubric = rubricFromDTO(req.Rubric)
+}
+
+// Helper: convert a position to the AI layer's role description
+func positionToAI(position *data.Position) *ai.PositionInfo {
+	return &ai.PositionInfo{
+		Title:          position.Title,
+		Level:          position.Level,
+		RequiredSkills: position.RequiredSkills,
+	}
+}
+
+// Helper: fill an interview request from its position, returning an error message if the position does not exist.
+// Runs before the template is applied, so the position's default template fills the remaining fields.
+func applyInterviewPosition(req *CreateInterviewRequestDTO) string {
+	if req.PositionID == "" {
+		return ""
+	}
+	position, err := data.GlobalStore.GetPosition(req.PositionID)
+	if err != nil {
+		return "Position not found"
+	}
+	if req.JobDescription == "" {
+		req.JobDescription = position.Description
+	}
+	if req.TemplateID == "" {
+		req.TemplateID = position.TemplateID
+	}
+	if req.Rubric == nil {
+		req.Rubric = rubricToDTO(position.Rubric)
+	}
+	return ""
+}
+
+// CreatePositionHandler handles POST /positions
+func CreatePositionHandler(w http.ResponseWriter, r *http.Request) {
+	var req PositionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	normalizePositionRequest(&req)
+	if msg := validatePositionRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	position := &data.Position{ID: data.GenerateID(), CreatedBy: creatorOf(r)}
+	applyPositionRequest(position, &req)
+	if err := data.GlobalStore.CreatePosition(position); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create position", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newPositionResponseDTO(position))
+}
+
+// ListPositionsHandler handles GET /positions
+// Lists positions by title, optionally filtered by ?title= (partial match) or ?department=. Recruiters
+// only see their own.
+func ListPositionsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := parseIntQuery(r, "limit", defaultPositionListLimit)
+	if limit <= 0 || limit > maxPositionListLimit {
+		limit = defaultPositionListLimit
+	}
+	filters := data.PositionFilters{
+		Title:      strings.TrimSpace(query.Get("title")),
+		Department: strings.TrimSpace(query.Get("department")),
+		CreatedBy:  ownerScope(r), // Recruiters only see the positions they created
+	}
+
+	positions, total, err := data.GlobalStore.ListPositions(filters, limit, max(parseIntQuery(r, "offset", 0), 0))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch positions", err.Error())
+		return
+	}
+
+	resp := ListPositionsResponseDTO{Positions: make([]PositionResponseDTO, len(positions)), Total: total}
+	for i, position := range positions {
+		resp.Positions[i] = newPositionResponseDTO(position)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetPositionHandler handles GET /positions/{id}
+func GetPositionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingPositionID)
+		return
+	}
+
+	position, err := data.GlobalStore.GetPosition(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Position not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPositionResponseDTO(position))
+}
+
+// UpdatePositionHandler handles PUT /positions/{id}
+// Replaces the position's details. Existing interviews keep the job description and rubric they were
+// created with; the evaluator reads the title and required skills from the position when it runs.
+func UpdatePositionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingPositionID)
+		return
+	}
+
+	existing, err := data.GlobalStore.GetPosition(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Position not found")
+		return
+	}
+
+	var req PositionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	normalizePositionRequest(&req)
+	if msg := validatePositionRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	position := *existing
+	applyPositionRequest(&position, &req)
+	position.UpdatedAt = time.Now()
+
+	if err := data.GlobalStore.UpdatePosition(&position); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update position", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPositionResponseDTO(&position))
+}
+
+// DeletePositionHandler handles DELETE /positions/{id}
+// Positions with interviews cannot be deleted; delete the interviews first.
+func DeletePositionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingPositionID)
+		return
+	}
+
+	if _, err := data.GlobalStore.GetPosition(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Position not found")
+		return
+	}
+	if err := data.GlobalStore.DeletePosition(id); err != nil {
+		if errors.Is(err, data.ErrPositionHasInterviews) {
+			writeJSONError(w, http.StatusConflict, "Position has interviews; delete them first")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to delete position", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// createTestPosition creates a position and returns the response
+func createTestPosition(t *testing.T, router http.Handler, req PositionRequestDTO) PositionResponseDTO {
+	t.Helper()
+	var resp PositionResponseDTO
+	doJSONRequest(t, router, "POST", "/positions", req, http.StatusCreated, &resp)
+	return resp
+}
+
+func TestPositionHandlers_CRUD(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	position := createTestPosition(t, router, PositionRequestDTO{
+		Title:          " Backend Engineer ",
+		Department:     "Platform",
+		Level:          "senior",
+		RequiredSkills: []string{"Go", " go ", "", "PostgreSQL"},
+	})
+	if position.Title != "Backend Engineer" || len(position.RequiredSkills) != 2 || position.RequiredSkills[1] != "PostgreSQL" {
+		t.Errorf("expected a normalized position, got %+v", position)
+	}
+
+	for _, invalid := range []PositionRequestDTO{
+		{Department: "Platform"},
+		{Title: "A", TemplateID: "missing"},
+		{Title: "A", Rubric: &RubricDTO{}},
+	} {
+		b, _ := json.Marshal(invalid)
+		expectHTTPError(t, router, "POST", "/positions", b, http.StatusBadRequest)
+	}
+	createTestPosition(t, router, PositionRequestDTO{Title: "Account Manager", Department: "Sales"})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/positions?department=Platform", nil))
+	var list ListPositionsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Total != 1 || list.Positions[0].ID != position.ID {
+		t.Errorf("expected the position found by department, got %+v", list)
+	}
+
+	b, _ := json.Marshal(PositionRequestDTO{Title: "Staff Backend Engineer", RequiredSkills: []string{"Go"}})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/positions/"+position.ID, bytes.NewReader(b)))
+	var updated PositionResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &updated)
+	if w.Code != http.StatusOK || updated.Title != "Staff Backend Engineer" || updated.Department != "" || len(updated.RequiredSkills) != 1 {
+		t.Errorf("expected the position replaced, got %d: %s", w.Code, w.Body.String())
+	}
+
+	expectHTTPError(t, router, "GET", "/positions/missing", nil, http.StatusNotFound)
+	expectHTTPError(t, router, "DELETE", "/positions/"+position.ID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "DELETE", "/positions/"+position.ID, nil, http.StatusNotFound)
+}
+
+func TestPositionHandlers_Interviews(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	template := createTestTemplate(t, router, InterviewTemplateRequestDTO{
+		Name:           "Backend loop",
+		InterviewType:  "technical",
+		Questions:      []string{"Design a rate limiter"},
+		JobDescription: "Template description",
+	})
+	position := createTestPosition(t, router, PositionRequestDTO{
+		Title:          "Backend Engineer",
+		Description:    "Build payment services in Go",
+		RequiredSkills: []string{"Go"},
+		TemplateID:     template.ID,
+		Rubric:         &RubricDTO{Criteria: []RubricCriterionDTO{{Key: "go_proficiency", Weight: 1}}},
+	})
+
+	b, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "A", PositionID: "missing", Questions: []string{"Q1"}, InterviewType: "general"})
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusNotFound)
+
+	// The position supplies the description, template and rubric; the template fills the rest
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Jane", PositionID: position.ID})
+	if interview.PositionID != position.ID || interview.TemplateID != template.ID || interview.JobDescription != "Build payment services in Go" {
+		t.Errorf("expected the interview filled from the position, got %+v", interview)
+	}
+	if interview.InterviewType != "technical" || interview.Rubric == nil || interview.Rubric.Criteria[0].Key != "go_proficiency" {
+		t.Errorf("expected the template and rubric applied, got %+v", interview)
+	}
+	other := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Alex", Questions: []string{"Q1"}, InterviewType: "general"})
+
+	for _, id := range []string{interview.ID, other.ID} {
+		session := startChatSession(t, router, id, nil)
+		sendMessage(t, router, session.ID, "I built a payment service in Go")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/chat/"+session.ID+"/end", nil))
+		awaitEvaluation(t, router, w)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/evaluation/stats?group_by=position", nil))
+	var stats EvaluationStatsDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &stats)
+	if w.Code != http.StatusOK || stats.TotalEvaluations != 2 || len(stats.ByPosition) != 1 {
+		t.Fatalf("expected both evaluations with one position group, got %d: %s", w.Code, w.Body.String())
+	}
+	if group := stats.ByPosition[0]; group.PositionID != position.ID || group.Title != "Backend Engineer" || group.TotalEvaluations != 1 {
+		t.Errorf("expected the position's evaluation grouped, got %+v", group)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/evaluation/stats?position_id="+position.ID, nil))
+	var filtered EvaluationStatsDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &filtered)
+	if filtered.TotalEvaluations != 1 || filtered.ByPosition != nil {
+		t.Errorf("expected statistics filtered by position, got %+v", filtered)
+	}
+	expectHTTPError(t, router, "GET", "/evaluation/stats?group_by=department", nil, http.StatusBadRequest)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews?position_id="+position.ID, nil))
+	var interviews ListInterviewsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interviews)
+	if interviews.Total != 1 {
+		t.Errorf("expected interviews filtered by position, got %d", interviews.Total)
+	}
+
+	expectHTTPError(t, router, "DELETE", "/positions/"+position.ID, nil, http.StatusConflict)
+}
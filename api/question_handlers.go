@@ -0,0 +1,250 @@
+// HTTP handler functions for the reusable question bank
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Helper: convert a question bank entry to its response DTO
+func newQuestionResponseDTO(question *data.Question) QuestionResponseDTO {
+	tags := question.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return QuestionResponseDTO{
+		ID:             question.ID,
+		Text:           question.Text,
+		Translations:   question.Translations,
+		Tags:           tags,
+		InterviewType:  question.InterviewType,
+		Difficulty:     question.Difficulty,
+		ExpectedAnswer: question.ExpectedAnswer,
+		UsageCount:     question.UsageCount,
+		CreatedAt:      question.CreatedAt,
+		UpdatedAt:      question.UpdatedAt,
+	}
+}
+
+// Helper: trim, lowercase and de-duplicate tags so both backends filter them identically
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// Helper: validate a question request, returning an error message or "" if valid
+func validateQuestionRequest(req *QuestionRequestDTO) string {
+	if strings.TrimSpace(req.Text) == "" {
+		return "Missing text field"
+	}
+	if req.InterviewType == "" {
+		return "Missing interview_type field"
+	}
+	if !data.ValidateInterviewType(req.InterviewType) {
+		return "Invalid interview_type. Supported types: general, technical, behavioral"
+	}
+	if req.Difficulty != "" && !data.ValidateDifficulty(req.Difficulty) {
+		return "Invalid difficulty. Supported values: easy, medium, hard"
+	}
+	for lang := range req.Translations {
+		if !data.ValidateLanguage(lang) {
+			return "Invalid translation language code. Supported languages: en, zh-TW"
+		}
+	}
+	return ""
+}
+
+// resolveInterviewQuestions returns the interview's full question list: referenced bank
+// questions (localized to the interview language) followed by any inline questions
+func resolveInterviewQuestions(interview *data.Interview) []string {
+	questions := make([]string, 0, len(interview.QuestionIDs)+len(interview.Questions))
+	if len(interview.QuestionIDs) > 0 {
+		bankQuestions, err := data.GlobalStore.GetQuestionsByIDs(interview.QuestionIDs)
+		if err != nil {
+			// Keep the questions that still resolve rather than dropping them all
+			utils.Warningf("Failed to resolve bank questions for interview %s: %v", interview.ID, err)
+			bankQuestions = bankQuestions[:0]
+			for _, id := range interview.QuestionIDs {
+				if question, err := data.GlobalStore.GetQuestion(id); err == nil {
+					bankQuestions = append(bankQuestions, question)
+				}
+			}
+		}
+		for _, question := range bankQuestions {
+			questions = append(questions, question.TextFor(interview.InterviewLanguage))
+		}
+	}
+	return append(questions, interview.Questions...)
+}
+
+// CreateQuestionHandler handles POST /questions
+func CreateQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	var req QuestionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if msg := validateQuestionRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	difficulty := req.Difficulty
+	if difficulty == "" {
+		difficulty = data.DifficultyMedium
+	}
+
+	question := &data.Question{
+		ID:             data.GenerateID(),
+		Text:           strings.TrimSpace(req.Text),
+		Translations:   req.Translations,
+		Tags:           normalizeTags(req.Tags),
+		InterviewType:  req.InterviewType,
+		Difficulty:     difficulty,
+		ExpectedAnswer: req.ExpectedAnswer,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := data.GlobalStore.CreateQuestion(question); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create question", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newQuestionResponseDTO(question))
+}
+
+// ListQuestionsHandler handles GET /questions
+func ListQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := data.ListQuestionsOptions{
+		Limit:         parseIntQuery(r, "limit", 10),
+		Offset:        parseIntQuery(r, "offset", 0),
+		Page:          parseIntQuery(r, "page", 0),
+		InterviewType: query.Get("interview_type"),
+		Difficulty:    query.Get("difficulty"),
+		Search:        strings.TrimSpace(query.Get("q")),
+		SortBy:        query.Get("sort_by"),
+	}
+
+	// Tags may be repeated (?tag=a&tag=b) or comma-separated (?tags=a,b)
+	tags := query["tag"]
+	if tagList := query.Get("tags"); tagList != "" {
+		tags = append(tags, strings.Split(tagList, ",")...)
+	}
+	opts.Tags = normalizeTags(tags)
+
+	result, err := data.GlobalStore.GetQuestionsWithOptions(opts)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch questions", err.Error())
+		return
+	}
+
+	questionDTOs := make([]QuestionResponseDTO, len(result.Questions))
+	for i, question := range result.Questions {
+		questionDTOs[i] = newQuestionResponseDTO(question)
+	}
+
+	writeJSON(w, http.StatusOK, ListQuestionsResponseDTO{
+		Questions:  questionDTOs,
+		Total:      result.Total,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		TotalPages: result.TotalPages,
+	})
+}
+
+// GetQuestionHandler handles GET /questions/{id}
+func GetQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingQuestionID)
+		return
+	}
+
+	question, err := data.GlobalStore.GetQuestion(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Question not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newQuestionResponseDTO(question))
+}
+
+// UpdateQuestionHandler handles PUT /questions/{id}
+func UpdateQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingQuestionID)
+		return
+	}
+
+	question, err := data.GlobalStore.GetQuestion(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Question not found")
+		return
+	}
+
+	var req QuestionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if msg := validateQuestionRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	question.Text = strings.TrimSpace(req.Text)
+	question.Translations = req.Translations
+	question.Tags = normalizeTags(req.Tags)
+	question.InterviewType = req.InterviewType
+	if req.Difficulty != "" {
+		question.Difficulty = req.Difficulty
+	}
+	question.ExpectedAnswer = req.ExpectedAnswer
+	question.UpdatedAt = time.Now()
+
+	if err := data.GlobalStore.UpdateQuestion(question); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update question", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newQuestionResponseDTO(question))
+}
+
+// DeleteQuestionHandler handles DELETE /questions/{id}
+// Questions that interviews or templates still reference cannot be deleted.
+func DeleteQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingQuestionID)
+		return
+	}
+
+	if err := data.GlobalStore.DeleteQuestion(id); err != nil {
+		if errors.Is(err, data.ErrQuestionInUse) {
+			writeJSONError(w, http.StatusConflict, "Question is used by interviews or templates")
+			return
+		}
+		writeJSONError(w, http.StatusNotFound, "Question not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
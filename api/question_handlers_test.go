@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// createTestQuestion creates a question bank entry and returns the response
+func createTestQuestion(t *testing.T, router http.Handler, req QuestionRequestDTO) QuestionResponseDTO {
+	t.Helper()
+	var resp QuestionResponseDTO
+	doJSONRequest(t, router, "POST", "/questions", req, http.StatusCreated, &resp)
+	return resp
+}
+
+func TestQuestionHandlers_CRUD(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	question := createTestQuestion(t, router, QuestionRequestDTO{
+		Text:          "Describe a REST API you designed",
+		Tags:          []string{" API ", "design", "api"},
+		InterviewType: "technical",
+	})
+	if question.Difficulty != "medium" {
+		t.Errorf("expected default difficulty 'medium', got %s", question.Difficulty)
+	}
+	if len(question.Tags) != 2 || question.Tags[0] != "api" {
+		t.Errorf("expected normalized tags [api design], got %v", question.Tags)
+	}
+
+	// Update
+	b, _ := json.Marshal(QuestionRequestDTO{
+		Text:          "Describe a REST API you designed and its versioning strategy",
+		Tags:          []string{"api"},
+		InterviewType: "technical",
+		Difficulty:    "hard",
+	})
+	req := httptest.NewRequest("PUT", "/questions/"+question.ID, bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK on update, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Search
+	req = httptest.NewRequest("GET", "/questions?q=versioning&difficulty=hard", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var list ListQuestionsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal list response: %v", err)
+	}
+	if list.Total != 1 {
+		t.Errorf("expected 1 search result, got %d", list.Total)
+	}
+
+	// Delete
+	expectHTTPError(t, router, "DELETE", "/questions/"+question.ID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "GET", "/questions/"+question.ID, nil, http.StatusNotFound)
+}
+
+func TestQuestionHandlers_Validation(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	cases := []QuestionRequestDTO{
+		{InterviewType: "technical"},
+		{Text: "Q", InterviewType: "unknown"},
+		{Text: "Q", InterviewType: "general", Difficulty: "extreme"},
+		{Text: "Q", InterviewType: "general", Translations: map[string]string{"fr": "Q"}},
+	}
+	for _, c := range cases {
+		b, _ := json.Marshal(c)
+		expectHTTPError(t, router, "POST", "/questions", b, http.StatusBadRequest)
+	}
+}
+
+func TestCreateInterviewHandler_WithQuestionBank(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	question := createTestQuestion(t, router, QuestionRequestDTO{
+		Text:          "Why do you want this role?",
+		Translations:  map[string]string{"zh-TW": "你為什麼想要這個職位？"},
+		InterviewType: "general",
+	})
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName:     "Bank User",
+		QuestionIDs:       []string{question.ID},
+		Questions:         []string{"Anything else?"},
+		InterviewType:     "general",
+		InterviewLanguage: "zh-TW",
+	})
+	if len(interview.Questions) != 2 || interview.Questions[0] != "你為什麼想要這個職位？" {
+		t.Errorf("expected localized bank question followed by inline question, got %v", interview.Questions)
+	}
+
+	// Usage count reflects the new reference
+	req := httptest.NewRequest("GET", "/questions/"+question.ID, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var fetched QuestionResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed to unmarshal question response: %v", err)
+	}
+	if fetched.UsageCount != 1 {
+		t.Errorf("expected usage count 1, got %d", fetched.UsageCount)
+	}
+
+	// Unknown bank IDs are rejected
+	b, _ := json.Marshal(CreateInterviewRequestDTO{
+		CandidateName: "Bank User",
+		QuestionIDs:   []string{"missing"},
+		InterviewType: "general",
+	})
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusBadRequest)
+
+	// Referenced questions cannot be deleted, so the interview keeps its question order
+	expectHTTPError(t, router, "DELETE", "/questions/"+question.ID, nil, http.StatusConflict)
+}
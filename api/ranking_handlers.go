@@ -0,0 +1,347 @@
+// HTTP handler functions for ranking and comparing candidates
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Candidate ranking and comparison limits
+const (
+	maxRankingCandidates = 500
+	minCompareCandidates = 2
+	maxCompareCandidates = 5
+)
+
+// Helper: category scores to show for an evaluation, preferring the reviewer's adjustments
+func finalCategoryScores(evaluation *data.Evaluation) map[string]float64 {
+	if evaluation.HumanCategoryScores != nil {
+		return evaluation.HumanCategoryScores
+	}
+	return evaluation.CategoryScores
+}
+
+// Helper: link to the transcript of the chat session an evaluation scored, or "" when it has none
+func transcriptURL(evaluation *data.Evaluation) string {
+	if evaluation != nil && evaluation.SessionID != nil {
+		return "/chat/" + *evaluation.SessionID
+	}
+	return ""
+}
+
+// questionAnswer is a question put to a candidate and their answer to it
+type questionAnswer struct {
+	Question string
+	Answer   string
+}
+
+// Helper: the questions a candidate was asked with the answers behind their evaluation. Chat
+// evaluations key answers by reply order rather than by configured question, so their pairs come
+// from the transcript instead: each reply is matched with the AI message it answered. Partial
+// evaluations whose abandoned session was purged have no transcript left and yield no pairs.
+func evaluationAnswers(interview *data.Interview, evaluation *data.Evaluation) ([]questionAnswer, error) {
+	if evaluation != nil && (evaluation.SessionID != nil || evaluation.Partial) {
+		if evaluation.SessionID == nil {
+			return nil, nil
+		}
+		messages, err := data.GlobalStore.GetChatMessages(*evaluation.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		var pairs []questionAnswer
+		question, answered := "", false
+		for _, msg := range messages {
+			switch {
+			case msg.Type == "ai":
+				question, answered = msg.Content, false
+			case msg.Type != "user" || question == "":
+				continue
+			case answered:
+				pairs[len(pairs)-1].Answer += "\n\n" + msg.Content
+			default:
+				pairs = append(pairs, questionAnswer{Question: question, Answer: msg.Content})
+				answered = true
+			}
+		}
+		return pairs, nil
+	}
+
+	questions := resolveInterviewQuestions(interview)
+	pairs := make([]questionAnswer, len(questions))
+	for q, question := range questions {
+		pairs[q].Question = question
+		if evaluation != nil {
+			pairs[q].Answer = evaluation.Answers[fmt.Sprintf("question_%d", q)]
+		}
+	}
+	return pairs, nil
+}
+
+// Helper: sorted union of category keys
+func categoryKeys(scoreMaps ...map[string]float64) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	for _, scores := range scoreMaps {
+		for key := range scores {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Helper: non-nil copy of a string list so JSON renders [] rather than null
+func stringList(values []string) []string {
+	return append([]string{}, values...)
+}
+
+// Helper: fetch interviews by ID in request order, dropping duplicates; returns the first missing ID on failure
+func interviewsByID(ids []string) ([]*data.Interview, string) {
+	interviews := make([]*data.Interview, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		interview, err := data.GlobalStore.GetInterview(id)
+		if err != nil {
+			return nil, id
+		}
+		interviews = append(interviews, interview)
+	}
+	return interviews, ""
+}
+
+// Helper: report whether the caller may see every selected interview, writing a 403 if not
+func canAccessAllInterviews(w http.ResponseWriter, r *http.Request, interviews []*data.Interview) bool {
+	for _, interview := range interviews {
+		if !canAccessInterview(r, interview) {
+			writeForbidden(w, r, "Recruiters may only access their own interviews: "+interview.ID, PermEvaluationsRead)
+			return false
+		}
+	}
+	return true
+}
+
+// RankCandidatesHandler handles POST /rankings
+// Ranks the selected interviews' candidates by the final score of their latest evaluation.
+func RankCandidatesHandler(w http.ResponseWriter, r *http.Request) {
+	var req RankCandidatesRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	jobDescription := strings.TrimSpace(req.JobDescription)
+	selectors := 0
+	for _, set := range []bool{jobDescription != "", req.TemplateID != "", req.PositionID != "", len(req.InterviewIDs) > 0} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		writeJSONError(w, http.StatusBadRequest, "Provide exactly one of job_description, template_id, position_id or interview_ids")
+		return
+	}
+
+	var interviews []*data.Interview
+	switch {
+	case len(req.InterviewIDs) > 0:
+		if len(req.InterviewIDs) > maxRankingCandidates {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("At most %d interviews can be ranked", maxRankingCandidates))
+			return
+		}
+		var missing string
+		if interviews, missing = interviewsByID(req.InterviewIDs); missing != "" {
+			writeJSONError(w, http.StatusNotFound, "Interview not found: "+missing)
+			return
+		}
+		if !canAccessAllInterviews(w, r, interviews) {
+			return
+		}
+	default:
+		// Recruiters only rank the interviews they created
+		opts := data.ListInterviewsOptions{Limit: maxRankingCandidates, JobDescription: jobDescription, CreatedBy: ownerScope(r)}
+		if req.TemplateID != "" {
+			if _, err := data.GlobalStore.GetInterviewTemplate(req.TemplateID); err != nil {
+				writeJSONError(w, http.StatusNotFound, "Template not found")
+				return
+			}
+			opts.TemplateID = req.TemplateID
+		}
+		if req.PositionID != "" {
+			if _, err := data.GlobalStore.GetPosition(req.PositionID); err != nil {
+				writeJSONError(w, http.StatusNotFound, "Position not found")
+				return
+			}
+			opts.PositionID = req.PositionID
+		}
+		result, err := data.GlobalStore.GetInterviewsWithOptions(opts)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to fetch interviews", err.Error())
+			return
+		}
+		interviews = result.Interviews
+	}
+
+	interviewIDs := make([]string, len(interviews))
+	for i, interview := range interviews {
+		interviewIDs[i] = interview.ID
+	}
+	evaluations, err := data.GlobalStore.GetLatestEvaluations(interviewIDs)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch evaluations", err.Error())
+		return
+	}
+
+	resp := RankCandidatesResponseDTO{
+		Rankings:    []CandidateRankingDTO{},
+		Unevaluated: []string{},
+	}
+	var scoreMaps []map[string]float64
+	for _, interview := range interviews {
+		evaluation, ok := evaluations[interview.ID]
+		if !ok {
+			resp.Unevaluated = append(resp.Unevaluated, interview.ID)
+			continue
+		}
+		categoryScores := finalCategoryScores(evaluation)
+		scoreMaps = append(scoreMaps, categoryScores)
+		resp.Rankings = append(resp.Rankings, CandidateRankingDTO{
+			InterviewID:    interview.ID,
+			CandidateName:  interview.CandidateName,
+			EvaluationID:   evaluation.ID,
+			Score:          evaluation.FinalScore(),
+			AIScore:        evaluation.Score,
+			CategoryScores: categoryScores,
+			Decision:       evaluation.Decision,
+			Strengths:      stringList(evaluation.Strengths),
+			Weaknesses:     stringList(evaluation.Weaknesses),
+			NeedsReview:    evaluation.NeedsReview,
+			LowConfidence:  evaluation.LowConfidence,
+			EvaluatedAt:    evaluation.CreatedAt,
+			EvaluationURL:  "/evaluation/" + evaluation.ID,
+			TranscriptURL:  transcriptURL(evaluation),
+		})
+	}
+	resp.Categories = categoryKeys(scoreMaps...)
+
+	// Highest score first; earlier evaluations, then names, break ties so the order is stable
+	sort.SliceStable(resp.Rankings, func(i, j int) bool {
+		a, b := resp.Rankings[i], resp.Rankings[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.EvaluatedAt.Equal(b.EvaluatedAt) {
+			return a.EvaluatedAt.Before(b.EvaluatedAt)
+		}
+		return a.CandidateName < b.CandidateName
+	})
+	// Equal scores share a rank (1, 1, 3)
+	for i := range resp.Rankings {
+		if i > 0 && resp.Rankings[i].Score == resp.Rankings[i-1].Score {
+			resp.Rankings[i].Rank = resp.Rankings[i-1].Rank
+		} else {
+			resp.Rankings[i].Rank = i + 1
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CompareCandidatesHandler handles POST /rankings/compare
+// Lines up two to five candidates' scores and their answers to each question side by side.
+func CompareCandidatesHandler(w http.ResponseWriter, r *http.Request) {
+	var req CompareCandidatesRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	interviews, missing := interviewsByID(req.InterviewIDs)
+	if missing != "" {
+		writeJSONError(w, http.StatusNotFound, "Interview not found: "+missing)
+		return
+	}
+	if !canAccessAllInterviews(w, r, interviews) {
+		return
+	}
+	if len(interviews) < minCompareCandidates || len(interviews) > maxCompareCandidates {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Comparison requires %d to %d distinct interviews", minCompareCandidates, maxCompareCandidates))
+		return
+	}
+
+	interviewIDs := make([]string, len(interviews))
+	for i, interview := range interviews {
+		interviewIDs[i] = interview.ID
+	}
+	evaluations, err := data.GlobalStore.GetLatestEvaluations(interviewIDs)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch evaluations", err.Error())
+		return
+	}
+
+	resp := CompareCandidatesResponseDTO{
+		Candidates: make([]ComparisonCandidateDTO, len(interviews)),
+		Questions:  []QuestionComparisonDTO{},
+	}
+	var scoreMaps []map[string]float64
+	// Questions are matched by text so shared questions line up even when their order differs
+	questionIndex := make(map[string]int)
+	for i, interview := range interviews {
+		candidate := ComparisonCandidateDTO{
+			InterviewID:   interview.ID,
+			CandidateName: interview.CandidateName,
+			Strengths:     []string{},
+			Weaknesses:    []string{},
+		}
+		evaluation := evaluations[interview.ID]
+		candidate.TranscriptURL = transcriptURL(evaluation)
+		if evaluation != nil {
+			score := evaluation.FinalScore()
+			candidate.EvaluationID = evaluation.ID
+			candidate.Score = &score
+			candidate.CategoryScores = finalCategoryScores(evaluation)
+			candidate.Decision = evaluation.Decision
+			candidate.Strengths = stringList(evaluation.Strengths)
+			candidate.Weaknesses = stringList(evaluation.Weaknesses)
+			scoreMaps = append(scoreMaps, candidate.CategoryScores)
+		}
+		resp.Candidates[i] = candidate
+
+		pairs, err := evaluationAnswers(interview, evaluation)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to fetch chat messages", err.Error())
+			return
+		}
+		for _, pair := range pairs {
+			row, ok := questionIndex[pair.Question]
+			if !ok {
+				row = len(resp.Questions)
+				questionIndex[pair.Question] = row
+				resp.Questions = append(resp.Questions, QuestionComparisonDTO{
+					Question: pair.Question,
+					Answers:  make([]string, len(interviews)),
+				})
+			}
+			if answer := resp.Questions[row].Answers[i]; answer != "" {
+				resp.Questions[row].Answers[i] = answer + "\n\n" + pair.Answer
+			} else {
+				resp.Questions[row].Answers[i] = pair.Answer
+			}
+		}
+	}
+	resp.Categories = categoryKeys(scoreMaps...)
+
+	writeJSON(w, http.StatusOK, resp)
+}
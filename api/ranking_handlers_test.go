@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// createRankedCandidate stores an interview with a job description and, when score >= 0, an evaluation
+func createRankedCandidate(t *testing.T, name string, score float64, answers map[string]string) *data.Interview {
+	t.Helper()
+	interview := &data.Interview{
+		ID:             data.GenerateID(),
+		CandidateName:  name,
+		Questions:      []string{"Design a cache", "Describe a conflict"},
+		JobDescription: "Backend Engineer",
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := data.GlobalStore.CreateInterview(interview); err != nil {
+		t.Fatalf("failed to create interview: %v", err)
+	}
+	if score >= 0 {
+		evaluation := &data.Evaluation{
+			ID:             data.GenerateID(),
+			InterviewID:    interview.ID,
+			Answers:        answers,
+			Score:          score,
+			Strengths:      data.StringArray{"clear communication"},
+			CategoryScores: data.FloatMap{"design": score},
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+		if err := data.GlobalStore.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("failed to create evaluation: %v", err)
+		}
+	}
+	return interview
+}
+
+// createChatRankedCandidate stores an interview held by chat with the given replies and an evaluation
+// of that session, keying the answers by reply order as chat evaluations do
+func createChatRankedCandidate(t *testing.T, router http.Handler, name string, score float64, replies ...string) (*data.Interview, string) {
+	t.Helper()
+	interview := createRankedCandidate(t, name, -1, nil)
+	session := startChatSession(t, router, interview.ID, nil)
+	answers := make(map[string]string)
+	for i, reply := range replies {
+		for _, message := range []*data.ChatMessage{
+			{ID: data.GenerateID(), SessionID: session.ID, Type: "user", Content: reply, Timestamp: time.Now()},
+			{ID: data.GenerateID(), SessionID: session.ID, Type: "ai", Content: fmt.Sprintf("Follow-up %d", i), Timestamp: time.Now()},
+		} {
+			if err := data.GlobalStore.AddChatMessage(session.ID, message); err != nil {
+				t.Fatalf("failed to add chat message: %v", err)
+			}
+		}
+		answers[fmt.Sprintf("question_%d", i)] = reply
+	}
+	evaluation := &data.Evaluation{
+		ID:             data.GenerateID(),
+		InterviewID:    interview.ID,
+		SessionID:      &session.ID,
+		Answers:        answers,
+		Score:          score,
+		CategoryScores: data.FloatMap{"design": score},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := data.GlobalStore.CreateEvaluation(evaluation); err != nil {
+		t.Fatalf("failed to create evaluation: %v", err)
+	}
+	return interview, session.ID
+}
+
+func TestRankCandidatesHandler(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	low := createRankedCandidate(t, "Low", 0.5, nil)
+	high, sessionID := createChatRankedCandidate(t, router, "High", 0.9)
+	overridden := createRankedCandidate(t, "Overridden", 0.6, nil)
+	pending := createRankedCandidate(t, "Pending", -1, nil)
+	// A session started after the answer-form evaluation is not what Low was scored on
+	startChatSession(t, router, low.ID, nil)
+
+	// A human override lifts the third candidate to the top score, tying with High
+	humanScore := 0.9
+	evaluations, _ := data.GlobalStore.GetLatestEvaluations([]string{overridden.ID})
+	reviewEvaluation(t, router, evaluations[overridden.ID].ID, ReviewEvaluationRequestDTO{Reviewer: "alice", Score: &humanScore})
+
+	var resp RankCandidatesResponseDTO
+	doJSONRequest(t, router, "POST", "/rankings", RankCandidatesRequestDTO{JobDescription: "Backend Engineer"}, http.StatusOK, &resp)
+	if len(resp.Rankings) != 3 || len(resp.Unevaluated) != 1 || resp.Unevaluated[0] != pending.ID {
+		t.Fatalf("expected 3 ranked and 1 unevaluated candidate, got %+v", resp)
+	}
+	first, second, third := resp.Rankings[0], resp.Rankings[1], resp.Rankings[2]
+	if first.InterviewID != high.ID || second.InterviewID != overridden.ID || third.InterviewID != low.ID {
+		t.Errorf("unexpected ranking order: %+v", resp.Rankings)
+	}
+	if first.Rank != 1 || second.Rank != 1 || third.Rank != 3 {
+		t.Errorf("expected tied ranks 1, 1, 3, got %d, %d, %d", first.Rank, second.Rank, third.Rank)
+	}
+	if second.AIScore != 0.6 || second.Score != 0.9 {
+		t.Errorf("expected final score to use the human override, got %+v", second)
+	}
+	if first.TranscriptURL != "/chat/"+sessionID || third.TranscriptURL != "" {
+		t.Errorf("unexpected transcript links: %q, %q", first.TranscriptURL, third.TranscriptURL)
+	}
+	if len(second.Strengths) != 1 || len(resp.Categories) != 1 || resp.Categories[0] != "design" {
+		t.Errorf("expected strengths and categories, got %+v", resp)
+	}
+
+	// Explicit interview sets are ranked too
+	doJSONRequest(t, router, "POST", "/rankings", RankCandidatesRequestDTO{InterviewIDs: []string{low.ID, high.ID}}, http.StatusOK, &resp)
+	if len(resp.Rankings) != 2 || resp.Rankings[0].InterviewID != high.ID {
+		t.Errorf("unexpected ranking for interview set: %+v", resp.Rankings)
+	}
+
+	doJSONRequest(t, router, "POST", "/rankings", RankCandidatesRequestDTO{}, http.StatusBadRequest, nil)
+	doJSONRequest(t, router, "POST", "/rankings", RankCandidatesRequestDTO{TemplateID: "x", JobDescription: "y"}, http.StatusBadRequest, nil)
+	doJSONRequest(t, router, "POST", "/rankings", RankCandidatesRequestDTO{TemplateID: "missing"}, http.StatusNotFound, nil)
+	doJSONRequest(t, router, "POST", "/rankings", RankCandidatesRequestDTO{InterviewIDs: []string{"missing"}}, http.StatusNotFound, nil)
+}
+
+func TestCompareCandidatesHandler(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	alice := createRankedCandidate(t, "Alice", 0.8, map[string]string{"question_0": "LRU", "question_1": "Talked it out"})
+	bob := createRankedCandidate(t, "Bob", 0.7, map[string]string{"question_0": "Write-through"})
+	carol := createRankedCandidate(t, "Carol", -1, nil)
+	dave, sessionID := createChatRankedCandidate(t, router, "Dave", 0.6, "Hi, I'm Dave", "Consistent hashing")
+
+	var resp CompareCandidatesResponseDTO
+	doJSONRequest(t, router, "POST", "/rankings/compare", CompareCandidatesRequestDTO{InterviewIDs: []string{alice.ID, bob.ID, carol.ID, dave.ID}}, http.StatusOK, &resp)
+	if len(resp.Candidates) != 4 || resp.Candidates[2].Score != nil || *resp.Candidates[0].Score != 0.8 {
+		t.Fatalf("unexpected candidates: %+v", resp.Candidates)
+	}
+	if resp.Candidates[0].TranscriptURL != "" || resp.Candidates[3].TranscriptURL != "/chat/"+sessionID {
+		t.Errorf("expected only the chat evaluation to link a transcript, got %+v", resp.Candidates)
+	}
+	// Two configured questions, then the greeting and one follow-up Dave answered by chat
+	if len(resp.Questions) != 4 {
+		t.Fatalf("expected shared questions to line up in 4 rows, got %+v", resp.Questions)
+	}
+	cache := resp.Questions[0]
+	if cache.Question != "Design a cache" || cache.Answers[0] != "LRU" || cache.Answers[1] != "Write-through" || cache.Answers[2] != "" || cache.Answers[3] != "" {
+		t.Errorf("unexpected answers for first question: %+v", cache)
+	}
+	if resp.Questions[1].Answers[1] != "" {
+		t.Errorf("expected an empty answer for an unanswered question, got %+v", resp.Questions[1])
+	}
+	// Chat replies sit next to the AI message they answered, not the configured question at their index
+	if greeting := resp.Questions[2]; greeting.Answers[3] != "Hi, I'm Dave" || greeting.Answers[0] != "" {
+		t.Errorf("expected the first reply to answer the greeting, got %+v", greeting)
+	}
+	if followUp := resp.Questions[3]; followUp.Question != "Follow-up 0" || followUp.Answers[3] != "Consistent hashing" {
+		t.Errorf("expected the second reply to answer the follow-up, got %+v", followUp)
+	}
+
+	doJSONRequest(t, router, "POST", "/rankings/compare", CompareCandidatesRequestDTO{InterviewIDs: []string{alice.ID, alice.ID}}, http.StatusBadRequest, nil)
+	doJSONRequest(t, router, "POST", "/rankings/compare", CompareCandidatesRequestDTO{InterviewIDs: []string{alice.ID, "missing"}}, http.StatusNotFound, nil)
+}
@@ -0,0 +1,238 @@
+// Role-based access control: the permissions of each role and their per-route checks
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Role is the set of permissions a principal acts with
+type Role string
+
+// Roles a principal can have
+const (
+	RoleAdmin     Role = "admin"     // Manages prompts (templates), providers, keys and webhooks, and can do anything else
+	RoleRecruiter Role = "recruiter" // Creates interviews and positions and sees only their own
+	RoleReviewer  Role = "reviewer"  // Reads every interview and scores evaluations
+	RoleCandidate Role = "candidate" // Takes the one interview their invitation is for
+)
+
+// Permission is an action a route requires
+type Permission string
+
+// Permissions routes can require
+const (
+	PermInterviewsRead    Permission = "interviews:read"
+	PermInterviewsWrite   Permission = "interviews:write"
+	PermSessionsRead      Permission = "sessions:read"
+	PermSessionsWrite     Permission = "sessions:write"
+	PermCandidatesRead    Permission = "candidates:read"
+	PermCandidatesWrite   Permission = "candidates:write"
+	PermPositionsRead     Permission = "positions:read"
+	PermPositionsWrite    Permission = "positions:write"
+	PermQuestionsRead     Permission = "questions:read"
+	PermQuestionsWrite    Permission = "questions:write"
+	PermTemplatesRead     Permission = "templates:read"
+	PermTemplatesWrite    Permission = "templates:write"
+	PermEvaluationsRead   Permission = "evaluations:read"
+	PermEvaluationsWrite  Permission = "evaluations:write"  // Queue evaluations and assign raters
+	PermEvaluationsReview Permission = "evaluations:review" // Review and rate evaluations
+	PermJobsRead          Permission = "jobs:read"
+	PermJobsManage        Permission = "jobs:manage"
+	PermWebhooksManage    Permission = "webhooks:manage"
+	PermAPIKeysManage     Permission = "api_keys:manage"
+)
+
+// rolePermissions lists what each role may do; admins may do everything
+var rolePermissions = map[Role][]Permission{
+	RoleRecruiter: {
+		PermInterviewsRead, PermInterviewsWrite, PermSessionsRead, PermSessionsWrite,
+		PermCandidatesRead, PermCandidatesWrite, PermPositionsRead, PermPositionsWrite,
+		PermQuestionsRead, PermQuestionsWrite, PermTemplatesRead,
+		PermEvaluationsRead, PermEvaluationsWrite, PermJobsRead,
+	},
+	RoleReviewer: {
+		PermInterviewsRead, PermSessionsRead, PermCandidatesRead, PermPositionsRead,
+		PermQuestionsRead, PermTemplatesRead, PermEvaluationsRead, PermEvaluationsReview, PermJobsRead,
+	},
+	RoleCandidate: {PermSessionsRead, PermSessionsWrite},
+}
+
+// Can reports whether the role grants the permission
+func (role Role) Can(perm Permission) bool {
+	return role == RoleAdmin || slices.Contains(rolePermissions[role], perm)
+}
+
+// parseStaffRole returns the staff role with the given name; candidates are only admitted by invitation
+func parseStaffRole(name string) (Role, bool) {
+	switch role := Role(name); role {
+	case RoleAdmin, RoleRecruiter, RoleReviewer:
+		return role, true
+	}
+	return "", false
+}
+
+// routeScope names the route parameter a permission is checked against, beyond the role itself
+type routeScope string
+
+// Route scopes
+const (
+	scopeNone       routeScope = ""
+	scopeInterview  routeScope = "interview"  // {id} is an interview
+	scopeSession    routeScope = "session"    // {sessionId} is a chat session
+	scopeEvaluation routeScope = "evaluation" // {id} is an evaluation
+	scopePosition   routeScope = "position"   // {id} is a position
+	scopeCandidate  routeScope = "candidate"  // {id} is a candidate
+)
+
+// openModePrincipal acts for anonymous requests when authentication is disabled with AUTH_DISABLED,
+// as before authentication existed
+var openModePrincipal = &Principal{Role: RoleAdmin}
+
+// Helper: the principal a request is authorized as, or nil if it must authenticate first
+func (deps *HandlerDependencies) requestPrincipal(r *http.Request) *Principal {
+	if principal := principalFromContext(r.Context()); principal != nil {
+		return principal
+	}
+	if !deps.AuthRequired {
+		return openModePrincipal
+	}
+	return nil
+}
+
+// Helper: the creator recruiters' queries are limited to, or "" when the caller may see everything
+func ownerScope(r *http.Request) string {
+	if principal := principalFromContext(r.Context()); principal != nil && principal.Role == RoleRecruiter {
+		return principal.Subject
+	}
+	return ""
+}
+
+// Helper: the subject recorded as the creator of new interviews, positions and candidates
+func creatorOf(r *http.Request) string {
+	if principal := principalFromContext(r.Context()); principal != nil {
+		return principal.Subject
+	}
+	return ""
+}
+
+// Helper: report whether the caller may see the interview, i.e. it is not another recruiter's
+func canAccessInterview(r *http.Request, interview *data.Interview) bool {
+	owner := ownerScope(r)
+	return owner == "" || interview.CreatedBy == owner
+}
+
+// Helper: report whether the recruiter created the candidate or interviews them
+func candidateVisibleTo(candidate *data.Candidate, subject string) bool {
+	if candidate.CreatedBy == subject {
+		return true
+	}
+	linked, err := data.GlobalStore.GetInterviewsWithOptions(data.ListInterviewsOptions{Limit: 1, CandidateID: candidate.ID, CreatedBy: subject})
+	return err == nil && linked.Total > 0
+}
+
+// writeForbidden writes a 403 with the permission the route requires, if any, and the caller's role
+func writeForbidden(w http.ResponseWriter, r *http.Request, msg string, perm Permission) {
+	resp := ForbiddenResponseDTO{Error: msg, Code: "forbidden", Permission: string(perm)}
+	if principal := principalFromContext(r.Context()); principal != nil {
+		resp.Role = string(principal.Role)
+	}
+	writeJSON(w, http.StatusForbidden, resp)
+}
+
+// Helper: resolve the interview a scoped route refers to, returning the interview's ID and the
+// creator of the scoped resource; ok is false when the resource does not exist
+func scopedResource(r *http.Request, scope routeScope) (interviewID, owner string, ok bool) {
+	switch scope {
+	case scopeInterview:
+		interview, err := data.GlobalStore.GetInterview(chi.URLParam(r, "id"))
+		if err != nil {
+			return "", "", false
+		}
+		return interview.ID, interview.CreatedBy, true
+	case scopeSession:
+		session, err := data.GlobalStore.GetChatSession(chi.URLParam(r, "sessionId"))
+		if err != nil {
+			return "", "", false
+		}
+		interviewID = session.InterviewID
+	case scopeEvaluation:
+		evaluation, err := data.GlobalStore.GetEvaluation(chi.URLParam(r, "id"))
+		if err != nil {
+			return "", "", false
+		}
+		interviewID = evaluation.InterviewID
+	case scopePosition:
+		position, err := data.GlobalStore.GetPosition(chi.URLParam(r, "id"))
+		if err != nil {
+			return "", "", false
+		}
+		return "", position.CreatedBy, true
+	default:
+		return "", "", false
+	}
+	if interview, err := data.GlobalStore.GetInterview(interviewID); err == nil {
+		owner = interview.CreatedBy
+	}
+	return interviewID, owner, true
+}
+
+// Helper: check the principal against the route's resource, returning a message if it is denied.
+// Candidates are held to their invitation's interview and recruiters to what they created, plus the
+// candidates on their interviews; missing
+// resources are left to the handler's 404, except that candidates cannot tell them from others'.
+func authorizeScope(principal *Principal, r *http.Request, scope routeScope) string {
+	switch principal.Role {
+	case RoleCandidate:
+		interviewID, _, ok := scopedResource(r, scope)
+		if !ok && scope == scopeSession {
+			return "Invitation does not grant access to this session"
+		}
+		if principal.Invitation == nil || interviewID != principal.Invitation.InterviewID {
+			return "Invitation does not grant access to this interview"
+		}
+	case RoleRecruiter:
+		if scope == scopeNone {
+			return ""
+		}
+		if scope == scopeCandidate {
+			if candidate, err := data.GlobalStore.GetCandidate(chi.URLParam(r, "id")); err == nil && !candidateVisibleTo(candidate, principal.Subject) {
+				return "Recruiters may only access their own candidates and those on their interviews"
+			}
+			return ""
+		}
+		if _, owner, ok := scopedResource(r, scope); ok && owner != principal.Subject {
+			if scope == scopePosition {
+				return "Recruiters may only access their own positions"
+			}
+			return "Recruiters may only access their own interviews"
+		}
+	}
+	return ""
+}
+
+// Authorize requires the permission for a route, and with a scope also that the caller may reach
+// the route's resource. Requests without credentials get 401 and denied ones a ForbiddenResponseDTO.
+func (deps *HandlerDependencies) Authorize(perm Permission, scope routeScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := deps.requestPrincipal(r)
+			if principal == nil {
+				writeJSONError(w, http.StatusUnauthorized, "Credentials required")
+				return
+			}
+			if !principal.Role.Can(perm) {
+				writeForbidden(w, r, "Permission denied", perm)
+				return
+			}
+			if msg := authorizeScope(principal, r, scope); msg != "" {
+				writeForbidden(w, r, msg, perm)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// setupRoleRouter creates a router that accepts the staff token and HS256 JWTs
+func setupRoleRouter() http.Handler {
+	return SetupRouter(&config.Config{
+		OpenAIAPIKey:     "test-openai-key",
+		GeminiAPIKey:     "test-gemini-key",
+		StaffAPIToken:    testStaffToken,
+		JWTSecret:        "jwt-secret",
+		InvitationSecret: testInvitationSecret,
+	})
+}
+
+// roleBearer returns an Authorization value for a JWT user with the given role
+func roleBearer(t *testing.T, subject string, role Role) string {
+	t.Helper()
+	claims := map[string]interface{}{"sub": subject, "role": string(role), "exp": time.Now().Add(time.Hour).Unix()}
+	return "Bearer " + signTestJWT(t, map[string]interface{}{"alg": "HS256"}, claims, []byte("jwt-secret"), nil)
+}
+
+func TestRole_Can(t *testing.T) {
+	tests := []struct {
+		role Role
+		perm Permission
+		want bool
+	}{
+		{RoleAdmin, PermTemplatesWrite, true},
+		{RoleAdmin, PermAPIKeysManage, true},
+		{RoleRecruiter, PermInterviewsWrite, true},
+		{RoleRecruiter, PermTemplatesWrite, false},
+		{RoleRecruiter, PermEvaluationsReview, false},
+		{RoleReviewer, PermEvaluationsReview, true},
+		{RoleReviewer, PermInterviewsWrite, false},
+		{RoleCandidate, PermSessionsWrite, true},
+		{RoleCandidate, PermInterviewsRead, false},
+		{"", PermInterviewsRead, false},
+	}
+	for _, tt := range tests {
+		if got := tt.role.Can(tt.perm); got != tt.want {
+			t.Errorf("%q.Can(%q) = %v, want %v", tt.role, tt.perm, got, tt.want)
+		}
+	}
+}
+
+func TestAuthorize_RecruitersSeeOnlyTheirOwn(t *testing.T) {
+	clearMemoryStore()
+	router := setupRoleRouter()
+	alice, bob := roleBearer(t, "alice", RoleRecruiter), roleBearer(t, "bob", RoleRecruiter)
+
+	b, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "general"})
+	w := serveWithHeader(router, "POST", "/interviews", b, "Authorization", alice)
+	var interview InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interview)
+	if w.Code != http.StatusCreated || interview.CreatedBy != "alice" {
+		t.Fatalf("expected the interview created by alice, got %d: %s", w.Code, w.Body.String())
+	}
+	_ = data.GlobalStore.CreateEvaluation(&data.Evaluation{ID: "eval-alice", InterviewID: interview.ID, Score: 0.8})
+	b, _ = json.Marshal(PositionRequestDTO{Title: "Backend Engineer"})
+	w = serveWithHeader(router, "POST", "/positions", b, "Authorization", alice)
+	var position PositionResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &position)
+
+	// Lists are scoped to the caller, and reviewers see everything
+	for _, tc := range []struct {
+		who  string
+		auth string
+		want int
+	}{{"alice", alice, 1}, {"bob", bob, 0}, {"a reviewer", roleBearer(t, "rita", RoleReviewer), 1}} {
+		var interviews ListInterviewsResponseDTO
+		_ = json.Unmarshal(serveWithHeader(router, "GET", "/interviews", nil, "Authorization", tc.auth).Body.Bytes(), &interviews)
+		var evaluations ListEvaluationsResponseDTO
+		_ = json.Unmarshal(serveWithHeader(router, "GET", "/evaluation", nil, "Authorization", tc.auth).Body.Bytes(), &evaluations)
+		var positions ListPositionsResponseDTO
+		_ = json.Unmarshal(serveWithHeader(router, "GET", "/positions", nil, "Authorization", tc.auth).Body.Bytes(), &positions)
+		if interviews.Total != tc.want || evaluations.Total != tc.want || positions.Total != tc.want {
+			t.Errorf("expected %s to see %d of each, got %d interviews, %d evaluations, %d positions",
+				tc.who, tc.want, interviews.Total, evaluations.Total, positions.Total)
+		}
+	}
+
+	// Another recruiter's resources are forbidden, while missing ones are still not found
+	w = serveWithHeader(router, "GET", "/interviews/"+interview.ID, nil, "Authorization", bob)
+	var forbidden ForbiddenResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &forbidden)
+	if w.Code != http.StatusForbidden || forbidden.Code != "forbidden" || forbidden.Permission != string(PermInterviewsRead) || forbidden.Role != string(RoleRecruiter) {
+		t.Errorf("expected a forbidden response naming the permission and role, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, path := range []string{"/evaluation/eval-alice", "/positions/" + position.ID} {
+		if w := serveWithHeader(router, "GET", path, nil, "Authorization", bob); w.Code != http.StatusForbidden {
+			t.Errorf("expected %s forbidden to another recruiter, got %d", path, w.Code)
+		}
+	}
+	if w := serveWithHeader(router, "GET", "/interviews/missing", nil, "Authorization", bob); w.Code != http.StatusNotFound {
+		t.Errorf("expected a missing interview not found, got %d", w.Code)
+	}
+	b, _ = json.Marshal(CompareCandidatesRequestDTO{InterviewIDs: []string{interview.ID}})
+	if w := serveWithHeader(router, "POST", "/rankings/compare", b, "Authorization", bob); w.Code != http.StatusForbidden {
+		t.Errorf("expected comparing another recruiter's interviews forbidden, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "GET", "/interviews/"+interview.ID, nil, "Authorization", alice); w.Code != http.StatusOK {
+		t.Errorf("expected alice to read her interview, got %d", w.Code)
+	}
+}
+
+func TestAuthorize_RolePermissions(t *testing.T) {
+	clearMemoryStore()
+	router := setupRoleRouter()
+	admin, recruiter, reviewer := "Bearer "+testStaffToken, roleBearer(t, "alice", RoleRecruiter), roleBearer(t, "rita", RoleReviewer)
+
+	template := []byte(`{"name":"Backend","questions":["Q1"],"interview_type":"technical"}`)
+	interview := []byte(`{"candidate_name":"Jane","questions":["Q1"],"interview_type":"general"}`)
+	tests := []struct {
+		name         string
+		method, path string
+		body         []byte
+		auth         string
+		want         int
+	}{
+		{"admins manage prompts", "POST", "/templates", template, admin, http.StatusCreated},
+		{"recruiters cannot edit prompts", "POST", "/templates", template, recruiter, http.StatusForbidden},
+		{"recruiters read templates", "GET", "/templates", nil, recruiter, http.StatusOK},
+		{"recruiters cannot manage keys", "GET", "/api-keys", nil, recruiter, http.StatusForbidden},
+		{"recruiters cannot manage webhooks", "GET", "/webhooks", nil, recruiter, http.StatusForbidden},
+		{"recruiters create interviews", "POST", "/interviews", interview, recruiter, http.StatusCreated},
+		{"reviewers cannot create interviews", "POST", "/interviews", interview, reviewer, http.StatusForbidden},
+		{"reviewers read interviews", "GET", "/interviews", nil, reviewer, http.StatusOK},
+		{"reviewers cannot list jobs", "GET", "/jobs", nil, reviewer, http.StatusForbidden},
+		{"credentials are still required", "GET", "/interviews", nil, "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		w := serveWithHeader(router, tt.method, tt.path, tt.body, "Authorization", tt.auth)
+		if w.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.want, w.Code, w.Body.String())
+		}
+	}
+
+	// API keys act with the role they were created with
+	w := serveWithHeader(router, "POST", "/api-keys", []byte(`{"name":"Scorer","role":"reviewer"}`), "Authorization", admin)
+	var key APIKeyResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &key)
+	if w.Code != http.StatusCreated || key.Role != string(RoleReviewer) {
+		t.Fatalf("expected a reviewer key, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := serveWithHeader(router, "POST", "/interviews", interview, apiKeyHeader, key.Key); w.Code != http.StatusForbidden {
+		t.Errorf("expected the reviewer key unable to create interviews, got %d", w.Code)
+	}
+	if w := serveWithHeader(router, "POST", "/api-keys", []byte(`{"name":"Bad","role":"candidate"}`), "Authorization", admin); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a candidate key rejected, got %d", w.Code)
+	}
+}
+
+func TestAuthorize_CandidateHistoryScoped(t *testing.T) {
+	clearMemoryStore()
+	router := setupRoleRouter()
+	alice, bob := roleBearer(t, "alice", RoleRecruiter), roleBearer(t, "bob", RoleRecruiter)
+
+	b, _ := json.Marshal(CandidateRequestDTO{Name: "Jane Doe", Email: "jane@example.com"})
+	w := serveWithHeader(router, "POST", "/candidates", b, "Authorization", alice)
+	var candidate CandidateResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &candidate)
+	if w.Code != http.StatusCreated || candidate.CreatedBy != "alice" {
+		t.Fatalf("expected the candidate created by alice, got %d: %s", w.Code, w.Body.String())
+	}
+	b, _ = json.Marshal(CreateInterviewRequestDTO{CandidateID: candidate.ID, Questions: []string{"Q1"}, InterviewType: "general"})
+	w = serveWithHeader(router, "POST", "/interviews", b, "Authorization", alice)
+	var interview InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &interview)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected alice's interview created, got %d: %s", w.Code, w.Body.String())
+	}
+	_ = data.GlobalStore.CreateChatSession(&data.ChatSession{ID: "session-alice", InterviewID: interview.ID, Status: "completed"})
+	_ = data.GlobalStore.CreateEvaluation(&data.Evaluation{ID: "eval-alice", InterviewID: interview.ID, Score: 0.8})
+
+	listed := func(auth string) int {
+		var candidates ListCandidatesResponseDTO
+		_ = json.Unmarshal(serveWithHeader(router, "GET", "/candidates", nil, "Authorization", auth).Body.Bytes(), &candidates)
+		return candidates.Total
+	}
+
+	// Another recruiter can neither list nor reach a candidate they did not create or interview
+	if n := listed(bob); n != 0 {
+		t.Errorf("expected bob to list no candidates, got %d", n)
+	}
+	b, _ = json.Marshal(CandidateRequestDTO{Name: "Jane Roe", Email: "jane@example.com"})
+	for _, req := range []struct {
+		method string
+		body   []byte
+	}{{"GET", nil}, {"PUT", b}, {"DELETE", nil}} {
+		if w := serveWithHeader(router, req.method, "/candidates/"+candidate.ID, req.body, "Authorization", bob); w.Code != http.StatusForbidden {
+			t.Errorf("expected %s of alice's candidate forbidden to bob, got %d", req.method, w.Code)
+		}
+	}
+	if w := serveWithHeader(router, "GET", "/candidates/missing", nil, "Authorization", bob); w.Code != http.StatusNotFound {
+		t.Errorf("expected a missing candidate not found, got %d", w.Code)
+	}
+
+	// Once bob interviews the candidate they are reachable to bob, but none of alice's interviews, sessions or evaluations
+	b, _ = json.Marshal(CreateInterviewRequestDTO{CandidateID: candidate.ID, Questions: []string{"Q1"}, InterviewType: "general"})
+	if w := serveWithHeader(router, "POST", "/interviews", b, "Authorization", bob); w.Code != http.StatusCreated {
+		t.Fatalf("expected bob's interview created, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, tc := range []struct {
+		who  string
+		auth string
+		want int
+	}{{"alice", alice, 1}, {"bob", bob, 1}, {"a reviewer", roleBearer(t, "rita", RoleReviewer), 2}} {
+		if n := listed(tc.auth); n != 1 {
+			t.Errorf("expected %s to list the candidate, got %d", tc.who, n)
+		}
+		w := serveWithHeader(router, "GET", "/candidates/"+candidate.ID, nil, "Authorization", tc.auth)
+		var history CandidateHistoryResponseDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &history)
+		if w.Code != http.StatusOK || history.Candidate.ID != candidate.ID || len(history.Interviews) != tc.want {
+			t.Errorf("expected %s to see the candidate with %d interviews, got %d: %s", tc.who, tc.want, w.Code, w.Body.String())
+			continue
+		}
+		for _, seen := range history.Interviews {
+			if seen.Interview.ID == interview.ID && (len(seen.Sessions) != 1 || len(seen.Evaluations) != 1) {
+				t.Errorf("expected %s to see alice's session and evaluation, got %+v", tc.who, seen)
+			}
+			if tc.who == "bob" && seen.Interview.ID == interview.ID {
+				t.Errorf("expected bob not to see alice's interview")
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// HTTP handler functions for candidate resume uploads
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/files"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Resume upload limits
+const (
+	defaultMaxUploadSize = 5 << 20 // Largest accepted resume when no limit is configured
+	multipartOverhead    = 1 << 20 // Allowance for multipart headers and boundaries around the file
+	resumeFormField      = "file"  // Multipart field holding the resume
+)
+
+// Helper: the configured upload size limit
+func (deps *HandlerDependencies) maxUploadSize() int64 {
+	if deps.MaxUploadSize > 0 {
+		return deps.MaxUploadSize
+	}
+	return defaultMaxUploadSize
+}
+
+// Helper: remove a blob that is no longer referenced, logging rather than failing the request;
+// reports whether the blob is gone
+func (deps *HandlerDependencies) deleteBlob(key string) bool {
+	if deps.BlobStore == nil || key == "" {
+		return true
+	}
+	if err := deps.BlobStore.Delete(key); err != nil {
+		utils.Errorf("Failed to delete blob %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+// Helper: remove a replaced resume's contents and then its record; the record stays with the
+// interview if the contents could not be deleted, so deleting the interview retries them
+func (deps *HandlerDependencies) deleteReplacedResume(file *data.File) {
+	if !deps.deleteBlob(file.StorageKey) {
+		return
+	}
+	if err := data.GlobalStore.DeleteFile(file.ID); err != nil {
+		utils.Errorf("Failed to delete replaced resume %s: %v", file.ID, err)
+	}
+}
+
+// Helper: convert a resume file to its response DTO
+func newResumeResponseDTO(file *data.File) ResumeResponseDTO {
+	dto := ResumeResponseDTO{
+		ID:            file.ID,
+		OriginalName:  file.OriginalName,
+		ContentType:   file.ContentType,
+		FileSize:      file.FileSize,
+		ExtractedText: file.ExtractedText,
+		CreatedAt:     file.CreatedAt,
+	}
+	if file.InterviewID != nil {
+		dto.InterviewID = *file.InterviewID
+	}
+	return dto
+}
+
+// Helper: load an interview's resume, writing a 404 if the interview or its resume does not exist
+func loadInterviewResume(w http.ResponseWriter, r *http.Request) (*data.File, bool) {
+	interview, err := data.GlobalStore.GetInterview(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return nil, false
+	}
+	if interview.ResumeFileID == "" {
+		writeJSONError(w, http.StatusNotFound, "Interview has no resume")
+		return nil, false
+	}
+	file, err := data.GlobalStore.GetFile(interview.ResumeFileID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview has no resume")
+		return nil, false
+	}
+	return file, true
+}
+
+// UploadResumeHandler handles POST /interviews/{id}/resume
+// Accepts a multipart upload of a PDF, DOCX or plain-text resume in the "file" field, stores it
+// and extracts its text for question generation and the interviewer prompt, then queues a job
+// parsing it into a candidate profile. A new upload replaces the interview's previous resume and profile.
+func (deps *HandlerDependencies) UploadResumeHandler(w http.ResponseWriter, r *http.Request) {
+	if deps.BlobStore == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "File uploads are not configured")
+		return
+	}
+	interview, err := data.GlobalStore.GetInterview(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+
+	maxSize := deps.maxUploadSize()
+	tooLarge := "Resume must be at most " + strconv.FormatInt(maxSize, 10) + " bytes"
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+	upload, header, err := r.FormFile(resumeFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, tooLarge)
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "Invalid upload: expected multipart/form-data with a \""+resumeFormField+"\" file", err.Error())
+		return
+	}
+	defer upload.Close()
+	if header.Size > maxSize {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, tooLarge)
+		return
+	}
+	content, err := io.ReadAll(io.LimitReader(upload, maxSize+1))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Failed to read upload", err.Error())
+		return
+	}
+	if int64(len(content)) > maxSize {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, tooLarge)
+		return
+	}
+	if len(content) == 0 {
+		writeJSONError(w, http.StatusBadRequest, "Resume file is empty")
+		return
+	}
+
+	contentType, err := files.DetectContentType(header.Header.Get("Content-Type"), header.Filename, content[:min(len(content), 512)])
+	if err != nil {
+		writeJSONError(w, http.StatusUnsupportedMediaType, "Unsupported resume format", err.Error())
+		return
+	}
+	text, err := files.ExtractText(contentType, content)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Failed to extract resume text", err.Error())
+		return
+	}
+
+	name := filepath.Base(header.Filename)
+	if name == "." || name == string(filepath.Separator) {
+		name = "resume"
+	}
+	interviewID := interview.ID
+	file := &data.File{
+		ID:            data.GenerateID(),
+		OriginalName:  name,
+		ContentType:   contentType,
+		FileSize:      int64(len(content)),
+		InterviewID:   &interviewID,
+		ExtractedText: text,
+	}
+	file.StorageKey = "resumes/" + file.ID + strings.ToLower(filepath.Ext(file.OriginalName))
+	if _, err := deps.BlobStore.Put(file.StorageKey, bytes.NewReader(content)); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to store resume", err.Error())
+		return
+	}
+
+	replaced, err := data.GlobalStore.AttachInterviewResume(file)
+	if err != nil {
+		deps.deleteBlob(file.StorageKey)
+		if errors.Is(err, data.ErrInterviewSessionActive) {
+			writeJSONError(w, http.StatusConflict, "Resume cannot be changed while a chat session is active")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to save resume", err.Error())
+		return
+	}
+	if replaced != nil {
+		deps.deleteReplacedResume(replaced)
+	}
+
+	resp := newResumeResponseDTO(file)
+	// The upload stands without a profile, which can be parsed again with POST /interviews/{id}/profile/parse
+	if job, err := deps.enqueueJob(data.JobTypeResumeParse, resumeParsePayload(interview.ID, file.ID)); err != nil {
+		utils.Errorf("Failed to queue resume parsing for interview %s: %v", interview.ID, err)
+	} else {
+		resp.ProfileJobID = job.ID
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// GetResumeHandler handles GET /interviews/{id}/resume
+// Returns the resume's details and extracted text.
+func GetResumeHandler(w http.ResponseWriter, r *http.Request) {
+	file, ok := loadInterviewResume(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newResumeResponseDTO(file))
+}
+
+// DownloadResumeHandler handles GET /interviews/{id}/resume/file
+// Streams the originally uploaded document.
+func (deps *HandlerDependencies) DownloadResumeHandler(w http.ResponseWriter, r *http.Request) {
+	if deps.BlobStore == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "File uploads are not configured")
+		return
+	}
+	file, ok := loadInterviewResume(w, r)
+	if !ok {
+		return
+	}
+	blob, err := deps.BlobStore.Get(file.StorageKey)
+	if err != nil {
+		if errors.Is(err, files.ErrBlobNotFound) {
+			writeJSONError(w, http.StatusNotFound, "Resume contents not found")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to read resume", err.Error())
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(file.FileSize, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.OriginalName}))
+	if _, err := io.Copy(w, blob); err != nil {
+		utils.Errorf("Failed to stream resume %s: %v", file.ID, err)
+	}
+}
+
+// GenerateResumeQuestionsHandler handles POST /interviews/{id}/resume/questions
+// Suggests interview questions from the resume, candidate profile and job description; nothing is saved, so the
+// recruiter can add the ones they want with PUT /interviews/{id}.
+func (deps *HandlerDependencies) GenerateResumeQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	interview, err := data.GlobalStore.GetInterview(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		return
+	}
+	if interview.ResumeText == "" {
+		writeJSONError(w, http.StatusBadRequest, "Interview has no resume; upload one with POST /interviews/{id}/resume")
+		return
+	}
+
+	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create AI client")
+		return
+	}
+	questions, err := aiClient.GenerateQuestionsForCandidate(interview.ResumeText, candidateProfileToAI(interview.CandidateProfile), interview.JobDescription)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to generate questions", err.Error())
+		return
+	}
+
+	resp := ResumeQuestionsResponseDTO{Questions: make([]ResumeQuestionDTO, 0, len(questions))}
+	for _, q := range questions {
+		resp.Questions = append(resp.Questions, ResumeQuestionDTO{
+			Question:   q.Question,
+			Category:   q.Category,
+			Difficulty: q.Difficulty,
+			FollowUp:   q.FollowUp,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
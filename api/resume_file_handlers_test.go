@@ -0,0 +1,240 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// uploadResume posts a file to the interview's resume endpoint and returns the recorder
+func uploadResume(router http.Handler, interviewID, filename, contentType string, content []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, _ := form.CreatePart(header)
+	_, _ = part.Write(content)
+	_ = form.Close()
+
+	req := httptest.NewRequest("POST", "/interviews/"+interviewID+"/resume", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// countBlobs returns the number of stored resume blobs
+func countBlobs(t *testing.T, dir string) int {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Join(dir, "resumes"))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("failed to read blob directory: %v", err)
+	}
+	return len(entries)
+}
+
+func TestResumeUpload(t *testing.T) {
+	clearMemoryStore()
+	uploadDir := t.TempDir()
+	router := SetupRouter(&config.Config{
+		OpenAIAPIKey:  "test-openai-key",
+		GeminiAPIKey:  "test-gemini-key",
+		UploadPath:    uploadDir,
+		MaxUploadSize: 4096,
+		AuthDisabled:  true,
+	})
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "technical"})
+
+	// Uploads are validated before anything is stored
+	if w := uploadResume(router, "missing", "cv.txt", "text/plain", []byte("Jane")); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing interview, got %d", w.Code)
+	}
+	if w := uploadResume(router, interview.ID, "cv.txt", "text/plain", bytes.Repeat([]byte("a"), 5000)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for an oversized file, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := uploadResume(router, interview.ID, "cv.png", "image/png", []byte{0x89, 'P', 'N', 'G', 0, 0}); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for an image, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := uploadResume(router, interview.ID, "cv.txt", "text/plain", []byte("  \n ")); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a file without text, got %d: %s", w.Code, w.Body.String())
+	}
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/resume", []byte(`{}`), http.StatusBadRequest)
+	expectHTTPError(t, router, "GET", "/interviews/"+interview.ID+"/resume", nil, http.StatusNotFound)
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/resume/questions", nil, http.StatusBadRequest)
+	if n := countBlobs(t, uploadDir); n != 0 {
+		t.Fatalf("expected no blobs after rejected uploads, got %d", n)
+	}
+
+	w := uploadResume(router, interview.ID, "jane.txt", "text/plain", []byte("Jane Doe\n\nLed the payment migration at Acme"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 uploading resume, got %d: %s", w.Code, w.Body.String())
+	}
+	var resume ResumeResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &resume)
+	if resume.ExtractedText != "Jane Doe\n\nLed the payment migration at Acme" || resume.ContentType != "text/plain" || resume.InterviewID != interview.ID {
+		t.Fatalf("unexpected resume: %+v", resume)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+interview.ID, nil))
+	var updated InterviewResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &updated)
+	if updated.ResumeFileID != resume.ID {
+		t.Errorf("expected interview to reference resume %s, got %q", resume.ID, updated.ResumeFileID)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+interview.ID+"/resume/file", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "Jane Doe\n\nLed the payment migration at Acme" || w.Header().Get("Content-Type") != "text/plain" {
+		t.Errorf("expected the original file, got %d %q (%s)", w.Code, w.Body.String(), w.Header().Get("Content-Type"))
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/interviews/"+interview.ID+"/resume/questions", nil))
+	var questions ResumeQuestionsResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &questions)
+	if w.Code != http.StatusOK || len(questions.Questions) == 0 {
+		t.Errorf("expected generated questions, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// A new upload replaces the previous resume and its blob
+	var docx bytes.Buffer
+	archive := zip.NewWriter(&docx)
+	document, _ := archive.Create("word/document.xml")
+	_, _ = document.Write([]byte(`<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Jane Doe, Go engineer</w:t></w:r></w:p></w:body></w:document>`))
+	_ = archive.Close()
+	w = uploadResume(router, interview.ID, "jane.docx", "application/octet-stream", docx.Bytes())
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 replacing resume, got %d: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+interview.ID+"/resume", nil))
+	_ = json.Unmarshal(w.Body.Bytes(), &resume)
+	if resume.ExtractedText != "Jane Doe, Go engineer" || resume.OriginalName != "jane.docx" {
+		t.Errorf("expected the replacement resume, got %+v", resume)
+	}
+	if n := countBlobs(t, uploadDir); n != 1 {
+		t.Errorf("expected the replaced blob deleted, got %d blobs", n)
+	}
+	if files, _ := data.GlobalStore.ListInterviewFiles(interview.ID); len(files) != 1 || files[0].ID != resume.ID {
+		t.Errorf("expected only the current resume recorded, got %+v", files)
+	}
+
+	// A replaced blob that cannot be deleted stays recorded and is removed with the interview
+	stuck := filepath.Join(uploadDir, "resumes", resume.ID+".docx")
+	_ = os.Remove(stuck)
+	_ = os.MkdirAll(filepath.Join(stuck, "locked"), 0o755)
+	if w := uploadResume(router, interview.ID, "jane.txt", "text/plain", []byte("Jane Doe")); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 replacing resume, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := data.GlobalStore.GetFile(resume.ID); err != nil {
+		t.Errorf("expected the replaced resume kept while its blob remains, got %v", err)
+	}
+	_ = os.Remove(filepath.Join(stuck, "locked"))
+
+	// The resume cannot change mid-interview
+	session := startChatSession(t, router, interview.ID, nil)
+	if w := uploadResume(router, interview.ID, "cv.txt", "text/plain", []byte("Someone else")); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 during an active session, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := countBlobs(t, uploadDir); n != 2 {
+		t.Errorf("expected the rejected upload's blob removed, got %d blobs", n)
+	}
+
+	// Deleting the interview removes every resume it stored
+	expectHTTPError(t, router, "POST", "/chat/"+session.ID+"/end", nil, http.StatusAccepted)
+	expectHTTPError(t, router, "DELETE", "/interviews/"+interview.ID+"?cascade=true", nil, http.StatusNoContent)
+	if n := countBlobs(t, uploadDir); n != 0 {
+		t.Errorf("expected the resume blobs deleted with the interview, got %d blobs", n)
+	}
+}
+
+func TestResumeUpload_NotConfigured(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "general"})
+	if w := uploadResume(router, interview.ID, "cv.txt", "text/plain", []byte("Jane")); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 without an upload directory, got %d", w.Code)
+	}
+}
+
+func TestCandidateProfile(t *testing.T) {
+	clearMemoryStore()
+	router := SetupRouter(&config.Config{
+		OpenAIAPIKey: "test-openai-key",
+		GeminiAPIKey: "test-gemini-key",
+		UploadPath:   t.TempDir(),
+		AuthDisabled: true,
+	})
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "technical"})
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/profile/parse", nil, http.StatusBadRequest)
+
+	// Uploading a resume queues a job parsing it into a profile
+	w := uploadResume(router, interview.ID, "jane.txt", "text/plain", []byte("Jane Doe\n\nLed the payment migration at Acme"))
+	var resume ResumeResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &resume)
+	if w.Code != http.StatusCreated || resume.ProfileJobID == "" {
+		t.Fatalf("expected a profile job with the upload, got %d: %s", w.Code, w.Body.String())
+	}
+	expectHTTPError(t, router, "GET", "/interviews/"+interview.ID+"/profile", nil, http.StatusNotFound)
+	runQueuedJobs()
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+interview.ID+"/profile", nil))
+	var profile CandidateProfileDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if w.Code != http.StatusOK || profile.Source != "ai" || len(profile.WorkHistory) == 0 || profile.WorkHistory[0].Company != "Acme" {
+		t.Fatalf("expected the parsed profile, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Recruiter edits are validated against the schema
+	invalid, _ := json.Marshal(CandidateProfileDTO{WorkHistory: []WorkExperienceDTO{{Company: "Acme", StartDate: "2021", EndDate: "2019"}}})
+	expectHTTPError(t, router, "PUT", "/interviews/"+interview.ID+"/profile", invalid, http.StatusBadRequest)
+	expectHTTPError(t, router, "PUT", "/interviews/"+interview.ID+"/profile", []byte(`{}`), http.StatusBadRequest)
+	expectHTTPError(t, router, "PUT", "/interviews/missing/profile", []byte(`{"skills":["Go"]}`), http.StatusNotFound)
+
+	profile.WorkHistory[0].Highlights = []string{"Ran the checkout rewrite"}
+	edited, _ := json.Marshal(profile)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/interviews/"+interview.ID+"/profile", bytes.NewReader(edited)))
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if w.Code != http.StatusOK || profile.Source != "recruiter" || profile.WorkHistory[0].Highlights[0] != "Ran the checkout rewrite" {
+		t.Fatalf("expected the edited profile, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, _ := data.GlobalStore.GetInterview(interview.ID)
+	if opts := chatOptionsForInterview(stored, "en"); opts.Profile == nil || opts.Profile.WorkHistory[0].Highlights[0] != "Ran the checkout rewrite" {
+		t.Errorf("expected the interviewer to use the edited profile, got %+v", opts.Profile)
+	}
+
+	// Re-parsing replaces the edits; a job for a replaced resume is dropped
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/interviews/"+interview.ID+"/profile/parse", nil))
+	var reparse JobResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &reparse)
+	if w.Code != http.StatusAccepted || reparse.Type != data.JobTypeResumeParse {
+		t.Fatalf("expected a queued parse job, got %d: %s", w.Code, w.Body.String())
+	}
+	uploadResume(router, interview.ID, "jane2.txt", "text/plain", []byte("Jane Doe, Go engineer"))
+	expectHTTPError(t, router, "GET", "/interviews/"+interview.ID+"/profile", nil, http.StatusNotFound)
+	runQueuedJobs()
+	if stale, _ := data.GlobalStore.GetJob(reparse.ID); stale.Status != data.JobStatusDead {
+		t.Errorf("expected the job for the replaced resume dead-lettered, got %s", stale.Status)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/interviews/"+interview.ID+"/profile", nil))
+	_ = json.Unmarshal(w.Body.Bytes(), &profile)
+	if w.Code != http.StatusOK || profile.Source != "ai" {
+		t.Errorf("expected the new resume parsed, got %d: %s", w.Code, w.Body.String())
+	}
+}
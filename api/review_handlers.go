@@ -0,0 +1,246 @@
+// HTTP handler functions for human review of evaluations
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/ai"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Helper: convert stored annotations to DTOs
+func annotationsToDTO(annotations data.Annotations) []AnnotationDTO {
+	if len(annotations) == 0 {
+		return nil
+	}
+	dtos := make([]AnnotationDTO, len(annotations))
+	for i, annotation := range annotations {
+		dtos[i] = AnnotationDTO(annotation)
+	}
+	return dtos
+}
+
+// Helper: convert an audit revision to its DTO
+func newEvaluationRevisionDTO(revision *data.EvaluationRevision) EvaluationRevisionDTO {
+	return EvaluationRevisionDTO{
+		Revision:       revision.Revision,
+		Source:         revision.Source,
+		Reviewer:       revision.Reviewer,
+		Score:          revision.Score,
+		CategoryScores: revision.CategoryScores,
+		Decision:       revision.Decision,
+		Comment:        revision.Comment,
+		Annotations:    annotationsToDTO(revision.Annotations),
+		CreatedAt:      revision.CreatedAt,
+	}
+}
+
+// Helper: validate a review request against the evaluation, returning an error message or "" if valid
+func validateReviewRequest(req *ReviewEvaluationRequestDTO, evaluation *data.Evaluation) string {
+	if len(req.CategoryScores) == 0 && req.Score == nil && req.Decision == "" && len(req.Annotations) == 0 {
+		return "No review changes provided"
+	}
+	if req.Decision != "" && !data.ValidateDecision(req.Decision) {
+		return "Invalid decision. Supported values: advance, hold, reject"
+	}
+	if req.Score != nil && (*req.Score < 0 || *req.Score > 1) {
+		return "Invalid score: must be between 0.0 and 1.0"
+	}
+
+	knownCategories := make(map[string]bool, len(evaluation.CategoryScores))
+	for key := range evaluation.CategoryScores {
+		knownCategories[key] = true
+	}
+	for _, criterion := range evaluation.Rubric.Criteria {
+		knownCategories[criterion.Key] = true
+	}
+	for key, score := range req.CategoryScores {
+		if !knownCategories[key] {
+			return "Unknown category: " + key
+		}
+		if score < 0 || score > 1 {
+			return fmt.Sprintf("Invalid score for %s: must be between 0.0 and 1.0", key)
+		}
+	}
+
+	for _, annotation := range req.Annotations {
+		if annotation.MessageID == "" || strings.TrimSpace(annotation.Comment) == "" {
+			return "Annotations require message_id and comment"
+		}
+		message, err := data.GlobalStore.GetChatMessage(annotation.MessageID)
+		if err != nil {
+			return "Annotation message not found: " + annotation.MessageID
+		}
+		session, err := data.GlobalStore.GetChatSession(message.SessionID)
+		if err != nil || session.InterviewID != evaluation.InterviewID {
+			return "Annotation message does not belong to this interview: " + annotation.MessageID
+		}
+	}
+	return ""
+}
+
+// Helper: derive the human overall score from adjusted category scores, using the
+// evaluation's rubric snapshot when there is one and the plain mean otherwise
+func humanScoreFromCategories(evaluation *data.Evaluation, categoryScores map[string]float64) float64 {
+	if !evaluation.Rubric.IsEmpty() {
+		score, _ := ai.ComputeWeightedScore(rubricToAI(evaluation.Rubric), categoryScores)
+		return score
+	}
+	if len(categoryScores) == 0 {
+		return evaluation.Score
+	}
+	var sum float64
+	for _, score := range categoryScores {
+		sum += score
+	}
+	return sum / float64(len(categoryScores))
+}
+
+// ReviewEvaluationHandler handles PUT /evaluation/{id}
+// Applies a reviewer's score adjustments, decision and transcript annotations. The AI
+// scores are left untouched and every review is appended to the evaluation's history.
+func ReviewEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingEvaluationID)
+		return
+	}
+
+	evaluation, err := data.GlobalStore.GetEvaluation(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Evaluation not found")
+		return
+	}
+
+	var req ReviewEvaluationRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	// The sign-off is recorded under the caller's own identity
+	reviewer, matches := requestIdentity(r, req.Reviewer)
+	if !matches {
+		writeForbidden(w, r, "reviewer does not match the signed-in principal", PermEvaluationsReview)
+		return
+	}
+	if reviewer == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing reviewer field")
+		return
+	}
+	if msg := validateReviewRequest(&req, evaluation); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Build the reviewed evaluation on a copy so a failed save leaves the stored one untouched
+	now := time.Now()
+	reviewed := *evaluation
+	reviewed.ReviewedBy = reviewer
+	reviewed.ReviewedAt = &now
+
+	if len(req.CategoryScores) > 0 {
+		base := evaluation.HumanCategoryScores
+		if base == nil {
+			base = evaluation.CategoryScores
+		}
+		merged := make(data.FloatMap, len(base)+len(req.CategoryScores))
+		for key, score := range base {
+			merged[key] = score
+		}
+		for key, score := range req.CategoryScores {
+			merged[key] = score
+		}
+		reviewed.HumanCategoryScores = merged
+
+		score := humanScoreFromCategories(evaluation, merged)
+		reviewed.HumanScore = &score
+	}
+	if req.Score != nil {
+		score := *req.Score
+		reviewed.HumanScore = &score
+	}
+	if req.Decision != "" {
+		reviewed.Decision = req.Decision
+	}
+
+	added := make(data.Annotations, len(req.Annotations))
+	for i, annotation := range req.Annotations {
+		added[i] = data.MessageAnnotation{
+			ID:        data.GenerateID(),
+			MessageID: annotation.MessageID,
+			Reviewer:  reviewer,
+			Comment:   strings.TrimSpace(annotation.Comment),
+			CreatedAt: now,
+		}
+	}
+	if len(added) > 0 {
+		reviewed.Annotations = append(append(data.Annotations{}, evaluation.Annotations...), added...)
+	}
+
+	categoryScores := reviewed.HumanCategoryScores
+	if categoryScores == nil {
+		categoryScores = reviewed.CategoryScores
+	}
+	revision := &data.EvaluationRevision{
+		ID:             data.GenerateID(),
+		EvaluationID:   evaluation.ID,
+		Source:         data.RevisionSourceHuman,
+		Reviewer:       reviewer,
+		Score:          reviewed.FinalScore(),
+		CategoryScores: categoryScores,
+		Decision:       reviewed.Decision,
+		Comment:        req.Comment,
+		Annotations:    added,
+	}
+	overridden := newWebhookEvent(data.WebhookEventEvaluationOverridden, newEvaluationResponseDTO(&reviewed))
+	if err := data.GlobalStore.ReviewEvaluation(&reviewed, revision, overridden); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to save review", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newEvaluationResponseDTO(&reviewed))
+}
+
+// GetEvaluationHistoryHandler handles GET /evaluation/{id}/history
+// Returns the AI original as revision 0 followed by every human revision.
+func GetEvaluationHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingEvaluationID)
+		return
+	}
+
+	evaluation, err := data.GlobalStore.GetEvaluation(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Evaluation not found")
+		return
+	}
+
+	revisions, err := data.GlobalStore.GetEvaluationRevisions(id)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch evaluation history", err.Error())
+		return
+	}
+
+	history := make([]EvaluationRevisionDTO, 0, len(revisions)+1)
+	history = append(history, EvaluationRevisionDTO{
+		Revision:       0,
+		Source:         data.RevisionSourceAI,
+		Score:          evaluation.Score,
+		CategoryScores: evaluation.CategoryScores,
+		CreatedAt:      evaluation.CreatedAt,
+	})
+	for _, revision := range revisions {
+		history = append(history, newEvaluationRevisionDTO(revision))
+	}
+
+	writeJSON(w, http.StatusOK, EvaluationHistoryResponseDTO{
+		EvaluationID: id,
+		Revisions:    history,
+	})
+}
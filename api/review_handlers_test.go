@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// reviewEvaluation submits a review and returns the updated evaluation
+func reviewEvaluation(t *testing.T, router http.Handler, evaluationID string, req ReviewEvaluationRequestDTO) EvaluationResponseDTO {
+	t.Helper()
+	var resp EvaluationResponseDTO
+	doJSONRequest(t, router, "PUT", "/evaluation/"+evaluationID, req, http.StatusOK, &resp)
+	return resp
+}
+
+func TestReviewEvaluationHandler_OverridesAndHistory(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Review Candidate",
+		Questions:     []string{"Design a cache"},
+		InterviewType: "technical",
+		Rubric: &RubricDTO{Criteria: []RubricCriterionDTO{
+			{Key: "design", Weight: 3},
+			{Key: "communication", Weight: 1},
+		}},
+	})
+	session := startChatSession(t, router, interview.ID, nil)
+	sent := sendMessage(t, router, session.ID, "I would use an LRU cache")
+
+	httpReq := httptest.NewRequest("POST", "/chat/"+session.ID+"/end", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+	evaluation := awaitEvaluation(t, router, w)
+	if evaluation.SignedOff || evaluation.FinalScore != evaluation.Score {
+		t.Fatalf("expected unreviewed evaluation to use the AI score, got %+v", evaluation)
+	}
+
+	// Unknown categories, bad decisions and foreign messages are rejected
+	for _, req := range []ReviewEvaluationRequestDTO{
+		{CategoryScores: map[string]float64{"design": 0.5}},
+		{Reviewer: "alice"},
+		{Reviewer: "alice", CategoryScores: map[string]float64{"charisma": 0.5}},
+		{Reviewer: "alice", Decision: "maybe"},
+		{Reviewer: "alice", Annotations: []AnnotationRequestDTO{{MessageID: "missing", Comment: "?"}}},
+	} {
+		b, _ := json.Marshal(req)
+		expectHTTPError(t, router, "PUT", "/evaluation/"+evaluation.ID, b, http.StatusBadRequest)
+	}
+
+	reviewed := reviewEvaluation(t, router, evaluation.ID, ReviewEvaluationRequestDTO{
+		Reviewer:       "alice",
+		CategoryScores: map[string]float64{"design": 0.5},
+		Comment:        "Missed cache invalidation",
+		Annotations:    []AnnotationRequestDTO{{MessageID: sent.Message.ID, Comment: "No eviction discussion"}},
+	})
+	// AI score is preserved; human score re-weights the rubric: (3*0.5 + 1*0.8) / 4
+	if reviewed.Score != evaluation.Score {
+		t.Errorf("expected AI score to be unchanged, got %v want %v", reviewed.Score, evaluation.Score)
+	}
+	if reviewed.HumanScore == nil || math.Abs(*reviewed.HumanScore-0.575) > 1e-9 || reviewed.FinalScore != *reviewed.HumanScore {
+		t.Errorf("expected human score 0.575, got %v", reviewed.HumanScore)
+	}
+	if len(reviewed.Annotations) != 1 || reviewed.Annotations[0].Reviewer != "alice" {
+		t.Errorf("expected one annotation by alice, got %+v", reviewed.Annotations)
+	}
+	if reviewed.SignedOff {
+		t.Error("expected no sign-off before a decision is recorded")
+	}
+
+	signed := reviewEvaluation(t, router, evaluation.ID, ReviewEvaluationRequestDTO{Reviewer: "bob", Decision: "advance"})
+	if !signed.SignedOff || signed.Decision != "advance" || signed.ReviewedBy != "bob" {
+		t.Errorf("expected sign-off by bob, got %+v", signed)
+	}
+	if signed.HumanScore == nil || *signed.HumanScore != *reviewed.HumanScore || len(signed.Annotations) != 1 {
+		t.Errorf("expected earlier adjustments to carry over, got %+v", signed)
+	}
+
+	httpReq = httptest.NewRequest("GET", "/evaluation/"+evaluation.ID+"/history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+	var history EvaluationHistoryResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &history); err != nil {
+		t.Fatalf("failed to unmarshal history: %v", err)
+	}
+	if len(history.Revisions) != 3 {
+		t.Fatalf("expected AI original plus 2 revisions, got %d", len(history.Revisions))
+	}
+	if history.Revisions[0].Source != "ai" || history.Revisions[0].Score != evaluation.Score {
+		t.Errorf("expected AI original first, got %+v", history.Revisions[0])
+	}
+	if history.Revisions[1].Comment != "Missed cache invalidation" || history.Revisions[2].Decision != "advance" {
+		t.Errorf("expected revisions in order, got %+v", history.Revisions[1:])
+	}
+}
+
+func TestReviewEvaluationHandler_ReviewerFromCredentials(t *testing.T) {
+	clearMemoryStore()
+	router := setupRoleRouter()
+	rita := roleBearer(t, "rita", RoleReviewer)
+
+	interview := createTestInterview(t, setupTestRouter(), CreateInterviewRequestDTO{CandidateName: "Jane", Questions: []string{"Q1"}, InterviewType: "general"})
+	_ = data.GlobalStore.CreateEvaluation(&data.Evaluation{ID: "eval", InterviewID: interview.ID, Score: 0.6})
+
+	b, _ := json.Marshal(ReviewEvaluationRequestDTO{Reviewer: "alice", Decision: "advance"})
+	if w := serveWithHeader(router, "PUT", "/evaluation/eval", b, "Authorization", rita); w.Code != http.StatusForbidden {
+		t.Errorf("expected signing off as someone else forbidden, got %d: %s", w.Code, w.Body.String())
+	}
+
+	b, _ = json.Marshal(ReviewEvaluationRequestDTO{Decision: "advance"})
+	w := serveWithHeader(router, "PUT", "/evaluation/eval", b, "Authorization", rita)
+	var reviewed EvaluationResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &reviewed)
+	if w.Code != http.StatusOK || reviewed.ReviewedBy != "rita" {
+		t.Errorf("expected the review signed off by the signed-in reviewer, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,169 @@
+// HTTP handler functions for regenerating and editing the last turn of a chat session
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Helper: convert chat messages to the role/content history passed to the AI
+func conversationHistory(messages []*data.ChatMessage) []map[string]string {
+	history := make([]map[string]string, 0, len(messages))
+	for _, msg := range messages {
+		history = append(history, map[string]string{
+			"role":    msg.Type,
+			"content": msg.Content,
+		})
+	}
+	return history
+}
+
+// Helper: load an active session, its interview and its visible messages, writing the error
+// response and returning ok=false when the turn cannot be revised
+func loadRevisableSession(w http.ResponseWriter, sessionID string) (*data.ChatSession, *data.Interview, []*data.ChatMessage, bool) {
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
+		return nil, nil, nil, false
+	}
+	session, err := data.GlobalStore.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Chat session not found")
+		return nil, nil, nil, false
+	}
+	if session.Status != "active" {
+		writeJSONError(w, http.StatusBadRequest, "Chat session is not active")
+		return nil, nil, nil, false
+	}
+	interview, err := data.GlobalStore.GetInterview(session.InterviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get interview details")
+		return nil, nil, nil, false
+	}
+	messages, err := data.GlobalStore.GetChatMessages(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
+		return nil, nil, nil, false
+	}
+	return session, interview, messages, true
+}
+
+// Helper: generate the interviewer's reply to userMessage given the messages before it
+func (deps *HandlerDependencies) generateReply(session *data.ChatSession, interview *data.Interview, before []*data.ChatMessage, userMessage string) (string, error) {
+	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	if err != nil {
+		return "", err
+	}
+	return aiClient.GenerateChatResponseWithOptions(session.ID, conversationHistory(before), userMessage, chatOptionsForInterview(interview, session.SessionLanguage))
+}
+
+// Helper: store the replacements together with any appended messages that replace nothing, so a
+// revised turn is saved whole or not at all, and respond with the updated transcript
+func writeRevisedSession(w http.ResponseWriter, session *data.ChatSession, replacements map[string]*data.ChatMessage, appended ...*data.ChatMessage) {
+	if err := data.GlobalStore.ReplaceChatMessages(session.ID, replacements, appended...); err != nil {
+		if errors.Is(err, data.ErrStaleChatMessage) {
+			writeJSONError(w, http.StatusConflict, "The conversation moved on; only the latest turn can be revised")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to save revised messages", err.Error())
+		return
+	}
+	messages, err := data.GlobalStore.GetChatMessages(session.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
+		return
+	}
+	writeJSON(w, http.StatusOK, newChatSessionDTO(session, messages))
+}
+
+// RegenerateMessageHandler handles POST /chat/{sessionId}/regenerate
+// Replaces the last AI message with a new reply; the old one is kept as a hidden revision.
+func (deps *HandlerDependencies) RegenerateMessageHandler(w http.ResponseWriter, r *http.Request) {
+	session, interview, messages, ok := loadRevisableSession(w, chi.URLParam(r, "sessionId"))
+	if !ok {
+		return
+	}
+	if len(messages) == 0 || messages[len(messages)-1].Type != "ai" {
+		writeJSONError(w, http.StatusConflict, "The last message is not an AI message")
+		return
+	}
+	last := messages[len(messages)-1]
+
+	// Reply again to the candidate message the AI answered, or greet again if there is none
+	before := messages[:len(messages)-1]
+	userMessage := ""
+	if n := len(before); n > 0 && before[n-1].Type == "user" {
+		userMessage = before[n-1].Content
+		before = before[:n-1]
+	}
+	aiResponse, err := deps.generateReply(session, interview, before, userMessage)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response")
+		return
+	}
+
+	now := time.Now()
+	writeRevisedSession(w, session, map[string]*data.ChatMessage{
+		last.ID: {ID: data.GenerateID(), Type: "ai", Content: aiResponse, Timestamp: now, CreatedAt: now},
+	})
+}
+
+// EditMessageHandler handles PUT /chat/{sessionId}/messages/{messageId}
+// Replaces the candidate's last message and the AI reply to it, generating a new reply. The
+// replaced messages are kept as hidden revisions.
+func (deps *HandlerDependencies) EditMessageHandler(w http.ResponseWriter, r *http.Request) {
+	var req SendMessageRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if req.Message == "" {
+		writeJSONError(w, http.StatusBadRequest, "Message cannot be empty")
+		return
+	}
+
+	session, interview, messages, ok := loadRevisableSession(w, chi.URLParam(r, "sessionId"))
+	if !ok {
+		return
+	}
+	messageID := chi.URLParam(r, "messageId")
+	index, lastUser := -1, -1
+	for i, msg := range messages {
+		if msg.ID == messageID {
+			index = i
+		}
+		if msg.Type == "user" {
+			lastUser = i
+		}
+	}
+	if index < 0 {
+		writeJSONError(w, http.StatusNotFound, "Chat message not found")
+		return
+	}
+	// At most the AI reply to it may follow the message
+	if index != lastUser || len(messages)-index > 2 {
+		writeJSONError(w, http.StatusConflict, "Only the candidate's last message can be edited")
+		return
+	}
+
+	aiResponse, err := deps.generateReply(session, interview, messages[:index], req.Message)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response")
+		return
+	}
+
+	now := time.Now()
+	edited := &data.ChatMessage{ID: data.GenerateID(), Type: "user", Content: req.Message, Timestamp: now, CreatedAt: now}
+	reply := &data.ChatMessage{ID: data.GenerateID(), Type: "ai", Content: aiResponse, Timestamp: now.Add(time.Millisecond), CreatedAt: now}
+	replacements := map[string]*data.ChatMessage{messageID: edited}
+	if index+1 < len(messages) {
+		replacements[messages[index+1].ID] = reply
+		writeRevisedSession(w, session, replacements)
+		return
+	}
+	writeRevisedSession(w, session, replacements, reply)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegenerateAndEditLastTurn(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+	sent := sendMessage(t, router, ids.SessionID, "I pressed enter too ea")
+
+	do := func(method, path string, body interface{}, expected int) ChatInterviewSessionDTO {
+		t.Helper()
+		var b []byte
+		if body != nil {
+			b, _ = json.Marshal(body)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(b))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Fatalf("%s %s: expected %d, got %d: %s", method, path, expected, w.Code, w.Body.String())
+		}
+		var resp ChatInterviewSessionDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	// Regenerating replaces the last AI message but keeps the transcript length
+	regenerated := do("POST", "/chat/"+ids.SessionID+"/regenerate", nil, http.StatusOK)
+	if len(regenerated.Messages) != 3 {
+		t.Fatalf("expected 3 visible messages, got %d", len(regenerated.Messages))
+	}
+	last := regenerated.Messages[2]
+	if last.Type != "ai" || last.ID == sent.AIResponse.ID {
+		t.Errorf("expected a new AI message, got %+v", last)
+	}
+
+	// Editing the last user message replaces it and the reply to it
+	edited := do("PUT", "/chat/"+ids.SessionID+"/messages/"+sent.Message.ID, SendMessageRequestDTO{Message: "I pressed enter too early"}, http.StatusOK)
+	if len(edited.Messages) != 3 || edited.Messages[1].Content != "I pressed enter too early" || edited.Messages[2].Type != "ai" {
+		t.Fatalf("expected edited user message followed by a new reply, got %+v", edited.Messages)
+	}
+
+	// Superseded messages can't be edited again, and only the last user message is editable
+	do("PUT", "/chat/"+ids.SessionID+"/messages/"+sent.Message.ID, SendMessageRequestDTO{Message: "again"}, http.StatusNotFound)
+	do("PUT", "/chat/"+ids.SessionID+"/messages/"+edited.Messages[0].ID, SendMessageRequestDTO{Message: "again"}, http.StatusConflict)
+	do("PUT", "/chat/"+ids.SessionID+"/messages/"+edited.Messages[1].ID, SendMessageRequestDTO{}, http.StatusBadRequest)
+
+	// The audit view keeps every revision, each pointing at its replacement
+	history := do("GET", "/chat/"+ids.SessionID+"?include_revisions=true", nil, http.StatusOK)
+	if len(history.Messages) != 6 {
+		t.Fatalf("expected 6 messages including revisions, got %d", len(history.Messages))
+	}
+	hidden := 0
+	for _, msg := range history.Messages {
+		if msg.SupersededBy != "" {
+			hidden++
+		}
+	}
+	if hidden != 3 {
+		t.Errorf("expected 3 superseded messages, got %d", hidden)
+	}
+	do("GET", "/chat/"+ids.SessionID+"?include_revisions=maybe", nil, http.StatusBadRequest)
+
+	// Finished sessions can no longer be revised
+	do("POST", "/chat/"+ids.SessionID+"/end", nil, http.StatusAccepted)
+	do("POST", "/chat/"+ids.SessionID+"/regenerate", nil, http.StatusBadRequest)
+}
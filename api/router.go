@@ -5,27 +5,25 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/zidane0000/AI_Interview_Backend/ai"
 	"github.com/zidane0000/AI_Interview_Backend/config"
 	"github.com/zidane0000/AI_Interview_Backend/utils"
 )
 
-// SetupRouter initializes the HTTP routes for the API using chi
-// Config is injected from main.go to avoid loading configuration multiple times
+// SetupRouter initializes the HTTP routes for the API with dependencies created from the config
 func SetupRouter(cfg *config.Config) http.Handler {
-	// Create AI client factory with injected config
-	aiClientFactory := ai.NewAIClientFactory(*cfg)
-
-	// Create handler dependencies
-	deps := NewHandlerDependencies(aiClientFactory)
+	return NewRouter(NewHandlerDependenciesFromConfig(cfg))
+}
 
+// NewRouter initializes the HTTP routes for the API using chi
+// Dependencies are injected from main.go so the router and background workers share them
+func NewRouter(deps *HandlerDependencies) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(CORSMiddleware)
 	r.Use(LoggingMiddleware)
+	r.Use(deps.AuthMiddleware)
 
 	// TODO: Add rate limiting middleware for production
-	// TODO: Add authentication middleware if user accounts are implemented
 	// TODO: Add request validation middleware
 	// TODO: Add API versioning support (e.g., /api/v1/)
 
@@ -39,39 +37,150 @@ func SetupRouter(cfg *config.Config) http.Handler {
 			http.Error(w, ErrMsgMissingEvaluationID, ErrCodeBadRequest)
 			return
 		}
+		if r.URL.Path == "/questions/" {
+			http.Error(w, ErrMsgMissingQuestionID, ErrCodeBadRequest)
+			return
+		}
+		if r.URL.Path == "/templates/" {
+			http.Error(w, ErrMsgMissingTemplateID, ErrCodeBadRequest)
+			return
+		}
+		if r.URL.Path == "/candidates/" {
+			http.Error(w, ErrMsgMissingCandidateID, ErrCodeBadRequest)
+			return
+		}
+		if r.URL.Path == "/positions/" {
+			http.Error(w, ErrMsgMissingPositionID, ErrCodeBadRequest)
+			return
+		}
 		// TODO: Add custom 404 response for chat endpoints
 		http.NotFound(w, r)
 	}))
 
+	if !deps.AuthRequired {
+		utils.Warningf("AUTH_DISABLED is set; every endpoint accepts unauthenticated requests as admin")
+	}
+
+	// Every route declares the permission it requires, and for routes on one resource, the scope
+	// that holds recruiters to their own interviews and positions and candidates to their invitation
+	can := deps.Authorize
+
 	// Interview routes
 	r.Route("/interviews", func(r chi.Router) {
-		r.Post("/", CreateInterviewHandler)
-		r.Get("/", ListInterviewsHandler)
-		r.Get("/{id}", GetInterviewHandler)
-
-		// TODO: Implement chat session routes for conversational interviews
-		// These routes are expected by the frontend for chat-based interviews
-		r.Post("/{id}/chat/start", deps.StartChatSessionHandler)
-		// TODO: Add PUT /{id} for updating interviews
-		// TODO: Add DELETE /{id} for removing interviews
+		r.With(can(PermInterviewsWrite, scopeNone)).Post("/", CreateInterviewHandler)
+		r.With(can(PermInterviewsWrite, scopeNone)).Post("/bulk", BulkCreateInterviewsHandler)
+		r.With(can(PermInterviewsRead, scopeNone)).Get("/", ListInterviewsHandler)
+		r.With(can(PermInterviewsRead, scopeInterview)).Get("/{id}", GetInterviewHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Put("/{id}", UpdateInterviewHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Delete("/{id}", deps.DeleteInterviewHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Post("/{id}/transition", TransitionInterviewHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Post("/{id}/resume", deps.UploadResumeHandler)
+		r.With(can(PermInterviewsRead, scopeInterview)).Get("/{id}/resume", GetResumeHandler)
+		r.With(can(PermInterviewsRead, scopeInterview)).Get("/{id}/resume/file", deps.DownloadResumeHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Post("/{id}/resume/questions", deps.GenerateResumeQuestionsHandler)
+		r.With(can(PermInterviewsRead, scopeInterview)).Get("/{id}/profile", GetCandidateProfileHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Put("/{id}/profile", UpdateCandidateProfileHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Post("/{id}/profile/parse", deps.ParseCandidateProfileHandler)
+		r.With(can(PermInterviewsRead, scopeInterview)).Get("/{id}/sessions", ListInterviewSessionsHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Post("/{id}/invitations", deps.CreateInvitationHandler)
+		r.With(can(PermInterviewsRead, scopeInterview)).Get("/{id}/invitations", ListInvitationsHandler)
+		r.With(can(PermInterviewsWrite, scopeInterview)).Delete("/{id}/invitations/{invitationId}", RevokeInvitationHandler)
+		// Candidates with an invitation for the interview may start its chat sessions
+		r.With(can(PermSessionsWrite, scopeInterview)).Post("/{id}/chat/start", deps.StartChatSessionHandler)
 	})
 
-	// Evaluation routes
-	r.Route("/evaluation", func(r chi.Router) {
-		r.Post("/", deps.SubmitEvaluationHandler)
-		r.Get("/{id}", GetEvaluationHandler)
-		// TODO: Add GET / for listing evaluations
-		// TODO: Add PUT /{id} for updating evaluations
-		// TODO: Add DELETE /{id} for removing evaluations
-	})
 	// TODO: Implement chat routes for real-time interview conversations
 	// These routes are required by the frontend chat functionality
 	r.Route("/chat", func(r chi.Router) {
-		r.Post("/{sessionId}/message", deps.SendMessageHandler)
-		r.Get("/{sessionId}", GetChatSessionHandler)
-		r.Post("/{sessionId}/end", deps.EndChatSessionHandler)
+		// Candidates may only reach the sessions of the interview their invitation is for
+		r.With(can(PermSessionsWrite, scopeSession)).Post("/{sessionId}/message", deps.SendMessageHandler)
+		r.With(can(PermSessionsRead, scopeSession)).Get("/{sessionId}", GetChatSessionHandler)
+		r.With(can(PermSessionsWrite, scopeSession)).Post("/{sessionId}/end", deps.EndChatSessionHandler)
+		r.With(can(PermSessionsWrite, scopeSession)).Post("/{sessionId}/pause", PauseChatSessionHandler)
+		r.With(can(PermSessionsWrite, scopeSession)).Post("/{sessionId}/resume", deps.ResumeChatSessionHandler)
+		r.With(can(PermSessionsWrite, scopeSession)).Post("/{sessionId}/regenerate", deps.RegenerateMessageHandler)
+		r.With(can(PermSessionsWrite, scopeSession)).Put("/{sessionId}/messages/{messageId}", deps.EditMessageHandler)
+		r.With(can(PermInterviewsWrite, scopeSession)).Delete("/{sessionId}", DeleteChatSessionHandler)
 		// TODO: Add WebSocket support for real-time messaging
-		// TODO: Add DELETE /{sessionId} for cleaning up sessions
+	})
+
+	// Candidate routes
+	r.Route("/candidates", func(r chi.Router) {
+		r.With(can(PermCandidatesWrite, scopeNone)).Post("/", CreateCandidateHandler)
+		r.With(can(PermCandidatesRead, scopeNone)).Get("/", ListCandidatesHandler)
+		r.With(can(PermCandidatesRead, scopeCandidate)).Get("/{id}", GetCandidateHandler)
+		r.With(can(PermCandidatesWrite, scopeCandidate)).Put("/{id}", UpdateCandidateHandler)
+		r.With(can(PermCandidatesWrite, scopeCandidate)).Delete("/{id}", DeleteCandidateHandler)
+	})
+
+	// Position routes
+	r.Route("/positions", func(r chi.Router) {
+		r.With(can(PermPositionsWrite, scopeNone)).Post("/", CreatePositionHandler)
+		r.With(can(PermPositionsRead, scopeNone)).Get("/", ListPositionsHandler)
+		r.With(can(PermPositionsRead, scopePosition)).Get("/{id}", GetPositionHandler)
+		r.With(can(PermPositionsWrite, scopePosition)).Put("/{id}", UpdatePositionHandler)
+		r.With(can(PermPositionsWrite, scopePosition)).Delete("/{id}", DeletePositionHandler)
+	})
+
+	// Question bank routes
+	r.Route("/questions", func(r chi.Router) {
+		r.With(can(PermQuestionsWrite, scopeNone)).Post("/", CreateQuestionHandler)
+		r.With(can(PermQuestionsRead, scopeNone)).Get("/", ListQuestionsHandler)
+		r.With(can(PermQuestionsRead, scopeNone)).Get("/{id}", GetQuestionHandler)
+		r.With(can(PermQuestionsWrite, scopeNone)).Put("/{id}", UpdateQuestionHandler)
+		r.With(can(PermQuestionsWrite, scopeNone)).Delete("/{id}", DeleteQuestionHandler)
+	})
+
+	// Interview template routes; templates hold the prompts and AI providers, so only admins edit them
+	r.Route("/templates", func(r chi.Router) {
+		r.With(can(PermTemplatesWrite, scopeNone)).Post("/", CreateInterviewTemplateHandler)
+		r.With(can(PermTemplatesRead, scopeNone)).Get("/", ListInterviewTemplatesHandler)
+		r.With(can(PermTemplatesRead, scopeNone)).Get("/{id}", GetInterviewTemplateHandler)
+		r.With(can(PermTemplatesWrite, scopeNone)).Put("/{id}", UpdateInterviewTemplateHandler)
+		r.With(can(PermTemplatesWrite, scopeNone)).Delete("/{id}", DeleteInterviewTemplateHandler)
+		r.With(can(PermEvaluationsReview, scopeNone)).Get("/{id}/calibration-report", GetCalibrationReportHandler)
+	})
+
+	// Evaluation routes
+	r.Route("/evaluation", func(r chi.Router) {
+		r.With(can(PermEvaluationsWrite, scopeNone)).Post("/", deps.SubmitEvaluationHandler)
+		r.With(can(PermEvaluationsRead, scopeNone)).Get("/", ListEvaluationsHandler)
+		r.With(can(PermEvaluationsRead, scopeNone)).Get("/stats", GetEvaluationStatsHandler)
+		r.With(can(PermEvaluationsRead, scopeEvaluation)).Get("/{id}", GetEvaluationHandler)
+		r.With(can(PermEvaluationsReview, scopeEvaluation)).Put("/{id}", ReviewEvaluationHandler)
+		r.With(can(PermEvaluationsRead, scopeEvaluation)).Get("/{id}/history", GetEvaluationHistoryHandler)
+		r.With(can(PermEvaluationsWrite, scopeEvaluation)).Put("/{id}/raters", AssignEvaluationRatersHandler)
+		r.With(can(PermEvaluationsReview, scopeEvaluation)).Post("/{id}/ratings", SubmitEvaluationRatingHandler)
+		r.With(can(PermEvaluationsRead, scopeEvaluation)).Get("/{id}/ratings", GetEvaluationRatingsHandler)
+		// TODO: Add DELETE /{id} for removing evaluations
+	})
+
+	// Candidate ranking routes
+	r.Route("/rankings", func(r chi.Router) {
+		r.With(can(PermEvaluationsRead, scopeNone)).Post("/", RankCandidatesHandler)
+		r.With(can(PermEvaluationsRead, scopeNone)).Post("/compare", CompareCandidatesHandler)
+	})
+	// Background job routes, e.g. polling queued evaluations
+	r.Route("/jobs", func(r chi.Router) {
+		r.With(can(PermJobsManage, scopeNone)).Get("/", ListJobsHandler)
+		r.With(can(PermJobsRead, scopeNone)).Get("/{id}", GetJobHandler)
+		r.With(can(PermJobsManage, scopeNone)).Post("/{id}/retry", RetryJobHandler)
+	})
+	// Integration API key routes
+	r.Route("/api-keys", func(r chi.Router) {
+		r.With(can(PermAPIKeysManage, scopeNone)).Post("/", CreateAPIKeyHandler)
+		r.With(can(PermAPIKeysManage, scopeNone)).Get("/", ListAPIKeysHandler)
+		r.With(can(PermAPIKeysManage, scopeNone)).Delete("/{id}", RevokeAPIKeyHandler)
+	})
+	// Webhook subscription routes
+	r.Route("/webhooks", func(r chi.Router) {
+		r.With(can(PermWebhooksManage, scopeNone)).Post("/", CreateWebhookHandler)
+		r.With(can(PermWebhooksManage, scopeNone)).Get("/", ListWebhooksHandler)
+		r.With(can(PermWebhooksManage, scopeNone)).Get("/{id}", GetWebhookHandler)
+		r.With(can(PermWebhooksManage, scopeNone)).Put("/{id}", UpdateWebhookHandler)
+		r.With(can(PermWebhooksManage, scopeNone)).Delete("/{id}", DeleteWebhookHandler)
+		r.With(can(PermWebhooksManage, scopeNone)).Get("/{id}/deliveries", ListWebhookDeliveriesHandler)
+		r.With(can(PermWebhooksManage, scopeNone)).Post("/{id}/deliveries/{deliveryId}/replay", ReplayWebhookDeliveryHandler)
 	})
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -83,7 +192,6 @@ func SetupRouter(cfg *config.Config) http.Handler {
 	})
 
 	// TODO: Add metrics endpoint for monitoring
-	// TODO: Add file upload endpoints for resume handling
 	// TODO: Add internationalization endpoints for multi-language support
 
 	return r
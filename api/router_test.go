@@ -31,8 +31,8 @@ func TestRouter_EvaluationID_BadRequest(t *testing.T) {
 	req := httptest.NewRequest("GET", "/evaluation/", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
-	if w.Code != http.StatusMethodNotAllowed {
-		t.Errorf("expected 405 Method Not Allowed, got %d", w.Code)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 OK from the evaluation list, got %d", w.Code)
 	}
 }
 
@@ -0,0 +1,189 @@
+// HTTP handler functions for pausing and resuming chat sessions
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/ai"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Helper: generate a random resume token, returning the token and the hash to store
+func newResumeToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(buf)
+	return token, hashResumeToken(token), nil
+}
+
+// Helper: SHA-256 hex digest of a resume token
+func hashResumeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Helper: report whether a token matches the session's stored resume token hash
+func validResumeToken(session *data.ChatSession, token string) bool {
+	if session.ResumeTokenHash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashResumeToken(token)), []byte(session.ResumeTokenHash)) == 1
+}
+
+// Helper: load a session and check the resume token in the request body, writing the error
+// response and returning nil when the request may not proceed
+func authorizeSessionToken(w http.ResponseWriter, r *http.Request) *data.ChatSession {
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
+		return nil
+	}
+
+	var req ChatSessionTokenRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return nil
+	}
+	if req.ResumeToken == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing resume_token")
+		return nil
+	}
+
+	session, err := data.GlobalStore.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Chat session not found")
+		return nil
+	}
+	if !validResumeToken(session, req.ResumeToken) {
+		writeForbidden(w, r, "Invalid resume token", "")
+		return nil
+	}
+	return session
+}
+
+// Helper: where the interview stood when the candidate left, from the messages so far
+func interviewStage(messages []*data.ChatMessage) string {
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			return ai.InterviewStageQuestions
+		}
+	}
+	return ai.InterviewStageIntroduction
+}
+
+// PauseChatSessionHandler handles POST /chat/{sessionId}/pause
+// Stops the session clock until the candidate resumes with their resume token.
+func PauseChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	session := authorizeSessionToken(w, r)
+	if session == nil {
+		return
+	}
+	if session.Status != "active" {
+		writeJSONError(w, http.StatusConflict, "Only active chat sessions can be paused")
+		return
+	}
+
+	// Update a copy so a failed save leaves the stored session untouched
+	paused := *session
+	pausedAt := time.Now()
+	paused.Status = "paused"
+	paused.PausedAt = &pausedAt
+	paused.UpdatedAt = pausedAt
+	if err := data.GlobalStore.UpdateChatSession(&paused); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to pause session")
+		return
+	}
+
+	messages, err := data.GlobalStore.GetChatMessages(paused.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
+		return
+	}
+	writeJSON(w, http.StatusOK, newChatSessionDTO(&paused, messages))
+}
+
+// ResumeChatSessionHandler handles POST /chat/{sessionId}/resume
+// Reactivates a paused session with a welcome-back message and issues a new resume token.
+func (deps *HandlerDependencies) ResumeChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	session := authorizeSessionToken(w, r)
+	if session == nil {
+		return
+	}
+	if session.Status != "paused" {
+		writeJSONError(w, http.StatusConflict, "Only paused chat sessions can be resumed")
+		return
+	}
+
+	interview, err := data.GlobalStore.GetInterview(session.InterviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get interview details")
+		return
+	}
+	messages, err := data.GlobalStore.GetChatMessages(session.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
+		return
+	}
+
+	// Generate the welcome-back message first so a failure leaves the session paused and the token valid
+	aiClient, err := deps.AIClientFactory.CreateDefaultClient()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create AI client")
+		return
+	}
+	aiResponse, err := aiClient.GenerateWelcomeBackMessageWithOptions(session.ID, conversationHistory(messages), interviewStage(messages),
+		chatOptionsForInterview(interview, session.SessionLanguage))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response")
+		return
+	}
+
+	// Rotate the token so one that leaked while paused cannot be reused
+	resumeToken, resumeTokenHash, err := newResumeToken()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create resume token")
+		return
+	}
+
+	resumed := *session
+	now := time.Now()
+	if resumed.PausedAt != nil {
+		resumed.PausedSeconds += int64(now.Sub(*resumed.PausedAt) / time.Second)
+	}
+	resumed.PausedAt = nil
+	resumed.Status = "active"
+	resumed.ResumeTokenHash = resumeTokenHash
+	resumed.UpdatedAt = now
+	if err := data.GlobalStore.UpdateChatSession(&resumed); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to resume session")
+		return
+	}
+
+	aiMessage := &data.ChatMessage{
+		ID:        data.GenerateID(),
+		SessionID: resumed.ID,
+		Type:      "ai",
+		Content:   aiResponse,
+		Timestamp: now,
+		CreatedAt: now,
+	}
+	if err := data.GlobalStore.AddChatMessage(resumed.ID, aiMessage); err != nil {
+		utils.Errorf("Failed to save welcome-back message for session %s: %v", resumed.ID, err)
+	} else {
+		messages = append(messages, aiMessage)
+	}
+
+	response := newChatSessionDTO(&resumed, messages)
+	response.ResumeToken = resumeToken
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+func TestPauseAndResumeChatSession(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Paused Candidate",
+		Questions:     []string{"Q1", "Q2"},
+		InterviewType: "general",
+	})
+	session := startChatSession(t, router, interview.ID, nil)
+	if session.ResumeToken == "" {
+		t.Fatal("expected a resume token when the session starts")
+	}
+	sendMessage(t, router, session.ID, "My first answer")
+
+	post := func(action, token string, expected int) ChatInterviewSessionDTO {
+		t.Helper()
+		b, _ := json.Marshal(ChatSessionTokenRequestDTO{ResumeToken: token})
+		req := httptest.NewRequest("POST", "/chat/"+session.ID+"/"+action, bytes.NewReader(b))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != expected {
+			t.Fatalf("%s: expected %d, got %d: %s", action, expected, w.Code, w.Body.String())
+		}
+		var resp ChatInterviewSessionDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	// The token is required and must match
+	post("pause", "", http.StatusBadRequest)
+	post("pause", "not-the-token", http.StatusForbidden)
+	post("resume", session.ResumeToken, http.StatusConflict)
+
+	paused := post("pause", session.ResumeToken, http.StatusOK)
+	if paused.Status != "paused" || paused.PausedAt == nil || paused.ResumeToken != "" {
+		t.Fatalf("expected paused session without a token, got %+v", paused)
+	}
+	expectHTTPError(t, router, "POST", "/chat/"+session.ID+"/message", []byte(`{"message":"hello?"}`), http.StatusBadRequest)
+	// A paused session still blocks starting another one
+	expectHTTPError(t, router, "POST", "/interviews/"+interview.ID+"/chat/start", nil, http.StatusConflict)
+	post("pause", session.ResumeToken, http.StatusConflict)
+
+	resumed := post("resume", session.ResumeToken, http.StatusOK)
+	if resumed.Status != "active" || resumed.PausedAt != nil {
+		t.Fatalf("expected active session after resume, got %+v", resumed)
+	}
+	if resumed.ResumeToken == "" || resumed.ResumeToken == session.ResumeToken {
+		t.Error("expected a new resume token after resuming")
+	}
+	if last := resumed.Messages[len(resumed.Messages)-1]; last.Type != "ai" || len(resumed.Messages) != len(paused.Messages)+1 {
+		t.Errorf("expected a welcome-back AI message, got %+v", resumed.Messages)
+	}
+
+	// The old token is no longer valid, the new one is
+	post("pause", session.ResumeToken, http.StatusForbidden)
+	post("pause", resumed.ResumeToken, http.StatusOK)
+
+	stored, _ := data.GlobalStore.GetChatSession(session.ID)
+	if stored.ResumeTokenHash == resumed.ResumeToken {
+		t.Error("expected only the token hash to be stored")
+	}
+	expectHTTPError(t, router, "POST", "/chat/missing/pause", []byte(`{"resume_token":"x"}`), http.StatusNotFound)
+}
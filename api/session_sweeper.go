@@ -0,0 +1,124 @@
+// Background expiry of idle chat sessions
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// SessionSweeper periodically marks idle chat sessions abandoned and purges old abandoned ones
+type SessionSweeper struct {
+	deps              *HandlerDependencies
+	interval          time.Duration
+	inactivityTimeout time.Duration
+	retention         time.Duration
+	evaluateAbandoned bool
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSessionSweeper creates a sweeper from the session expiry configuration, queueing evaluations with deps
+func NewSessionSweeper(cfg *config.Config, deps *HandlerDependencies) *SessionSweeper {
+	return &SessionSweeper{
+		deps:              deps,
+		interval:          cfg.SessionSweepInterval,
+		inactivityTimeout: cfg.SessionInactivityTimeout,
+		retention:         cfg.AbandonedSessionRetention,
+		evaluateAbandoned: cfg.EvaluateAbandonedSessions,
+		stop:              make(chan struct{}),
+		done:              make(chan struct{}),
+	}
+}
+
+// Start runs the sweeper in the background until Stop is called
+func (s *SessionSweeper) Start() {
+	if s.interval <= 0 || (s.inactivityTimeout <= 0 && s.retention <= 0) {
+		utils.Infof("Session sweeper disabled")
+		close(s.done)
+		return
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-ticker.C:
+				s.sweep(now)
+			}
+		}
+	}()
+	utils.Infof("Session sweeper started (interval %s, inactivity timeout %s, retention %s)", s.interval, s.inactivityTimeout, s.retention)
+}
+
+// Stop signals the sweeper to exit and waits for an in-flight sweep to finish
+func (s *SessionSweeper) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// stopping reports whether Stop has been called
+func (s *SessionSweeper) stopping() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// sweep abandons sessions idle since before now minus the timeout, then purges expired abandoned sessions
+func (s *SessionSweeper) sweep(now time.Time) {
+	if s.inactivityTimeout > 0 {
+		abandoned, err := data.GlobalStore.AbandonInactiveChatSessions(now.Add(-s.inactivityTimeout))
+		if err != nil {
+			utils.Errorf("Failed to abandon inactive chat sessions: %v", err)
+		}
+		for _, session := range abandoned {
+			utils.Infof("Chat session %s abandoned after %s of inactivity", session.ID, s.inactivityTimeout)
+			completeInterview(session.InterviewID)
+			if s.evaluateAbandoned && !s.stopping() {
+				s.evaluatePartial(session)
+			}
+		}
+	}
+
+	if s.retention > 0 && !s.stopping() {
+		purged, err := data.GlobalStore.PurgeAbandonedChatSessions(now.Add(-s.retention))
+		if err != nil {
+			utils.Errorf("Failed to purge abandoned chat sessions: %v", err)
+		} else if purged > 0 {
+			utils.Infof("Purged %d abandoned chat sessions", purged)
+		}
+	}
+}
+
+// evaluatePartial queues an evaluation of what an abandoned session's candidate answered before leaving
+func (s *SessionSweeper) evaluatePartial(session *data.ChatSession) {
+	messages, err := data.GlobalStore.GetChatMessages(session.ID)
+	if err != nil {
+		utils.Errorf("Failed to get messages of abandoned session %s: %v", session.ID, err)
+		return
+	}
+	answered := false
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			answered = true
+			break
+		}
+	}
+	if !answered {
+		return
+	}
+	if _, err := s.deps.enqueueJob(data.JobTypeSessionEvaluation, sessionEvaluationPayload(session.ID, true)); err != nil {
+		utils.Errorf("Failed to queue evaluation of abandoned session %s: %v", session.ID, err)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+func TestSessionSweeperAbandonsAndPurgesSessions(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	cfg := &config.Config{
+		OpenAIAPIKey:              "test-openai-key",
+		GeminiAPIKey:              "test-gemini-key",
+		SessionSweepInterval:      time.Minute,
+		SessionInactivityTimeout:  30 * time.Minute,
+		AbandonedSessionRetention: 24 * time.Hour,
+		EvaluateAbandonedSessions: true,
+	}
+	sweeper := NewSessionSweeper(cfg, NewHandlerDependenciesFromConfig(cfg))
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Idle Candidate",
+		Questions:     []string{"Q1", "Q2"},
+		InterviewType: "general",
+	})
+	session := startChatSession(t, router, interview.ID, nil)
+	sendMessage(t, router, session.ID, "My first answer")
+
+	// Active sessions cannot be deleted
+	expectHTTPError(t, router, "DELETE", "/chat/"+session.ID, nil, http.StatusConflict)
+
+	// Recent activity keeps the session alive
+	sweeper.sweep(time.Now())
+	if stored, _ := data.GlobalStore.GetChatSession(session.ID); stored.Status != "active" {
+		t.Fatalf("expected session to stay active, got %s", stored.Status)
+	}
+
+	// After the inactivity timeout the session is abandoned and queued for a partial evaluation
+	sweeper.sweep(time.Now().Add(31 * time.Minute))
+	runQueuedJobs()
+	stored, _ := data.GlobalStore.GetChatSession(session.ID)
+	if stored.Status != "abandoned" || stored.EndedAt == nil {
+		t.Fatalf("expected abandoned session with an end time, got %+v", stored)
+	}
+	if storedInterview, _ := data.GlobalStore.GetInterview(interview.ID); storedInterview.Status != data.InterviewStatusCompleted {
+		t.Errorf("expected interview completed after abandonment, got %s", storedInterview.Status)
+	}
+	evaluations, _ := data.GlobalStore.GetLatestEvaluations([]string{interview.ID})
+	evaluation, ok := evaluations[interview.ID]
+	if !ok || !evaluation.Partial || stringValue(evaluation.SessionID) != session.ID {
+		t.Fatalf("expected a partial evaluation linked to the session, got %+v", evaluation)
+	}
+	expectHTTPError(t, router, "POST", "/chat/"+session.ID+"/message", []byte(`{"message":"hello?"}`), http.StatusBadRequest)
+
+	// Past retention the session and its transcript are purged; the evaluation stays
+	sweeper.sweep(time.Now().Add(25 * time.Hour))
+	expectHTTPError(t, router, "GET", "/chat/"+session.ID, nil, http.StatusNotFound)
+	kept, err := data.GlobalStore.GetEvaluation(evaluation.ID)
+	if err != nil || kept.SessionID != nil {
+		t.Errorf("expected evaluation kept without its session link, got %+v (%v)", kept, err)
+	}
+
+	// Stop is safe to call on a sweeper that was never started
+	disabled := NewSessionSweeper(&config.Config{}, NewHandlerDependenciesFromConfig(&config.Config{}))
+	disabled.Start()
+	disabled.Stop()
+}
+
+func TestDeleteChatSessionHandler(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+
+	expectHTTPError(t, router, "POST", "/chat/"+ids.SessionID+"/end", nil, http.StatusAccepted)
+	runQueuedJobs()
+	expectHTTPError(t, router, "DELETE", "/chat/"+ids.SessionID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "GET", "/chat/"+ids.SessionID, nil, http.StatusNotFound)
+	expectHTTPError(t, router, "DELETE", "/chat/"+ids.SessionID, nil, http.StatusNotFound)
+
+	evaluations, _ := data.GlobalStore.GetLatestEvaluations([]string{ids.InterviewID})
+	if evaluation, ok := evaluations[ids.InterviewID]; !ok || evaluation.SessionID != nil {
+		t.Errorf("expected evaluation kept and unlinked from the deleted session, got %+v", evaluation)
+	}
+}
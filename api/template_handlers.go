@@ -0,0 +1,269 @@
+// HTTP handler functions for reusable interview templates
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+// Helper: convert an interview template to its response DTO
+func newInterviewTemplateResponseDTO(template *data.InterviewTemplate) InterviewTemplateResponseDTO {
+	questions := template.Questions
+	if questions == nil {
+		questions = []string{}
+	}
+	return InterviewTemplateResponseDTO{
+		ID:                 template.ID,
+		Name:               template.Name,
+		Description:        template.Description,
+		InterviewType:      template.InterviewType,
+		InterviewLanguage:  template.InterviewLanguage,
+		Questions:          questions,
+		QuestionIDs:        template.QuestionIDs,
+		JobDescription:     template.JobDescription,
+		EndPolicy:          endPolicyToDTO(template.EndPolicy),
+		EvaluationCriteria: template.EvalCriteria,
+		InterviewerPersona: template.Persona,
+		Rubric:             rubricToDTO(template.Rubric),
+		EvaluationEnsemble: ensembleToDTO(template.Ensemble),
+		EvaluationSampling: samplingToDTO(template.Sampling),
+		MaxRetakes:         template.MaxRetakes,
+		CreatedAt:          template.CreatedAt,
+		UpdatedAt:          template.UpdatedAt,
+	}
+}
+
+// Helper: validate a template request, returning an error message or "" if valid
+func validateInterviewTemplateRequest(req *InterviewTemplateRequestDTO) string {
+	if strings.TrimSpace(req.Name) == "" {
+		return "Missing name field"
+	}
+	if req.InterviewType == "" {
+		return "Missing interview_type field"
+	}
+	if !data.ValidateInterviewType(req.InterviewType) {
+		return "Invalid interview_type. Supported types: general, technical, behavioral"
+	}
+	if req.InterviewLanguage != "" && !data.ValidateLanguage(req.InterviewLanguage) {
+		return "Invalid language code. Supported languages: en, zh-TW"
+	}
+	if len(req.Questions) == 0 && len(req.QuestionIDs) == 0 {
+		return "Missing questions or question_ids"
+	}
+	if req.EndPolicy != nil && (req.EndPolicy.MaxUserMessages < 0 || req.EndPolicy.MaxDurationMinutes < 0) {
+		return "Invalid end_policy: values cannot be negative"
+	}
+	if msg := validateRubricDTO(req.Rubric); msg != "" {
+		return msg
+	}
+	if msg := validateEnsembleDTO(req.EvaluationEnsemble); msg != "" {
+		return msg
+	}
+	if msg := validateSamplingDTO(req.EvaluationSampling); msg != "" {
+		return msg
+	}
+	if msg := validateMaxRetakes(req.MaxRetakes); msg != "" {
+		return msg
+	}
+	if len(req.QuestionIDs) > 0 {
+		if _, err := data.GlobalStore.GetQuestionsByIDs(req.QuestionIDs); err != nil {
+			return "Invalid question_ids: " + err.Error()
+		}
+	}
+	return ""
+}
+
+// Helper: copy template settings into any interview request fields the caller left empty
+func applyInterviewTemplate(req *CreateInterviewRequestDTO, template *data.InterviewTemplate) {
+	if req.InterviewType == "" {
+		req.InterviewType = template.InterviewType
+	}
+	if req.InterviewLanguage == "" {
+		req.InterviewLanguage = template.InterviewLanguage
+	}
+	if len(req.Questions) == 0 && len(req.QuestionIDs) == 0 {
+		req.Questions = template.Questions
+		req.QuestionIDs = template.QuestionIDs
+	}
+	if req.JobDescription == "" {
+		req.JobDescription = template.JobDescription
+	}
+	if req.EndPolicy == nil {
+		policy := endPolicyToDTO(template.EndPolicy)
+		req.EndPolicy = &policy
+	}
+	if len(req.EvaluationCriteria) == 0 {
+		req.EvaluationCriteria = template.EvalCriteria
+	}
+	if req.InterviewerPersona == "" {
+		req.InterviewerPersona = template.Persona
+	}
+	if req.Rubric == nil {
+		req.Rubric = rubricToDTO(template.Rubric)
+	}
+	if req.EvaluationEnsemble == nil {
+		req.EvaluationEnsemble = ensembleToDTO(template.Ensemble)
+	}
+	if req.EvaluationSampling == nil {
+		req.EvaluationSampling = samplingToDTO(template.Sampling)
+	}
+	if req.MaxRetakes == nil {
+		maxRetakes := template.MaxRetakes
+		req.MaxRetakes = &maxRetakes
+	}
+}
+
+// CreateInterviewTemplateHandler handles POST /templates
+func CreateInterviewTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	var req InterviewTemplateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if msg := validateInterviewTemplateRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	template := &data.InterviewTemplate{
+		ID:                data.GenerateID(),
+		Name:              strings.TrimSpace(req.Name),
+		Description:       req.Description,
+		InterviewType:     req.InterviewType,
+		InterviewLanguage: data.GetValidatedLanguage(req.InterviewLanguage),
+		Questions:         req.Questions,
+		QuestionIDs:       req.QuestionIDs,
+		JobDescription:    req.JobDescription,
+		EndPolicy:         endPolicyFromDTO(req.EndPolicy),
+		EvalCriteria:      req.EvaluationCriteria,
+		Persona:           req.InterviewerPersona,
+		Rubric:            rubricFromDTO(req.Rubric),
+		Ensemble:          ensembleFromDTO(req.EvaluationEnsemble),
+		Sampling:          samplingFromDTO(req.EvaluationSampling),
+		MaxRetakes:        maxRetakesFromDTO(req.MaxRetakes),
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+	if err := data.GlobalStore.CreateInterviewTemplate(template); err != nil {
+		if errors.Is(err, data.ErrDuplicateTemplateName) {
+			writeJSONError(w, http.StatusConflict, "Template name already exists")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create template", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newInterviewTemplateResponseDTO(template))
+}
+
+// ListInterviewTemplatesHandler handles GET /templates
+func ListInterviewTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	templates, err := data.GlobalStore.GetInterviewTemplates()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch templates", err.Error())
+		return
+	}
+
+	templateDTOs := make([]InterviewTemplateResponseDTO, len(templates))
+	for i, template := range templates {
+		templateDTOs[i] = newInterviewTemplateResponseDTO(template)
+	}
+
+	writeJSON(w, http.StatusOK, ListInterviewTemplatesResponseDTO{
+		Templates: templateDTOs,
+		Total:     len(templateDTOs),
+	})
+}
+
+// GetInterviewTemplateHandler handles GET /templates/{id}
+func GetInterviewTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingTemplateID)
+		return
+	}
+
+	template, err := data.GlobalStore.GetInterviewTemplate(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Template not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newInterviewTemplateResponseDTO(template))
+}
+
+// UpdateInterviewTemplateHandler handles PUT /templates/{id}
+func UpdateInterviewTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingTemplateID)
+		return
+	}
+
+	existing, err := data.GlobalStore.GetInterviewTemplate(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Template not found")
+		return
+	}
+
+	var req InterviewTemplateRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if msg := validateInterviewTemplateRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Build the replacement on a copy so a rejected rename leaves the stored template untouched
+	template := *existing
+	template.Name = strings.TrimSpace(req.Name)
+	template.Description = req.Description
+	template.InterviewType = req.InterviewType
+	template.InterviewLanguage = data.GetValidatedLanguage(req.InterviewLanguage)
+	template.Questions = req.Questions
+	template.QuestionIDs = req.QuestionIDs
+	template.JobDescription = req.JobDescription
+	template.EndPolicy = endPolicyFromDTO(req.EndPolicy)
+	template.EvalCriteria = req.EvaluationCriteria
+	template.Persona = req.InterviewerPersona
+	template.Rubric = rubricFromDTO(req.Rubric)
+	template.Ensemble = ensembleFromDTO(req.EvaluationEnsemble)
+	template.Sampling = samplingFromDTO(req.EvaluationSampling)
+	template.MaxRetakes = maxRetakesFromDTO(req.MaxRetakes)
+	template.UpdatedAt = time.Now()
+
+	if err := data.GlobalStore.UpdateInterviewTemplate(&template); err != nil {
+		if errors.Is(err, data.ErrDuplicateTemplateName) {
+			writeJSONError(w, http.StatusConflict, "Template name already exists")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update template", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newInterviewTemplateResponseDTO(&template))
+}
+
+// DeleteInterviewTemplateHandler handles DELETE /templates/{id}
+func DeleteInterviewTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingTemplateID)
+		return
+	}
+
+	if err := data.GlobalStore.DeleteInterviewTemplate(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Template not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// createTestTemplate creates an interview template and returns the response
+func createTestTemplate(t *testing.T, router http.Handler, req InterviewTemplateRequestDTO) InterviewTemplateResponseDTO {
+	t.Helper()
+	var resp InterviewTemplateResponseDTO
+	doJSONRequest(t, router, "POST", "/templates", req, http.StatusCreated, &resp)
+	return resp
+}
+
+func TestInterviewTemplateHandlers_CRUD(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	req := InterviewTemplateRequestDTO{
+		Name:               "Backend Engineer L4",
+		InterviewType:      "technical",
+		Questions:          []string{"Design a rate limiter"},
+		EndPolicy:          &EndPolicyDTO{MaxUserMessages: 5},
+		EvaluationCriteria: []string{"system_design", "communication"},
+		InterviewerPersona: "pragmatic staff engineer",
+	}
+	template := createTestTemplate(t, router, req)
+	if template.InterviewLanguage != "en" {
+		t.Errorf("expected default language 'en', got %s", template.InterviewLanguage)
+	}
+
+	// Duplicate names conflict
+	b, _ := json.Marshal(req)
+	expectHTTPError(t, router, "POST", "/templates", b, http.StatusConflict)
+
+	// Update
+	req.Description = "Standard L4 loop"
+	b, _ = json.Marshal(req)
+	expectHTTPError(t, router, "PUT", "/templates/"+template.ID, b, http.StatusOK)
+
+	// List
+	httpReq := httptest.NewRequest("GET", "/templates", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+	var list ListInterviewTemplatesResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal template list: %v", err)
+	}
+	if list.Total != 1 || list.Templates[0].Description != "Standard L4 loop" {
+		t.Errorf("expected one updated template, got %+v", list)
+	}
+
+	// Delete
+	expectHTTPError(t, router, "DELETE", "/templates/"+template.ID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "GET", "/templates/"+template.ID, nil, http.StatusNotFound)
+}
+
+func TestCreateInterviewHandler_FromTemplate(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	template := createTestTemplate(t, router, InterviewTemplateRequestDTO{
+		Name:               "Support Engineer",
+		InterviewType:      "behavioral",
+		InterviewLanguage:  "zh-TW",
+		Questions:          []string{"Tell me about a difficult customer"},
+		EndPolicy:          &EndPolicyDTO{MaxUserMessages: 1},
+		InterviewerPersona: "calm support lead",
+	})
+
+	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
+		CandidateName: "Template User",
+		TemplateID:    template.ID,
+	})
+	if interview.TemplateID != template.ID {
+		t.Errorf("expected template_id %s, got %s", template.ID, interview.TemplateID)
+	}
+	if interview.InterviewType != "behavioral" || interview.InterviewLanguage != "zh-TW" {
+		t.Errorf("expected template type and language, got %s/%s", interview.InterviewType, interview.InterviewLanguage)
+	}
+	if interview.InterviewerPersona != "calm support lead" || interview.EndPolicy.MaxUserMessages != 1 {
+		t.Errorf("expected template persona and end policy, got %+v", interview)
+	}
+
+	// The template end policy ends the session after the first candidate message
+	session := startChatSession(t, router, interview.ID, nil)
+	resp := sendMessage(t, router, session.ID, "I listened and escalated")
+	if resp.SessionStatus != "completed" || resp.EvaluationJobID == "" {
+		t.Errorf("expected session to complete under template end policy with an evaluation queued, got %+v", resp)
+	}
+
+	// Unknown templates are rejected
+	b, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "X", TemplateID: "missing"})
+	expectHTTPError(t, router, "POST", "/interviews", b, http.StatusNotFound)
+}
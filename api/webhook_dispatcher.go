@@ -0,0 +1,223 @@
+// Background delivery of webhook events from the transactional outbox
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Webhook dispatch limits. Deliveries are claimed a few at a time so the claim's lease, which
+// covers attempting all of them one after another, stays short enough to recover from a crash soon.
+const (
+	webhookDispatchBatch = 100 // Events fanned out, and deliveries attempted, per tick
+	webhookClaimBatch    = 10  // Deliveries claimed at once
+)
+
+// Headers sent with every webhook delivery
+const (
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookDeliveryHeader  = "X-Webhook-Delivery"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookDispatcher periodically fans outbox events out into deliveries and sends the due ones,
+// retrying failed attempts with exponential backoff. Deliveries are at-least-once: subscribers
+// should deduplicate on the delivery's event ID.
+type WebhookDispatcher struct {
+	client       *http.Client
+	interval     time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+	timeout      time.Duration
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWebhookDispatcher creates a dispatcher from the webhook delivery configuration
+func NewWebhookDispatcher(cfg *config.Config) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		client:       &http.Client{Timeout: cfg.WebhookTimeout},
+		interval:     cfg.WebhookDispatchInterval,
+		maxAttempts:  max(cfg.WebhookMaxAttempts, 1),
+		retryBackoff: cfg.WebhookRetryBackoff,
+		timeout:      cfg.WebhookTimeout,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// Start runs the dispatcher in the background until Stop is called
+func (d *WebhookDispatcher) Start() {
+	if d.interval <= 0 {
+		utils.Warningf("Webhook dispatcher disabled; webhook events will not be delivered")
+		close(d.done)
+		return
+	}
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stop:
+				return
+			case now := <-ticker.C:
+				d.dispatch(now)
+			}
+		}
+	}()
+	utils.Infof("Webhook dispatcher started (interval %s, max attempts %d)", d.interval, d.maxAttempts)
+}
+
+// Stop signals the dispatcher to exit and waits for in-flight deliveries to finish
+func (d *WebhookDispatcher) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+	<-d.done
+}
+
+// stopping reports whether Stop has been called
+func (d *WebhookDispatcher) stopping() bool {
+	select {
+	case <-d.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// dispatch fans new outbox events out into deliveries, then attempts the deliveries due by now
+func (d *WebhookDispatcher) dispatch(now time.Time) {
+	if _, err := data.GlobalStore.DispatchWebhookEvents(now, webhookDispatchBatch); err != nil {
+		utils.Errorf("Failed to dispatch webhook events: %v", err)
+	}
+
+	// The lease outlasts attempting the whole claim, so a delivery is only retried early if the
+	// process died mid-attempt
+	lease := time.Duration(webhookClaimBatch+1) * d.timeout
+	started := time.Now()
+	for attempted := 0; attempted < webhookDispatchBatch && !d.stopping(); {
+		deliveries, err := data.GlobalStore.ClaimDueWebhookDeliveries(now.Add(time.Since(started)), lease, webhookClaimBatch)
+		if err != nil {
+			utils.Errorf("Failed to claim webhook deliveries: %v", err)
+			return
+		}
+		d.deliverBySubscription(deliveries)
+		if len(deliveries) < webhookClaimBatch {
+			return
+		}
+		attempted += len(deliveries)
+	}
+}
+
+// deliverBySubscription attempts the deliveries of each subscription in order, with subscriptions
+// served concurrently so a slow subscriber does not hold up the others
+func (d *WebhookDispatcher) deliverBySubscription(deliveries []*data.WebhookDelivery) {
+	bySubscription := make(map[string][]*data.WebhookDelivery)
+	for _, delivery := range deliveries {
+		bySubscription[delivery.SubscriptionID] = append(bySubscription[delivery.SubscriptionID], delivery)
+	}
+
+	var wg sync.WaitGroup
+	for _, subscriptionDeliveries := range bySubscription {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, delivery := range subscriptionDeliveries {
+				if d.stopping() {
+					return
+				}
+				d.deliver(delivery)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// deliver makes one attempt at a delivery and records the outcome
+func (d *WebhookDispatcher) deliver(delivery *data.WebhookDelivery) {
+	subscription, err := data.GlobalStore.GetWebhookSubscription(delivery.SubscriptionID)
+	if err != nil || !subscription.Active {
+		d.fail(delivery, 0, "subscription deleted or inactive", false)
+		return
+	}
+	event, err := data.GlobalStore.GetWebhookEvent(delivery.EventID)
+	if err != nil {
+		d.fail(delivery, 0, "event not found", false)
+		return
+	}
+
+	status, err := d.post(subscription, event, delivery)
+	if err != nil {
+		d.fail(delivery, status, err.Error(), true)
+		return
+	}
+	if err := data.GlobalStore.CompleteWebhookDelivery(delivery.ID, status); err != nil {
+		utils.Errorf("Failed to mark webhook delivery %s succeeded: %v", delivery.ID, err)
+	}
+}
+
+// post sends the event's payload to the subscription, signed with its secret, returning the
+// response status. Any status outside 2xx is an error.
+func (d *WebhookDispatcher) post(subscription *data.WebhookSubscription, event *data.WebhookEvent, delivery *data.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewBufferString(event.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("invalid request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, event.Type)
+	req.Header.Set(webhookDeliveryHeader, delivery.ID)
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, signWebhookPayload(subscription.Secret, timestamp, event.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// fail records a failed attempt, scheduling a retry when one is allowed and attempts remain
+func (d *WebhookDispatcher) fail(delivery *data.WebhookDelivery, status int, lastError string, retryable bool) {
+	if !retryable || delivery.Attempts >= d.maxAttempts {
+		utils.Warningf("Webhook delivery %s (%s) failed after %d attempts: %s", delivery.ID, delivery.EventType, delivery.Attempts, lastError)
+		if err := data.GlobalStore.FailWebhookDelivery(delivery.ID, status, lastError, nil); err != nil {
+			utils.Errorf("Failed to mark webhook delivery %s failed: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	retryAt := time.Now().Add(d.retryBackoff << min(delivery.Attempts-1, maxRetryBackoffDoublings))
+	if err := data.GlobalStore.FailWebhookDelivery(delivery.ID, status, lastError, &retryAt); err != nil {
+		utils.Errorf("Failed to reschedule webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// signWebhookPayload computes the signature header value: the hex HMAC-SHA256 of the timestamp
+// and body joined by a dot, keyed with the subscription secret
+func signWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
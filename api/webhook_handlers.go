@@ -0,0 +1,279 @@
+// HTTP handler functions for webhook subscriptions and their delivery log
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+// Webhook delivery log listing limits
+const (
+	defaultWebhookDeliveryListLimit = 50
+	maxWebhookDeliveryListLimit     = 200
+)
+
+// Helper: build an outbox event whose payload is the JSON envelope POSTed to subscribers
+func newWebhookEvent(eventType string, payload interface{}) *data.WebhookEvent {
+	event := &data.WebhookEvent{
+		ID:        data.GenerateID(),
+		Type:      eventType,
+		CreatedAt: time.Now(),
+	}
+	body, err := json.Marshal(WebhookEventDTO{ID: event.ID, Type: eventType, CreatedAt: event.CreatedAt, Data: payload})
+	if err != nil {
+		// The event still reaches subscribers so they can fetch the resource themselves
+		utils.Errorf("Failed to encode %s webhook payload: %v", eventType, err)
+		body, _ = json.Marshal(WebhookEventDTO{ID: event.ID, Type: eventType, CreatedAt: event.CreatedAt})
+	}
+	event.Payload = string(body)
+	return event
+}
+
+// Helper: build a session.started or session.completed event for a chat session
+func newSessionWebhookEvent(eventType string, session *data.ChatSession) *data.WebhookEvent {
+	return newWebhookEvent(eventType, WebhookSessionDTO{
+		SessionID:       session.ID,
+		InterviewID:     session.InterviewID,
+		Status:          session.Status,
+		SessionLanguage: session.SessionLanguage,
+		StartedAt:       session.StartedAt,
+		EndedAt:         session.EndedAt,
+	})
+}
+
+// Helper: generate a random signing secret for a subscription that did not supply one
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Helper: validate a webhook subscription request, returning an error message or "" if valid
+func validateWebhookSubscriptionRequest(req *WebhookSubscriptionRequestDTO) string {
+	target, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return "url must be an absolute http or https URL"
+	}
+	if len(req.EventTypes) == 0 {
+		return "At least one event type is required"
+	}
+	for _, eventType := range req.EventTypes {
+		if !data.ValidateWebhookEventType(eventType) {
+			return "Invalid event type: " + eventType
+		}
+	}
+	return ""
+}
+
+// Helper: convert a webhook subscription to its response DTO; the secret is never included
+func newWebhookSubscriptionResponseDTO(subscription *data.WebhookSubscription) WebhookSubscriptionResponseDTO {
+	return WebhookSubscriptionResponseDTO{
+		ID:          subscription.ID,
+		URL:         subscription.URL,
+		EventTypes:  subscription.EventTypes,
+		Description: subscription.Description,
+		Active:      subscription.Active,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+// Helper: convert a webhook delivery to its DTO
+func newWebhookDeliveryDTO(delivery *data.WebhookDelivery) WebhookDeliveryDTO {
+	dto := WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == data.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		dto.NextAttemptAt = &nextAttemptAt
+	}
+	return dto
+}
+
+// CreateWebhookHandler handles POST /webhooks
+// The signing secret is returned only in this response.
+func CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	var req WebhookSubscriptionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if msg := validateWebhookSubscriptionRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := newWebhookSecret()
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to generate webhook secret")
+			return
+		}
+		secret = generated
+	}
+	subscription := &data.WebhookSubscription{
+		ID:          data.GenerateID(),
+		URL:         strings.TrimSpace(req.URL),
+		Secret:      secret,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		Active:      req.Active == nil || *req.Active,
+	}
+	if err := data.GlobalStore.CreateWebhookSubscription(subscription); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to create webhook", err.Error())
+		return
+	}
+
+	resp := newWebhookSubscriptionResponseDTO(subscription)
+	resp.Secret = secret
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// ListWebhooksHandler handles GET /webhooks
+func ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := data.GlobalStore.GetWebhookSubscriptions()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to list webhooks")
+		return
+	}
+	resp := ListWebhookSubscriptionsResponseDTO{
+		Webhooks: make([]WebhookSubscriptionResponseDTO, 0, len(subscriptions)),
+		Total:    len(subscriptions),
+	}
+	for _, subscription := range subscriptions {
+		resp.Webhooks = append(resp.Webhooks, newWebhookSubscriptionResponseDTO(subscription))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetWebhookHandler handles GET /webhooks/{id}
+func GetWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	subscription, err := data.GlobalStore.GetWebhookSubscription(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookSubscriptionResponseDTO(subscription))
+}
+
+// UpdateWebhookHandler handles PUT /webhooks/{id}
+// Replaces the subscription's settings, keeping the current secret when none is given.
+func UpdateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	existing, err := data.GlobalStore.GetWebhookSubscription(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	var req WebhookSubscriptionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+	if msg := validateWebhookSubscriptionRequest(&req); msg != "" {
+		writeJSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Replace rather than mutate so readers holding the previous pointer see a consistent record
+	updated := *existing
+	updated.URL = strings.TrimSpace(req.URL)
+	updated.EventTypes = req.EventTypes
+	updated.Description = req.Description
+	updated.Active = req.Active == nil || *req.Active
+	if req.Secret != "" {
+		updated.Secret = req.Secret
+	}
+	updated.UpdatedAt = time.Now()
+	if err := data.GlobalStore.UpdateWebhookSubscription(&updated); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to update webhook", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, newWebhookSubscriptionResponseDTO(&updated))
+}
+
+// DeleteWebhookHandler handles DELETE /webhooks/{id}
+func DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := data.GlobalStore.GetWebhookSubscription(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if err := data.GlobalStore.DeleteWebhookSubscription(id); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to delete webhook", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveriesHandler handles GET /webhooks/{id}/deliveries
+// Lists the subscription's most recent deliveries; ?status=failed shows those that ran out of attempts.
+func ListWebhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if _, err := data.GlobalStore.GetWebhookSubscription(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	filters := data.WebhookDeliveryFilters{
+		Status:    r.URL.Query().Get("status"),
+		EventType: r.URL.Query().Get("event_type"),
+	}
+	switch filters.Status {
+	case "", data.WebhookDeliveryPending, data.WebhookDeliverySucceeded, data.WebhookDeliveryFailed:
+	default:
+		writeJSONError(w, http.StatusBadRequest, "Invalid status", "status must be one of: pending, succeeded, failed")
+		return
+	}
+	if filters.EventType != "" && !data.ValidateWebhookEventType(filters.EventType) {
+		writeJSONError(w, http.StatusBadRequest, "Invalid event_type")
+		return
+	}
+	limit := min(parseIntQuery(r, "limit", defaultWebhookDeliveryListLimit), maxWebhookDeliveryListLimit)
+
+	deliveries, err := data.GlobalStore.ListWebhookDeliveries(id, filters, limit)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to list webhook deliveries")
+		return
+	}
+	resp := ListWebhookDeliveriesResponseDTO{Deliveries: make([]WebhookDeliveryDTO, 0, len(deliveries)), Total: len(deliveries)}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, newWebhookDeliveryDTO(delivery))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ReplayWebhookDeliveryHandler handles POST /webhooks/{id}/deliveries/{deliveryId}/replay
+// Queues a new delivery of the same event, leaving the original in the log.
+func ReplayWebhookDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	delivery, err := data.GlobalStore.GetWebhookDelivery(chi.URLParam(r, "deliveryId"))
+	if err != nil || delivery.SubscriptionID != chi.URLParam(r, "id") {
+		writeJSONError(w, http.StatusNotFound, "Webhook delivery not found")
+		return
+	}
+	replay, err := data.GlobalStore.ReplayWebhookDelivery(delivery.ID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to replay webhook delivery", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, newWebhookDeliveryDTO(replay))
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/AI_Interview_Backend/config"
+	"github.com/zidane0000/AI_Interview_Backend/data"
+)
+
+func TestWebhooks(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	// A subscriber that records signed deliveries and can be told to fail
+	var mu sync.Mutex
+	var received []WebhookEventDTO
+	failing := false
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get(webhookSignatureHeader) != signWebhookPayload("test-secret", r.Header.Get(webhookTimestampHeader), string(body)) {
+			t.Errorf("delivery %s has an invalid signature", r.Header.Get(webhookDeliveryHeader))
+		}
+		var event WebhookEventDTO
+		_ = json.Unmarshal(body, &event)
+		if event.Type != r.Header.Get(webhookEventHeader) {
+			t.Errorf("expected event header %q to match body type %q", r.Header.Get(webhookEventHeader), event.Type)
+		}
+		received = append(received, event)
+	}))
+	defer subscriber.Close()
+
+	// Invalid subscriptions are rejected
+	expectHTTPError(t, router, "POST", "/webhooks", []byte(`{"url":"ftp://example.com","event_types":["interview.created"]}`), http.StatusBadRequest)
+	expectHTTPError(t, router, "POST", "/webhooks", []byte(`{"url":"https://example.com","event_types":["interview.deleted"]}`), http.StatusBadRequest)
+
+	b, _ := json.Marshal(WebhookSubscriptionRequestDTO{
+		URL:        subscriber.URL,
+		Secret:     "test-secret",
+		EventTypes: []string{data.WebhookEventInterviewCreated, data.WebhookEventSessionStarted, data.WebhookEventSessionCompleted},
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks", bytes.NewReader(b)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 creating webhook, got %d: %s", w.Code, w.Body.String())
+	}
+	var webhook WebhookSubscriptionResponseDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &webhook)
+	if webhook.Secret != "test-secret" || !webhook.Active {
+		t.Fatalf("expected active webhook with its secret, got %+v", webhook)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/"+webhook.ID, nil))
+	if bytes.Contains(w.Body.Bytes(), []byte("test-secret")) {
+		t.Error("expected the secret to be returned only on create")
+	}
+
+	dispatcher := NewWebhookDispatcher(&config.Config{WebhookTimeout: 5 * time.Second, WebhookMaxAttempts: 2, WebhookRetryBackoff: time.Minute})
+	listDeliveries := func(query string) []WebhookDeliveryDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/webhooks/"+webhook.ID+"/deliveries"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200 listing deliveries, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp ListWebhookDeliveriesResponseDTO
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp.Deliveries
+	}
+
+	// Events recorded by the handlers are delivered once the dispatcher runs
+	ids := createTestInterviewAndSession(t, router)
+	if len(received) != 0 {
+		t.Fatal("expected no deliveries before the dispatcher runs")
+	}
+	dispatcher.dispatch(time.Now())
+	if len(received) != 2 || received[0].Type != data.WebhookEventInterviewCreated || received[1].Type != data.WebhookEventSessionStarted {
+		t.Fatalf("expected interview.created then session.started, got %+v", received)
+	}
+	if started, _ := received[1].Data.(map[string]interface{}); started["session_id"] != ids.SessionID {
+		t.Errorf("expected session.started for %s, got %+v", ids.SessionID, received[1].Data)
+	}
+	if succeeded := listDeliveries("?status=succeeded"); len(succeeded) != 2 || succeeded[0].Attempts != 1 || succeeded[0].ResponseStatus != http.StatusOK {
+		t.Fatalf("expected 2 succeeded deliveries, got %+v", succeeded)
+	}
+
+	// Failed attempts are retried with backoff until they run out
+	failing = true
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/chat/"+ids.SessionID+"/end", nil))
+	now := time.Now()
+	dispatcher.dispatch(now)
+	pending := listDeliveries("?event_type=session.completed")
+	if len(pending) != 1 || pending[0].Status != data.WebhookDeliveryPending || pending[0].ResponseStatus != http.StatusServiceUnavailable ||
+		pending[0].NextAttemptAt == nil || !pending[0].NextAttemptAt.After(now.Add(59*time.Second)) {
+		t.Fatalf("expected a delivery retrying in about a minute, got %+v", pending)
+	}
+	dispatcher.dispatch(now.Add(2 * time.Minute))
+	failed := listDeliveries("?status=failed")
+	if len(failed) != 1 || failed[0].Attempts != 2 {
+		t.Fatalf("expected the delivery failed after 2 attempts, got %+v", failed)
+	}
+
+	// A failed delivery can be replayed once the subscriber recovers
+	failing = false
+	expectHTTPError(t, router, "POST", "/webhooks/"+webhook.ID+"/deliveries/missing/replay", nil, http.StatusNotFound)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks/"+webhook.ID+"/deliveries/"+failed[0].ID+"/replay", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 replaying delivery, got %d: %s", w.Code, w.Body.String())
+	}
+	var replay WebhookDeliveryDTO
+	_ = json.Unmarshal(w.Body.Bytes(), &replay)
+	if replay.ReplayOf != failed[0].ID || replay.EventID != failed[0].EventID {
+		t.Fatalf("expected a replay of %s, got %+v", failed[0].ID, replay)
+	}
+	dispatcher.dispatch(time.Now())
+	if last := received[len(received)-1]; len(received) != 3 || last.Type != data.WebhookEventSessionCompleted {
+		t.Fatalf("expected the replayed session.completed delivered, got %+v", received)
+	}
+
+	// Unsubscribed event types and inactive subscriptions receive nothing
+	b, _ = json.Marshal(WebhookSubscriptionRequestDTO{URL: subscriber.URL, EventTypes: []string{data.WebhookEventInterviewCreated}, Active: new(bool)})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/webhooks/"+webhook.ID, bytes.NewReader(b)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 updating webhook, got %d: %s", w.Code, w.Body.String())
+	}
+	createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Inactive", Questions: []string{"Q1"}, InterviewType: "general"})
+	dispatcher.dispatch(time.Now())
+	if len(received) != 3 {
+		t.Errorf("expected no deliveries to an inactive webhook, got %d", len(received))
+	}
+
+	expectHTTPError(t, router, "DELETE", "/webhooks/"+webhook.ID, nil, http.StatusNoContent)
+	expectHTTPError(t, router, "GET", "/webhooks/"+webhook.ID+"/deliveries", nil, http.StatusNotFound)
+}
+
+func TestWebhookDispatcher_SubscribersServedConcurrently(t *testing.T) {
+	clearMemoryStore()
+	router := setupTestRouter()
+
+	// Each subscriber holds its delivery until the other's arrives, which only happens when
+	// neither waits for the other to finish
+	arrived := make(chan struct{}, 2)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		for len(arrived) < 2 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}))
+	defer subscriber.Close()
+
+	for _, path := range []string{"/slow", "/fast"} {
+		b, _ := json.Marshal(WebhookSubscriptionRequestDTO{URL: subscriber.URL + path, EventTypes: []string{data.WebhookEventInterviewCreated}})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/webhooks", bytes.NewReader(b)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201 creating webhook, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	createTestInterview(t, router, CreateInterviewRequestDTO{CandidateName: "Concurrent", Questions: []string{"Q1"}, InterviewType: "general"})
+
+	dispatcher := NewWebhookDispatcher(&config.Config{WebhookTimeout: 2 * time.Second, WebhookMaxAttempts: 1})
+	dispatcher.dispatch(time.Now())
+	if len(arrived) != 2 {
+		t.Fatalf("expected both subscribers to receive the event, got %d", len(arrived))
+	}
+	webhooks, _ := data.GlobalStore.GetWebhookSubscriptions()
+	for _, webhook := range webhooks {
+		deliveries, _ := data.GlobalStore.ListWebhookDeliveries(webhook.ID, data.WebhookDeliveryFilters{}, 10)
+		if len(deliveries) != 1 || deliveries[0].Status != data.WebhookDeliverySucceeded {
+			t.Errorf("expected the delivery to %s to succeed, got %+v", webhook.URL, deliveries)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"time"
 
@@ -22,14 +23,55 @@ type Config struct {
 	GeminiAPIKey string
 	OpenAIAPIKey string
 
+	// Chat session expiry configuration
+	SessionSweepInterval      time.Duration // How often the sweeper runs
+	SessionInactivityTimeout  time.Duration // Active sessions idle this long are abandoned; 0 disables
+	AbandonedSessionRetention time.Duration // Abandoned sessions are purged this long after ending; 0 keeps them
+	EvaluateAbandonedSessions bool          // Run a partial evaluation when a session is abandoned
+
+	// Background job configuration
+	JobWorkers      int           // Concurrent job workers; 0 disables processing
+	JobPollInterval time.Duration // How often idle workers look for due jobs
+	JobMaxAttempts  int           // Attempts new jobs get before they are dead-lettered
+	JobRetryBackoff time.Duration // Delay before the first retry, doubling for each further attempt
+	JobTimeout      time.Duration // Running jobs older than this are presumed lost and requeued
+
+	// Webhook delivery configuration
+	WebhookDispatchInterval time.Duration // How often outbox events are fanned out and due deliveries sent; 0 disables
+	WebhookMaxAttempts      int           // Attempts a delivery gets before it is marked failed
+	WebhookRetryBackoff     time.Duration // Delay before the first retry, doubling for each further attempt
+	WebhookTimeout          time.Duration // Timeout of a single delivery request
+
+	// File upload configuration
+	UploadPath    string // Directory of the local blob store; empty disables uploads
+	MaxUploadSize int64  // Largest accepted upload in bytes
+
+	// Access control configuration. One of the staff token, JWT secret or JWKS file must be set
+	// unless AuthDisabled opts into running without credentials.
+	AuthDisabled      bool          // Accept unauthenticated requests as admin; for local development and e2e runs only
+	StaffAPIToken     string        // Static bearer token accepted as admin credentials
+	JWTSecret         string        // Key verifying HS256 staff JWTs; their role or roles claim sets the role
+	JWKSFile          string        // JWKS file with the public keys verifying RS256 staff JWTs
+	JWTIssuer         string        // Required iss claim of JWTs; empty accepts any
+	JWTAudience       string        // Required aud claim of JWTs; empty accepts any
+	InvitationSecret  string        // Key signing candidate invitation tokens; empty disables invitations
+	InvitationTTL     time.Duration // How long invitation links stay valid unless the request says otherwise
+	InvitationBaseURL string        // Candidate-facing page invitation links point at; empty returns tokens only
+
 	// TODO: Add more AI providers
-	// TODO: Add file upload configuration
-	// TODO: Add security configuration
 	// TODO: Add logging configuration
 	// TODO: Add internationalization configuration
 	// TODO: Add email/notification configuration
 }
 
+// ErrNoStaffCredentials is returned when no staff credentials are configured and authentication is not disabled
+var ErrNoStaffCredentials = errors.New("no staff credentials configured: set STAFF_API_TOKEN, JWT_SECRET or JWT_JWKS_FILE, or AUTH_DISABLED=true for local development")
+
+// HasStaffCredentials reports whether any way for staff to authenticate is configured
+func (c *Config) HasStaffCredentials() bool {
+	return c.StaffAPIToken != "" || c.JWTSecret != "" || c.JWKSFile != ""
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	// Load .env file (ignore error if file doesn't exist)
@@ -41,11 +83,43 @@ func LoadConfig() (*Config, error) {
 		GeminiAPIKey:    os.Getenv("GEMINI_API_KEY"),
 		OpenAIAPIKey:    os.Getenv("OPENAI_API_KEY"),
 		ShutdownTimeout: utils.GetEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		SessionSweepInterval:      utils.GetEnvDuration("SESSION_SWEEP_INTERVAL", time.Minute),
+		SessionInactivityTimeout:  utils.GetEnvDuration("SESSION_INACTIVITY_TIMEOUT", 30*time.Minute),
+		AbandonedSessionRetention: utils.GetEnvDuration("ABANDONED_SESSION_RETENTION", 30*24*time.Hour),
+		EvaluateAbandonedSessions: utils.GetEnvBool("EVALUATE_ABANDONED_SESSIONS", false),
+
+		JobWorkers:      utils.GetEnvInt("JOB_WORKERS", 2),
+		JobPollInterval: utils.GetEnvDuration("JOB_POLL_INTERVAL", time.Second),
+		JobMaxAttempts:  utils.GetEnvInt("JOB_MAX_ATTEMPTS", 3),
+		JobRetryBackoff: utils.GetEnvDuration("JOB_RETRY_BACKOFF", 10*time.Second),
+		JobTimeout:      utils.GetEnvDuration("JOB_TIMEOUT", 10*time.Minute),
+
+		WebhookDispatchInterval: utils.GetEnvDuration("WEBHOOK_DISPATCH_INTERVAL", 2*time.Second),
+		WebhookMaxAttempts:      utils.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookRetryBackoff:     utils.GetEnvDuration("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+		WebhookTimeout:          utils.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+
+		UploadPath:    utils.GetEnvString("UPLOAD_PATH", "./uploads"),
+		MaxUploadSize: int64(utils.GetEnvInt("MAX_UPLOAD_SIZE", 5<<20)),
+
+		AuthDisabled:      utils.GetEnvBool("AUTH_DISABLED", false),
+		StaffAPIToken:     os.Getenv("STAFF_API_TOKEN"),
+		JWTSecret:         os.Getenv("JWT_SECRET"),
+		JWKSFile:          os.Getenv("JWT_JWKS_FILE"),
+		JWTIssuer:         os.Getenv("JWT_ISSUER"),
+		JWTAudience:       os.Getenv("JWT_AUDIENCE"),
+		InvitationSecret:  os.Getenv("INVITATION_SECRET"),
+		InvitationTTL:     utils.GetEnvDuration("INVITATION_TTL", 7*24*time.Hour),
+		InvitationBaseURL: os.Getenv("INVITATION_BASE_URL"),
+	}
+
+	// Refuse to start without a way to authenticate rather than leave every endpoint open
+	if !cfg.AuthDisabled && !cfg.HasStaffCredentials() {
+		return nil, ErrNoStaffCredentials
 	}
 
-	// TODO: Load file upload configuration(cfg.UploadPath, cfg.MaxFileSize)
-	// TODO: Load security configuration(cfg.JWTSecret, cfg.CORSOrigins)
-	// TODO: Validate file paths and create directories if needed
+	// TODO: Load security configuration(cfg.CORSOrigins)
 	// TODO: Validate email configuration if notifications are enabled
 	// TODO: Load configuration from config files (YAML, JSON, TOML)
 	// TODO: Add configuration hot-reloading capability
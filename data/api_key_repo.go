@@ -0,0 +1,80 @@
+// API key data access
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository interface defines the contract for API key data access
+type APIKeyRepository interface {
+	Create(key *APIKey) error
+	GetByID(id string) (*APIKey, error)
+	GetByHash(hash string) (*APIKey, error)
+	List() ([]*APIKey, error)
+	Touch(id string, usedAt time.Time) error
+	Revoke(id string) error
+}
+
+// apiKeyRepository implements APIKeyRepository interface
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// Create creates a new API key
+func (r *apiKeyRepository) Create(key *APIKey) error {
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = time.Now()
+	return r.db.Create(key).Error
+}
+
+// GetByID retrieves an API key by ID
+func (r *apiKeyRepository) GetByID(id string) (*APIKey, error) {
+	var key APIKey
+	err := r.db.Where("id = ?", id).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("api key not found")
+	}
+	return &key, err
+}
+
+// GetByHash retrieves the API key with the given key hash
+func (r *apiKeyRepository) GetByHash(hash string) (*APIKey, error) {
+	var key APIKey
+	err := r.db.Where("key_hash = ?", hash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("api key not found")
+	}
+	return &key, err
+}
+
+// List retrieves every API key, revoked ones included, newest first
+func (r *apiKeyRepository) List() ([]*APIKey, error) {
+	var keys []*APIKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// Touch records when an API key was last used, without bumping updated_at
+func (r *apiKeyRepository) Touch(id string, usedAt time.Time) error {
+	return r.db.Model(&APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+}
+
+// Revoke stops an API key from authenticating; revoking it again is a no-op
+func (r *apiKeyRepository) Revoke(id string) error {
+	now := time.Now()
+	result := r.db.Model(&APIKey{}).Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	_, err := r.GetByID(id)
+	return err
+}
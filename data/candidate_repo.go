@@ -0,0 +1,183 @@
+// Candidate data access (CRUD operations and interview history)
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Candidate conflicts
+var (
+	ErrDuplicateCandidate     = errors.New("a candidate with this email or external ID already exists")
+	ErrCandidateHasInterviews = errors.New("candidate has interviews")
+)
+
+// CandidateFilters defines filter options for candidate queries
+type CandidateFilters struct {
+	Name       string // Case-insensitive partial match
+	Email      string // Exact match
+	ExternalID string // Exact match
+	CreatedBy  string // Candidates created by this principal or linked to their interviews
+}
+
+// CandidateHistory is everything recorded about a candidate's interviews, oldest first
+type CandidateHistory struct {
+	Interviews  []*Interview
+	Sessions    []*ChatSession
+	Evaluations []*Evaluation
+}
+
+// CandidateRepository interface defines the contract for candidate data access
+type CandidateRepository interface {
+	Create(candidate *Candidate) error
+	GetByID(id string) (*Candidate, error)
+	List(limit, offset int, filters CandidateFilters) ([]*Candidate, int64, error)
+	Update(id string, updates map[string]interface{}) error
+	Delete(id string) error
+	History(id string) (*CandidateHistory, error)
+}
+
+// candidateRepository implements CandidateRepository interface
+type candidateRepository struct {
+	db *gorm.DB
+}
+
+// NewCandidateRepository creates a new candidate repository
+func NewCandidateRepository(db *gorm.DB) CandidateRepository {
+	return &candidateRepository{db: db}
+}
+
+// Create creates a new candidate, rejecting a duplicate email or external ID
+func (r *candidateRepository) Create(candidate *Candidate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkCandidateUnique(tx, candidate.Email, candidate.ExternalID, ""); err != nil {
+			return err
+		}
+		candidate.CreatedAt = time.Now()
+		candidate.UpdatedAt = time.Now()
+		return tx.Create(candidate).Error
+	})
+}
+
+// GetByID retrieves a candidate by ID
+func (r *candidateRepository) GetByID(id string) (*Candidate, error) {
+	var candidate Candidate
+	err := r.db.Where("id = ?", id).First(&candidate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("candidate not found")
+	}
+	return &candidate, err
+}
+
+// List retrieves candidates by name with pagination and filtering
+func (r *candidateRepository) List(limit, offset int, filters CandidateFilters) ([]*Candidate, int64, error) {
+	var candidates []*Candidate
+	var total int64
+
+	query := r.db.Model(&Candidate{})
+	if filters.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+filters.Name+"%")
+	}
+	if filters.Email != "" {
+		query = query.Where("email = ?", filters.Email)
+	}
+	if filters.ExternalID != "" {
+		query = query.Where("external_id = ?", filters.ExternalID)
+	}
+	if filters.CreatedBy != "" {
+		query = query.Where("created_by = ? OR id IN (SELECT candidate_id FROM interviews WHERE created_by = ?)", filters.CreatedBy, filters.CreatedBy)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("name ASC").Order("id ASC").Limit(limit).Offset(offset).Find(&candidates).Error
+	return candidates, total, err
+}
+
+// Update updates a candidate, rejecting an email or external ID used by another candidate
+func (r *candidateRepository) Update(id string, updates map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		email, _ := updates["email"].(string)
+		externalID, _ := updates["external_id"].(string)
+		if err := checkCandidateUnique(tx, email, externalID, id); err != nil {
+			return err
+		}
+		updates["updated_at"] = time.Now()
+		result := tx.Model(&Candidate{}).Where("id = ?", id).Updates(updates)
+		if result.Error == nil && result.RowsAffected == 0 {
+			return errors.New("candidate not found")
+		}
+		return result.Error
+	})
+}
+
+// Delete deletes a candidate who has no interviews
+func (r *candidateRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var interviews int64
+		if err := tx.Model(&Interview{}).Where("candidate_id = ?", id).Count(&interviews).Error; err != nil {
+			return err
+		}
+		if interviews > 0 {
+			return ErrCandidateHasInterviews
+		}
+		result := tx.Where("id = ?", id).Delete(&Candidate{})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return errors.New("candidate not found")
+		}
+		return result.Error
+	})
+}
+
+// History retrieves the candidate's interviews with their chat sessions and evaluations
+func (r *candidateRepository) History(id string) (*CandidateHistory, error) {
+	history := &CandidateHistory{}
+	if err := r.db.Where("candidate_id = ?", id).Order("created_at ASC").Find(&history.Interviews).Error; err != nil {
+		return nil, err
+	}
+	if len(history.Interviews) == 0 {
+		return history, nil
+	}
+
+	interviewIDs := make([]string, len(history.Interviews))
+	for i, interview := range history.Interviews {
+		interviewIDs[i] = interview.ID
+	}
+	if err := r.db.Where("interview_id IN ?", interviewIDs).Order("started_at ASC").Find(&history.Sessions).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("interview_id IN ?", interviewIDs).Order("created_at ASC").Find(&history.Evaluations).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// checkCandidateUnique returns ErrDuplicateCandidate if another candidate already uses the email or external ID
+func checkCandidateUnique(tx *gorm.DB, email, externalID, excludeID string) error {
+	if email == "" && externalID == "" {
+		return nil
+	}
+	query := tx.Model(&Candidate{})
+	switch {
+	case email != "" && externalID != "":
+		query = query.Where("email = ? OR external_id = ?", email, externalID)
+	case email != "":
+		query = query.Where("email = ?", email)
+	default:
+		query = query.Where("external_id = ?", externalID)
+	}
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateCandidate
+	}
+	return nil
+}
@@ -6,8 +6,20 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Chat session conflicts
+var (
+	ErrSessionLimitReached = errors.New("interview has no retakes remaining")
+	ErrChatSessionActive   = errors.New("chat session is still active")
+	ErrChatSessionEnded    = errors.New("chat session has already ended")
+	ErrStaleChatMessage    = errors.New("chat message is no longer the latest in its session")
+)
+
+// lastActivitySQL is when a session last saw a message, falling back to when it started
+const lastActivitySQL = "COALESCE((SELECT MAX(m.timestamp) FROM chat_messages m WHERE m.session_id = chat_sessions.id), chat_sessions.created_at)"
+
 // ChatSessionFilters defines filter options for chat session queries
 type ChatSessionFilters struct {
 	InterviewID   string
@@ -19,13 +31,21 @@ type ChatSessionFilters struct {
 // ChatSessionRepository interface defines the contract for chat session data access
 type ChatSessionRepository interface {
 	Create(session *ChatSession) error
+	CreateForInterview(session *ChatSession, invitationID string, greeting *ChatMessage, events ...*WebhookEvent) error
 	GetByID(id string) (*ChatSession, error)
 	GetByInterviewID(interviewID string) (*ChatSession, error)
+	ListByInterviewID(interviewID string) ([]*ChatSession, error)
 	List(limit, offset int, filters ChatSessionFilters) ([]*ChatSession, int64, error)
-	Update(id string, updates map[string]interface{}) error
+	Update(id string, updates map[string]interface{}, events ...*WebhookEvent) error
+	Complete(id string, endedAt time.Time, job *Job, events ...*WebhookEvent) error
 	Delete(id string) error
+	AbandonInactive(inactiveSince time.Time) ([]*ChatSession, error)
+	PurgeAbandoned(endedBefore time.Time) (int64, error)
 	AddMessage(sessionID string, message *ChatMessage) error
 	GetMessages(sessionID string) ([]*ChatMessage, error)
+	GetMessageHistory(sessionID string) ([]*ChatMessage, error)
+	ReplaceMessages(sessionID string, replacements map[string]*ChatMessage, appended ...*ChatMessage) error
+	GetMessageByID(id string) (*ChatMessage, error)
 }
 
 // chatSessionRepository implements ChatSessionRepository interface
@@ -45,6 +65,53 @@ func (r *chatSessionRepository) Create(session *ChatSession) error {
 	return r.db.Create(session).Error
 }
 
+// CreateForInterview creates a chat session if the interview has no active session and has
+// retakes left. The interview row is locked so concurrent starts cannot both pass the checks.
+// A use of the invitation the session is started with, if any, the greeting message and the
+// webhook events are recorded in the same transaction, so none is left behind without the session.
+func (r *chatSessionRepository) CreateForInterview(session *ChatSession, invitationID string, greeting *ChatMessage, events ...*WebhookEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var interview Interview
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", session.InterviewID).First(&interview).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("interview not found")
+		}
+		if err != nil {
+			return err
+		}
+		// The invitation is used first so a spent one is reported as such; it rolls back with the session
+		if invitationID != "" {
+			if err := useInvitation(tx, invitationID); err != nil {
+				return err
+			}
+		}
+		if err := checkNoActiveSession(tx, interview.ID); err != nil {
+			return err
+		}
+		var total int64
+		if err := tx.Model(&ChatSession{}).Where("interview_id = ?", interview.ID).Count(&total).Error; err != nil {
+			return err
+		}
+		if int(total) >= interview.MaxSessions() {
+			return ErrSessionLimitReached
+		}
+
+		session.CreatedAt = time.Now()
+		session.UpdatedAt = time.Now()
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		if greeting != nil {
+			greeting.SessionID = session.ID
+			greeting.CreatedAt = time.Now()
+			if err := tx.Create(greeting).Error; err != nil {
+				return err
+			}
+		}
+		return createWebhookEvents(tx, events)
+	})
+}
+
 // GetByID retrieves a chat session by ID
 func (r *chatSessionRepository) GetByID(id string) (*ChatSession, error) {
 	var session ChatSession
@@ -65,6 +132,13 @@ func (r *chatSessionRepository) GetByInterviewID(interviewID string) (*ChatSessi
 	return &session, err
 }
 
+// ListByInterviewID returns all sessions of an interview, oldest first
+func (r *chatSessionRepository) ListByInterviewID(interviewID string) ([]*ChatSession, error) {
+	var sessions []*ChatSession
+	err := r.db.Where("interview_id = ?", interviewID).Order("created_at ASC").Find(&sessions).Error
+	return sessions, err
+}
+
 // List retrieves chat sessions with pagination and filtering
 func (r *chatSessionRepository) List(limit, offset int, filters ChatSessionFilters) ([]*ChatSession, int64, error) {
 	var sessions []*ChatSession
@@ -94,17 +168,98 @@ func (r *chatSessionRepository) List(limit, offset int, filters ChatSessionFilte
 	return sessions, total, err
 }
 
-// Update updates a chat session
-func (r *chatSessionRepository) Update(id string, updates map[string]interface{}) error {
+// Update updates a chat session, recording the webhook events in the same transaction
+func (r *chatSessionRepository) Update(id string, updates map[string]interface{}, events ...*WebhookEvent) error {
 	updates["updated_at"] = time.Now()
-	return r.db.Model(&ChatSession{}).Where("id = ?", id).Updates(updates).Error
+	return withWebhookEvents(r.db, events, func(tx *gorm.DB) error {
+		return tx.Model(&ChatSession{}).Where("id = ?", id).Updates(updates).Error
+	})
+}
+
+// Complete marks an active or paused session completed, queuing its evaluation job, if any, and
+// recording the webhook events in the same transaction. The status check is part of the update, so
+// a session that already ended, e.g. abandoned by the sweeper, is left alone with ErrChatSessionEnded.
+func (r *chatSessionRepository) Complete(id string, endedAt time.Time, job *Job, events ...*WebhookEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ChatSession{}).Where("id = ? AND status IN ?", id, openSessionStatuses).
+			Updates(map[string]interface{}{"status": "completed", "ended_at": endedAt, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&ChatSession{}).Where("id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errors.New("chat session not found")
+			}
+			return ErrChatSessionEnded
+		}
+		if job != nil {
+			if err := tx.Create(job).Error; err != nil {
+				return err
+			}
+		}
+		return createWebhookEvents(tx, events)
+	})
 }
 
-// Delete deletes a chat session
+// Delete deletes a finished chat session and its messages; evaluations of the session are kept
+// but lose their session link
 func (r *chatSessionRepository) Delete(id string) error {
-	// Also delete associated messages
-	r.db.Where("session_id = ?", id).Delete(&ChatMessage{})
-	return r.db.Where("id = ?", id).Delete(&ChatSession{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var session ChatSession
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&session).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("chat session not found")
+		}
+		if err != nil {
+			return err
+		}
+		if session.IsOpen() {
+			return ErrChatSessionActive
+		}
+		return deleteChatSessions(tx, tx.Model(&ChatSession{}).Select("id").Where("id = ?", id))
+	})
+}
+
+// AbandonInactive marks active or paused sessions without a message since inactiveSince as
+// abandoned and returns them. The status check is part of the update, so a session ended meanwhile is skipped.
+func (r *chatSessionRepository) AbandonInactive(inactiveSince time.Time) ([]*ChatSession, error) {
+	var sessions []*ChatSession
+	now := time.Now()
+	err := r.db.Model(&sessions).Clauses(clause.Returning{}).
+		Where("status IN ? AND "+lastActivitySQL+" < ?", openSessionStatuses, inactiveSince).
+		Updates(map[string]interface{}{"status": "abandoned", "ended_at": now, "updated_at": now}).Error
+	return sessions, err
+}
+
+// PurgeAbandoned deletes abandoned sessions that ended before endedBefore, with their messages
+func (r *chatSessionRepository) PurgeAbandoned(endedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&ChatSession{}).Select("id").Where("status = ? AND ended_at < ?", "abandoned", endedBefore)
+		if err := tx.Model(&ChatSession{}).Where("id IN (?)", expired).Count(&purged).Error; err != nil {
+			return err
+		}
+		return deleteChatSessions(tx, expired)
+	})
+	return purged, err
+}
+
+// deleteChatSessions removes the sessions selected by ids along with their messages, unlinking
+// their evaluations rather than deleting them
+func deleteChatSessions(tx *gorm.DB, ids *gorm.DB) error {
+	for _, result := range []*gorm.DB{
+		tx.Where("session_id IN (?)", ids).Delete(&ChatMessage{}),
+		tx.Model(&Evaluation{}).Where("session_id IN (?)", ids).Update("session_id", nil),
+	} {
+		if result.Error != nil {
+			return result.Error
+		}
+	}
+	return tx.Where("id IN (?)", ids).Delete(&ChatSession{}).Error
 }
 
 // AddMessage adds a message to a chat session
@@ -123,9 +278,87 @@ func (r *chatSessionRepository) AddMessage(sessionID string, message *ChatMessag
 	return r.db.Create(message).Error
 }
 
-// GetMessages retrieves all messages for a chat session
+// GetMessages retrieves the visible messages of a chat session, leaving out superseded revisions
 func (r *chatSessionRepository) GetMessages(sessionID string) ([]*ChatMessage, error) {
+	var messages []*ChatMessage
+	err := r.db.Where("session_id = ? AND superseded_by = ''", sessionID).Order("timestamp ASC").Find(&messages).Error
+	return messages, err
+}
+
+// GetMessageHistory retrieves every message of a chat session, including superseded revisions
+func (r *chatSessionRepository) GetMessageHistory(sessionID string) ([]*ChatMessage, error) {
 	var messages []*ChatMessage
 	err := r.db.Where("session_id = ?", sessionID).Order("timestamp ASC").Find(&messages).Error
 	return messages, err
 }
+
+// ReplaceMessages supersedes the visible messages keyed by ID with their replacements and adds the
+// appended messages in the same transaction. The replaced messages must still be the latest visible
+// ones, otherwise ErrStaleChatMessage is returned.
+func (r *chatSessionRepository) ReplaceMessages(sessionID string, replacements map[string]*ChatMessage, appended ...*ChatMessage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var session ChatSession
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", sessionID).First(&session).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("chat session not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		oldIDs := make([]string, 0, len(replacements))
+		for id := range replacements {
+			oldIDs = append(oldIDs, id)
+		}
+		var replaced []*ChatMessage
+		if err := tx.Where("session_id = ? AND superseded_by = '' AND id IN ?", sessionID, oldIDs).Find(&replaced).Error; err != nil {
+			return err
+		}
+		if len(replaced) != len(oldIDs) {
+			return ErrStaleChatMessage
+		}
+		var latest time.Time
+		for _, message := range replaced {
+			if message.Timestamp.After(latest) {
+				latest = message.Timestamp
+			}
+		}
+		var later int64
+		if err := tx.Model(&ChatMessage{}).Where("session_id = ? AND superseded_by = '' AND id NOT IN ? AND timestamp > ?", sessionID, oldIDs, latest).
+			Count(&later).Error; err != nil {
+			return err
+		}
+		if later > 0 {
+			return ErrStaleChatMessage
+		}
+
+		for oldID, message := range replacements {
+			message.SessionID = sessionID
+			message.CreatedAt = time.Now()
+			if err := tx.Create(message).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&ChatMessage{}).Where("id = ?", oldID).Update("superseded_by", message.ID).Error; err != nil {
+				return err
+			}
+		}
+		for _, message := range appended {
+			message.SessionID = sessionID
+			message.CreatedAt = time.Now()
+			if err := tx.Create(message).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetMessageByID retrieves a single chat message
+func (r *chatSessionRepository) GetMessageByID(id string) (*ChatMessage, error) {
+	var message ChatMessage
+	err := r.db.Where("id = ?", id).First(&message).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("chat message not found")
+	}
+	return &message, err
+}
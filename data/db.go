@@ -41,6 +41,11 @@ func InitDB(databaseURL string) (*gorm.DB, error) {
 		return nil, fmt.Errorf("migration failed: %w", err)
 	}
 
+	// Add referential integrity between interviews and their sessions and evaluations
+	if err := AddForeignKeys(db); err != nil {
+		utils.Warningf("Foreign keys could not be verified: %v\n", err)
+	}
+
 	// Add performance indexes for better concurrent query performance
 	if err := AddPerformanceIndexes(db); err != nil {
 		// Don't fail if indexes can't be created, just log warning
@@ -62,7 +67,19 @@ func runMigrations(db *gorm.DB) error {
 		&Evaluation{},
 		&ChatSession{},
 		&ChatMessage{},
-		// &File{}, // TODO: Uncomment when File model is implemented
+		&Question{},
+		&InterviewTemplate{},
+		&EvaluationRevision{},
+		&EvaluationRating{},
+		&Job{},
+		&WebhookSubscription{},
+		&WebhookEvent{},
+		&WebhookDelivery{},
+		&File{},
+		&Candidate{},
+		&Position{},
+		&Invitation{},
+		&APIKey{},
 	)
 }
 
@@ -11,6 +11,16 @@ type DatabaseService struct {
 	InterviewRepo   InterviewRepository
 	EvaluationRepo  EvaluationRepository
 	ChatSessionRepo ChatSessionRepository
+	QuestionRepo    QuestionRepository
+	TemplateRepo    TemplateRepository
+	RatingRepo      RatingRepository
+	JobRepo         JobRepository
+	WebhookRepo     WebhookRepository
+	FileRepo        FileRepository
+	CandidateRepo   CandidateRepository
+	PositionRepo    PositionRepository
+	InvitationRepo  InvitationRepository
+	APIKeyRepo      APIKeyRepository
 }
 
 // NewDatabaseService creates a new database service with all repositories
@@ -20,6 +30,16 @@ func NewDatabaseService(db *gorm.DB) *DatabaseService {
 		InterviewRepo:   NewInterviewRepository(db),
 		EvaluationRepo:  NewEvaluationRepository(db),
 		ChatSessionRepo: NewChatSessionRepository(db),
+		QuestionRepo:    NewQuestionRepository(db),
+		TemplateRepo:    NewTemplateRepository(db),
+		RatingRepo:      NewRatingRepository(db),
+		JobRepo:         NewJobRepository(db),
+		WebhookRepo:     NewWebhookRepository(db),
+		FileRepo:        NewFileRepository(db),
+		CandidateRepo:   NewCandidateRepository(db),
+		PositionRepo:    NewPositionRepository(db),
+		InvitationRepo:  NewInvitationRepository(db),
+		APIKeyRepo:      NewAPIKeyRepository(db),
 	}
 }
 
@@ -6,17 +6,34 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // EvaluationFilters defines filter options for evaluation queries
 type EvaluationFilters struct {
 	InterviewID   string
-	MinScore      float64
-	MaxScore      float64
+	PositionID    string   // Evaluations of interviews for this position
+	CreatedBy     string   // Evaluations of interviews created by this principal
+	MinScore      *float64 // Inclusive lower bound on the AI score
+	MaxScore      *float64 // Inclusive upper bound on the AI score
 	CreatedAfter  time.Time
 	CreatedBefore time.Time
+	Decision      string // Reviewer decision; "none" matches evaluations without one
+	NeedsReview   *bool
+	LowConfidence *bool
 }
 
+// Evaluation sort fields and orders
+const (
+	EvaluationSortDate  = "date"
+	EvaluationSortScore = "score"
+	SortOrderAsc        = "asc"
+	SortOrderDesc       = "desc"
+)
+
+// DecisionNone filters evaluations that have no reviewer decision yet
+const DecisionNone = "none"
+
 // EvaluationStatistics provides aggregated statistics for evaluations
 type EvaluationStatistics struct {
 	TotalEvaluations  int64          `json:"total_evaluations"`
@@ -26,15 +43,61 @@ type EvaluationStatistics struct {
 	ScoreDistribution map[string]int `json:"score_distribution"` // Score ranges
 }
 
+// Score distribution buckets, as percentages of the 0.0-1.0 score
+var scoreRanges = []struct {
+	Label string
+	Min   float64
+}{
+	{"90-100", 0.9},
+	{"80-89", 0.8},
+	{"70-79", 0.7},
+	{"60-69", 0.6},
+	{"0-59", 0},
+}
+
+// scoreRangeSQL computes the scoreRanges bucket label of an evaluation score in SQL
+const scoreRangeSQL = `CASE
+				WHEN score >= 0.9 THEN '90-100'
+				WHEN score >= 0.8 THEN '80-89'
+				WHEN score >= 0.7 THEN '70-79'
+				WHEN score >= 0.6 THEN '60-69'
+				ELSE '0-59'
+			END`
+
+// scoreRange returns the distribution bucket label for a score
+func scoreRange(score float64) string {
+	for _, bucket := range scoreRanges {
+		if score >= bucket.Min {
+			return bucket.Label
+		}
+	}
+	return scoreRanges[len(scoreRanges)-1].Label
+}
+
+// ValidEvaluationSort reports whether the sort field and order are supported
+func ValidEvaluationSort(sortBy, sortOrder string) bool {
+	if sortBy != "" && sortBy != EvaluationSortDate && sortBy != EvaluationSortScore {
+		return false
+	}
+	return sortOrder == "" || sortOrder == SortOrderAsc || sortOrder == SortOrderDesc
+}
+
 // EvaluationRepository interface defines the contract for evaluation data access
 type EvaluationRepository interface {
-	Create(evaluation *Evaluation) error
+	Create(evaluation *Evaluation, events ...*WebhookEvent) error
+	CreateForJob(evaluation *Evaluation, attempt int, events ...*WebhookEvent) error
 	GetByID(id string) (*Evaluation, error)
+	GetByJobID(jobID string) (*Evaluation, error)
 	GetByInterviewID(interviewID string) (*Evaluation, error)
-	List(limit, offset int, filters EvaluationFilters) ([]*Evaluation, int64, error)
+	List(limit, offset int, filters EvaluationFilters, sortBy, sortOrder string) ([]*Evaluation, int64, error)
 	Update(id string, updates map[string]interface{}) error
 	Delete(id string) error
-	GetStatistics() (*EvaluationStatistics, error)
+	GetStatistics(filters EvaluationFilters) (*EvaluationStatistics, error)
+	GetStatisticsByPosition(filters EvaluationFilters) (map[string]*EvaluationStatistics, error)
+	Review(evaluation *Evaluation, revision *EvaluationRevision, events ...*WebhookEvent) error
+	ListRevisions(evaluationID string) ([]*EvaluationRevision, error)
+	ListByTemplateID(templateID string) ([]*Evaluation, error)
+	GetLatestByInterviewIDs(interviewIDs []string) (map[string]*Evaluation, error)
 }
 
 // evaluationRepository implements EvaluationRepository interface
@@ -47,8 +110,8 @@ func NewEvaluationRepository(db *gorm.DB) EvaluationRepository {
 	return &evaluationRepository{db: db}
 }
 
-// Create creates a new evaluation with validation
-func (r *evaluationRepository) Create(evaluation *Evaluation) error {
+// Create creates a new evaluation with validation, recording the webhook events in the same transaction
+func (r *evaluationRepository) Create(evaluation *Evaluation, events ...*WebhookEvent) error {
 	// Validate that interview exists
 	var interview Interview
 	if err := r.db.Where("id = ?", evaluation.InterviewID).First(&interview).Error; err != nil {
@@ -61,7 +124,45 @@ func (r *evaluationRepository) Create(evaluation *Evaluation) error {
 	evaluation.CreatedAt = time.Now()
 	evaluation.UpdatedAt = time.Now()
 
-	return r.db.Create(evaluation).Error
+	return withWebhookEvents(r.db, events, func(tx *gorm.DB) error {
+		return tx.Create(evaluation).Error
+	})
+}
+
+// CreateForJob creates the evaluation produced by attempt of its background job. The job row is
+// locked and must still be running that attempt, so an attempt that timed out and was superseded
+// stores nothing and gets ErrJobAttemptSuperseded; the webhook events are recorded in the same transaction.
+func (r *evaluationRepository) CreateForJob(evaluation *Evaluation, attempt int, events ...*WebhookEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var job Job
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND status = ? AND attempts = ?", evaluation.JobID, JobStatusRunning, attempt).First(&job).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrJobAttemptSuperseded
+		}
+		if err != nil {
+			return err
+		}
+		evaluation.CreatedAt = time.Now()
+		evaluation.UpdatedAt = time.Now()
+		if err := tx.Create(evaluation).Error; err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, events)
+	})
+}
+
+// GetByJobID retrieves the evaluation produced by a background job, or nil if it has not stored one
+func (r *evaluationRepository) GetByJobID(jobID string) (*Evaluation, error) {
+	var evaluation Evaluation
+	err := r.db.Where("job_id = ?", jobID).First(&evaluation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &evaluation, nil
 }
 
 // GetByID retrieves an evaluation by ID
@@ -84,22 +185,22 @@ func (r *evaluationRepository) GetByInterviewID(interviewID string) (*Evaluation
 	return &evaluation, err
 }
 
-// List retrieves evaluations with filtering and sorting
-func (r *evaluationRepository) List(limit, offset int, filters EvaluationFilters) ([]*Evaluation, int64, error) {
-	var evaluations []*Evaluation
-	var total int64
-
-	query := r.db.Model(&Evaluation{})
-
-	// Apply filters
+// applyEvaluationFilters adds the filter conditions to an evaluation query
+func applyEvaluationFilters(query *gorm.DB, filters EvaluationFilters) *gorm.DB {
 	if filters.InterviewID != "" {
 		query = query.Where("interview_id = ?", filters.InterviewID)
 	}
-	if filters.MinScore > 0 {
-		query = query.Where("score >= ?", filters.MinScore)
+	if filters.PositionID != "" {
+		query = query.Where("interview_id IN (SELECT id FROM interviews WHERE position_id = ?)", filters.PositionID)
 	}
-	if filters.MaxScore > 0 {
-		query = query.Where("score <= ?", filters.MaxScore)
+	if filters.CreatedBy != "" {
+		query = query.Where("interview_id IN (SELECT id FROM interviews WHERE created_by = ?)", filters.CreatedBy)
+	}
+	if filters.MinScore != nil {
+		query = query.Where("score >= ?", *filters.MinScore)
+	}
+	if filters.MaxScore != nil {
+		query = query.Where("score <= ?", *filters.MaxScore)
 	}
 	if !filters.CreatedAfter.IsZero() {
 		query = query.Where("created_at >= ?", filters.CreatedAfter)
@@ -107,12 +208,44 @@ func (r *evaluationRepository) List(limit, offset int, filters EvaluationFilters
 	if !filters.CreatedBefore.IsZero() {
 		query = query.Where("created_at <= ?", filters.CreatedBefore)
 	}
+	if filters.Decision == DecisionNone {
+		query = query.Where("decision = ?", "")
+	} else if filters.Decision != "" {
+		query = query.Where("decision = ?", filters.Decision)
+	}
+	if filters.NeedsReview != nil {
+		query = query.Where("needs_review = ?", *filters.NeedsReview)
+	}
+	if filters.LowConfidence != nil {
+		query = query.Where("low_confidence = ?", *filters.LowConfidence)
+	}
+	return query
+}
+
+// List retrieves evaluations with filtering and sorting (by "date" or "score", newest first by default)
+func (r *evaluationRepository) List(limit, offset int, filters EvaluationFilters, sortBy, sortOrder string) ([]*Evaluation, int64, error) {
+	var evaluations []*Evaluation
+	var total int64
+
+	query := applyEvaluationFilters(r.db.Model(&Evaluation{}), filters)
 
 	// Get total count
-	query.Count(&total)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	column := "created_at"
+	if sortBy == EvaluationSortScore {
+		column = "score"
+	}
+	direction := "DESC"
+	if sortOrder == SortOrderAsc {
+		direction = "ASC"
+	}
 
-	// Apply pagination and ordering
-	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&evaluations).Error
+	// Apply pagination and ordering; id breaks ties so pages are stable
+	err := query.Order(column + " " + direction).Order("id " + direction).
+		Limit(limit).Offset(offset).Find(&evaluations).Error
 	return evaluations, total, err
 }
 
@@ -127,12 +260,86 @@ func (r *evaluationRepository) Delete(id string) error {
 	return r.db.Where("id = ?", id).Delete(&Evaluation{}).Error
 }
 
+// Review saves the evaluation's human review fields and appends the audit revision in one
+// transaction, along with the webhook events
+func (r *evaluationRepository) Review(evaluation *Evaluation, revision *EvaluationRevision, events ...*WebhookEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest struct{ Max int }
+		if err := tx.Model(&EvaluationRevision{}).
+			Select("COALESCE(MAX(revision), 0) as max").
+			Where("evaluation_id = ?", evaluation.ID).
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		evaluation.UpdatedAt = time.Now()
+		updates := map[string]interface{}{
+			"human_score":           evaluation.HumanScore,
+			"human_category_scores": evaluation.HumanCategoryScores,
+			"decision":              evaluation.Decision,
+			"reviewed_by":           evaluation.ReviewedBy,
+			"reviewed_at":           evaluation.ReviewedAt,
+			"annotations":           evaluation.Annotations,
+			"updated_at":            evaluation.UpdatedAt,
+		}
+		if err := tx.Model(&Evaluation{}).Where("id = ?", evaluation.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		revision.Revision = latest.Max + 1
+		revision.CreatedAt = time.Now()
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, events)
+	})
+}
+
+// ListRevisions retrieves the human review history of an evaluation, oldest first
+func (r *evaluationRepository) ListRevisions(evaluationID string) ([]*EvaluationRevision, error) {
+	var revisions []*EvaluationRevision
+	err := r.db.Where("evaluation_id = ?", evaluationID).Order("revision ASC").Find(&revisions).Error
+	return revisions, err
+}
+
+// ListByTemplateID retrieves evaluations of interviews created from the given template
+func (r *evaluationRepository) ListByTemplateID(templateID string) ([]*Evaluation, error) {
+	var evaluations []*Evaluation
+	err := r.db.Joins("JOIN interviews ON interviews.id = evaluations.interview_id").
+		Where("interviews.template_id = ?", templateID).
+		Order("evaluations.created_at ASC").
+		Find(&evaluations).Error
+	return evaluations, err
+}
+
+// GetLatestByInterviewIDs returns the most recent evaluation of each interview, keyed by interview ID
+func (r *evaluationRepository) GetLatestByInterviewIDs(interviewIDs []string) (map[string]*Evaluation, error) {
+	latest := make(map[string]*Evaluation, len(interviewIDs))
+	if len(interviewIDs) == 0 {
+		return latest, nil
+	}
+
+	var evaluations []*Evaluation
+	err := r.db.Where("interview_id IN ?", interviewIDs).Order("created_at DESC").Find(&evaluations).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, evaluation := range evaluations {
+		if _, seen := latest[evaluation.InterviewID]; !seen {
+			latest[evaluation.InterviewID] = evaluation
+		}
+	}
+	return latest, nil
+}
+
 // GetStatistics implements statistics aggregation for analytics
-func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
-	var stats EvaluationStatistics
+func (r *evaluationRepository) GetStatistics(filters EvaluationFilters) (*EvaluationStatistics, error) {
+	stats := EvaluationStatistics{ScoreDistribution: make(map[string]int)}
 
 	// Total count
-	r.db.Model(&Evaluation{}).Count(&stats.TotalEvaluations)
+	if err := applyEvaluationFilters(r.db.Model(&Evaluation{}), filters).Count(&stats.TotalEvaluations).Error; err != nil {
+		return nil, err
+	}
 
 	if stats.TotalEvaluations == 0 {
 		return &stats, nil
@@ -145,7 +352,7 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 		Max float64
 	}
 
-	err := r.db.Model(&Evaluation{}).
+	err := applyEvaluationFilters(r.db.Model(&Evaluation{}), filters).
 		Select("AVG(score) as avg, MIN(score) as min, MAX(score) as max").
 		Scan(&result).Error
 
@@ -157,25 +364,14 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 	stats.MinScore = result.Min
 	stats.MaxScore = result.Max
 
-	// Score distribution (simplified ranges)
-	stats.ScoreDistribution = make(map[string]int)
-
+	// Score distribution over the scoreRanges buckets
 	var distributions []struct {
 		Range string
 		Count int
 	}
 
-	err = r.db.Model(&Evaluation{}).
-		Select(`
-			CASE 
-				WHEN score >= 90 THEN '90-100'
-				WHEN score >= 80 THEN '80-89'
-				WHEN score >= 70 THEN '70-79'
-				WHEN score >= 60 THEN '60-69'
-				ELSE '0-59'
-			END as range,
-			COUNT(*) as count
-		`).
+	err = applyEvaluationFilters(r.db.Model(&Evaluation{}), filters).
+		Select(scoreRangeSQL + " as range, COUNT(*) as count").
 		Group("range").
 		Scan(&distributions).Error
 
@@ -189,3 +385,47 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 
 	return &stats, nil
 }
+
+// GetStatisticsByPosition aggregates the filtered evaluations per position of their interview,
+// leaving out interviews without one
+func (r *evaluationRepository) GetStatisticsByPosition(filters EvaluationFilters) (map[string]*EvaluationStatistics, error) {
+	var buckets []struct {
+		PositionID string
+		Range      string
+		Count      int
+		Sum        float64
+		Min        float64
+		Max        float64
+	}
+
+	evaluations := applyEvaluationFilters(r.db.Model(&Evaluation{}), filters).Select("interview_id, score")
+	err := r.db.Table("(?) AS e", evaluations).
+		Joins("JOIN interviews ON interviews.id = e.interview_id").
+		Where("interviews.position_id IS NOT NULL").
+		Select("interviews.position_id AS position_id, " + scoreRangeSQL + " AS range, COUNT(*) AS count, SUM(score) AS sum, MIN(score) AS min, MAX(score) AS max").
+		Group("interviews.position_id, range").
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Combine each position's score buckets into its statistics
+	groups := make(map[string]*EvaluationStatistics)
+	sums := make(map[string]float64)
+	for _, bucket := range buckets {
+		stats, exists := groups[bucket.PositionID]
+		if !exists {
+			stats = &EvaluationStatistics{ScoreDistribution: make(map[string]int), MinScore: bucket.Min, MaxScore: bucket.Max}
+			groups[bucket.PositionID] = stats
+		}
+		stats.TotalEvaluations += int64(bucket.Count)
+		stats.MinScore = min(stats.MinScore, bucket.Min)
+		stats.MaxScore = max(stats.MaxScore, bucket.Max)
+		stats.ScoreDistribution[bucket.Range] = bucket.Count
+		sums[bucket.PositionID] += bucket.Sum
+	}
+	for positionID, stats := range groups {
+		stats.AverageScore = sums[positionID] / float64(stats.TotalEvaluations)
+	}
+	return groups, nil
+}
@@ -0,0 +1,121 @@
+// Uploaded file data access
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrResumeReplaced is returned when a parsed profile is stored for a resume that has since been replaced
+var ErrResumeReplaced = errors.New("interview resume has been replaced")
+
+// FileRepository interface defines the contract for uploaded file data access
+type FileRepository interface {
+	GetByID(id string) (*File, error)
+	ListByInterview(interviewID string) ([]File, error)
+	AttachResume(file *File) (*File, error)
+	Delete(id string) error
+	SetCandidateProfile(interviewID, resumeFileID string, profile CandidateProfile) error
+}
+
+// fileRepository implements FileRepository interface
+type fileRepository struct {
+	db *gorm.DB
+}
+
+// NewFileRepository creates a new file repository
+func NewFileRepository(db *gorm.DB) FileRepository {
+	return &fileRepository{db: db}
+}
+
+// GetByID retrieves a file by ID
+func (r *fileRepository) GetByID(id string) (*File, error) {
+	var file File
+	err := r.db.Where("id = ?", id).First(&file).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("file not found")
+	}
+	return &file, err
+}
+
+// ListByInterview retrieves every file stored for an interview, including resumes that were replaced
+// but whose contents have not been removed yet
+func (r *fileRepository) ListByInterview(interviewID string) ([]File, error) {
+	var files []File
+	err := r.db.Where("interview_id = ?", interviewID).Order("created_at ASC").Find(&files).Error
+	return files, err
+}
+
+// Delete deletes a file record
+func (r *fileRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&File{}).Error
+}
+
+// AttachResume stores the file as its interview's resume, copying the extracted text onto the
+// interview and clearing the profile parsed from the previous resume, and returns the resume it replaced, if any. The interview row is locked so the
+// resume cannot change under a chat session that is starting. The replaced record is kept until its
+// contents are removed, so a blob that could not be deleted is still found when the interview is.
+func (r *fileRepository) AttachResume(file *File) (*File, error) {
+	var replaced *File
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var interview Interview
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", *file.InterviewID).First(&interview).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("interview not found")
+			}
+			return err
+		}
+		if err := checkNoActiveSession(tx, interview.ID); err != nil {
+			return err
+		}
+
+		file.CreatedAt = time.Now()
+		if err := tx.Create(file).Error; err != nil {
+			return err
+		}
+		if interview.ResumeFileID != "" {
+			var previous File
+			err := tx.Where("id = ?", interview.ResumeFileID).First(&previous).Error
+			switch {
+			case err == nil:
+				replaced = &previous
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return err
+			}
+		}
+		return tx.Model(&Interview{}).Where("id = ?", interview.ID).Updates(map[string]interface{}{
+			"resume_file_id":    file.ID,
+			"resume_text":       file.ExtractedText,
+			"candidate_profile": CandidateProfile{},
+			"updated_at":        time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replaced, nil
+}
+
+// SetCandidateProfile stores the interview's structured candidate profile. When resumeFileID is set
+// the profile was parsed from that resume, and ErrResumeReplaced is returned if it is no longer current.
+func (r *fileRepository) SetCandidateProfile(interviewID, resumeFileID string, profile CandidateProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var interview Interview
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", interviewID).First(&interview).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("interview not found")
+			}
+			return err
+		}
+		if resumeFileID != "" && interview.ResumeFileID != resumeFileID {
+			return ErrResumeReplaced
+		}
+		return tx.Model(&Interview{}).Where("id = ?", interviewID).Updates(map[string]interface{}{
+			"candidate_profile": profile,
+			"updated_at":        time.Now(),
+		}).Error
+	})
+}
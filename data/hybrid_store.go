@@ -4,6 +4,7 @@ package data
 import (
 	"fmt"
 	"os"
+	"time"
 )
 
 // StoreBackend defines the type of backend storage
@@ -52,12 +53,22 @@ func AutoDetectBackend() StoreBackend {
 	return BackendMemory
 }
 
-// CreateInterview creates a new interview using the configured backend
-func (h *HybridStore) CreateInterview(interview *Interview) error {
+// CreateInterview creates a new interview using the configured backend, recording the webhook
+// events atomically with it
+func (h *HybridStore) CreateInterview(interview *Interview, events ...*WebhookEvent) error {
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.InterviewRepo.Create(interview)
+		return h.dbService.InterviewRepo.Create(interview, events...)
 	}
-	return h.memoryStore.CreateInterview(interview)
+	return h.memoryStore.CreateInterview(interview, events...)
+}
+
+// CreateInterviewBatch creates new candidates and the interviews referencing them, all or none,
+// in one transaction on the database backend
+func (h *HybridStore) CreateInterviewBatch(candidates []*Candidate, interviews []*Interview, events []*WebhookEvent) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InterviewRepo.CreateBatch(candidates, interviews, events)
+	}
+	return h.memoryStore.CreateInterviewBatch(candidates, interviews, events)
 }
 
 // GetInterview retrieves an interview by ID
@@ -73,8 +84,13 @@ func (h *HybridStore) GetInterviewsWithOptions(options ListInterviewsOptions) (*
 	if h.backend == BackendDatabase && h.dbService != nil {
 		// Convert to database filters
 		filters := InterviewFilters{
-			CandidateName: options.CandidateName,
-			Status:        options.Status,
+			CandidateName:  options.CandidateName,
+			CandidateID:    options.CandidateID,
+			PositionID:     options.PositionID,
+			Status:         options.Status,
+			TemplateID:     options.TemplateID,
+			JobDescription: options.JobDescription,
+			CreatedBy:      options.CreatedBy,
 		}
 		if !options.DateFrom.IsZero() {
 			filters.CreatedAfter = options.DateFrom
@@ -110,12 +126,71 @@ func (h *HybridStore) GetInterviewsWithOptions(options ListInterviewsOptions) (*
 	return h.memoryStore.GetInterviewsWithOptions(options)
 }
 
-// CreateEvaluation creates a new evaluation
-func (h *HybridStore) CreateEvaluation(evaluation *Evaluation) error {
+// UpdateInterview updates an interview's editable fields, rejecting edits while a chat session is active
+func (h *HybridStore) UpdateInterview(interview *Interview) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"candidate_name":      interview.CandidateName,
+			"candidate_id":        interview.CandidateID,
+			"position_id":         interview.PositionID,
+			"questions":           interview.Questions,
+			"language":            interview.InterviewLanguage,
+			"type":                interview.InterviewType,
+			"job_description":     interview.JobDescription,
+			"question_ids":        interview.QuestionIDs,
+			"template_id":         interview.TemplateID,
+			"end_policy":          interview.EndPolicy,
+			"evaluation_criteria": interview.EvalCriteria,
+			"interviewer_persona": interview.Persona,
+			"rubric":              interview.Rubric,
+			"evaluation_ensemble": interview.Ensemble,
+			"evaluation_sampling": interview.Sampling,
+			"max_retakes":         interview.MaxRetakes,
+		}
+		return h.dbService.InterviewRepo.Edit(interview.ID, updates)
+	}
+	return h.memoryStore.UpdateInterview(interview)
+}
+
+// TransitionInterview moves an interview to a new lifecycle status, enforcing the allowed transitions
+func (h *HybridStore) TransitionInterview(id, status string) (*Interview, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InterviewRepo.Transition(id, status)
+	}
+	return h.memoryStore.TransitionInterview(id, status)
+}
+
+// DeleteInterview removes an interview; see InterviewRepository.Delete for the cascade rules
+func (h *HybridStore) DeleteInterview(id string, cascade bool) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InterviewRepo.Delete(id, cascade)
+	}
+	return h.memoryStore.DeleteInterview(id, cascade)
+}
+
+// CreateEvaluation creates a new evaluation, recording the webhook events atomically with it
+func (h *HybridStore) CreateEvaluation(evaluation *Evaluation, events ...*WebhookEvent) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.Create(evaluation, events...)
+	}
+	return h.memoryStore.CreateEvaluation(evaluation, events...)
+}
+
+// CreateJobEvaluation stores the evaluation produced by attempt of its background job with the
+// webhook events, or returns ErrJobAttemptSuperseded when that attempt is no longer running
+func (h *HybridStore) CreateJobEvaluation(evaluation *Evaluation, attempt int, events ...*WebhookEvent) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.CreateForJob(evaluation, attempt, events...)
+	}
+	return h.memoryStore.CreateJobEvaluation(evaluation, attempt, events...)
+}
+
+// GetJobEvaluation retrieves the evaluation produced by a background job, or nil if it has not stored one
+func (h *HybridStore) GetJobEvaluation(jobID string) (*Evaluation, error) {
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.EvaluationRepo.Create(evaluation)
+		return h.dbService.EvaluationRepo.GetByJobID(jobID)
 	}
-	return h.memoryStore.CreateEvaluation(evaluation)
+	return h.memoryStore.GetJobEvaluation(jobID)
 }
 
 // GetEvaluation retrieves an evaluation by ID
@@ -126,6 +201,116 @@ func (h *HybridStore) GetEvaluation(id string) (*Evaluation, error) {
 	return h.memoryStore.GetEvaluation(id)
 }
 
+// ListEvaluations retrieves evaluations with pagination, filtering, and sorting
+func (h *HybridStore) ListEvaluations(options ListEvaluationsOptions) (*ListEvaluationsResult, error) {
+	// Normalize paging here so both backends page identically
+	if options.Limit <= 0 {
+		options.Limit = 10
+	}
+	if options.Page > 0 {
+		options.Offset = (options.Page - 1) * options.Limit
+	}
+	if options.Offset < 0 {
+		options.Offset = 0
+	}
+
+	var result *ListEvaluationsResult
+	if h.backend == BackendDatabase && h.dbService != nil {
+		evaluations, total, err := h.dbService.EvaluationRepo.List(options.Limit, options.Offset, options.Filters, options.SortBy, options.SortOrder)
+		if err != nil {
+			return nil, err
+		}
+		result = &ListEvaluationsResult{Evaluations: evaluations, Total: int(total)}
+	} else {
+		var err error
+		if result, err = h.memoryStore.ListEvaluations(options); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Limit = options.Limit
+	result.Page = (options.Offset / options.Limit) + 1
+	result.TotalPages = (result.Total + options.Limit - 1) / options.Limit
+	if result.TotalPages == 0 {
+		result.TotalPages = 1
+	}
+	return result, nil
+}
+
+// GetEvaluationStatistics aggregates scores of the evaluations matching the filters
+func (h *HybridStore) GetEvaluationStatistics(filters EvaluationFilters) (*EvaluationStatistics, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.GetStatistics(filters)
+	}
+	return h.memoryStore.GetEvaluationStatistics(filters)
+}
+
+// GetEvaluationStatisticsByPosition aggregates the filtered evaluations per position, keyed by position ID
+func (h *HybridStore) GetEvaluationStatisticsByPosition(filters EvaluationFilters) (map[string]*EvaluationStatistics, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.GetStatisticsByPosition(filters)
+	}
+	return h.memoryStore.GetEvaluationStatisticsByPosition(filters)
+}
+
+// ReviewEvaluation saves a human review of an evaluation and appends it to the audit history
+func (h *HybridStore) ReviewEvaluation(evaluation *Evaluation, revision *EvaluationRevision, events ...*WebhookEvent) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.Review(evaluation, revision, events...)
+	}
+	return h.memoryStore.ReviewEvaluation(evaluation, revision, events...)
+}
+
+// GetEvaluationRevisions retrieves the human review history of an evaluation
+func (h *HybridStore) GetEvaluationRevisions(evaluationID string) ([]*EvaluationRevision, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.ListRevisions(evaluationID)
+	}
+	return h.memoryStore.GetEvaluationRevisions(evaluationID)
+}
+
+// AssignEvaluationRaters sets the reviewers expected to rate an evaluation for calibration
+func (h *HybridStore) AssignEvaluationRaters(evaluationID string, raters []string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.Update(evaluationID, map[string]interface{}{
+			"calibration_raters": StringArray(raters),
+		})
+	}
+	return h.memoryStore.AssignEvaluationRaters(evaluationID, raters)
+}
+
+// CreateEvaluationRating stores a reviewer's blind calibration rating
+func (h *HybridStore) CreateEvaluationRating(rating *EvaluationRating) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.RatingRepo.Create(rating)
+	}
+	return h.memoryStore.CreateEvaluationRating(rating)
+}
+
+// GetEvaluationRatings retrieves the calibration ratings of the given evaluations
+func (h *HybridStore) GetEvaluationRatings(evaluationIDs []string) ([]*EvaluationRating, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.RatingRepo.ListByEvaluationIDs(evaluationIDs)
+	}
+	return h.memoryStore.GetEvaluationRatings(evaluationIDs)
+}
+
+// GetEvaluationsByTemplate retrieves evaluations of interviews created from a template
+func (h *HybridStore) GetEvaluationsByTemplate(templateID string) ([]*Evaluation, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.ListByTemplateID(templateID)
+	}
+	return h.memoryStore.GetEvaluationsByTemplate(templateID)
+}
+
+// GetLatestEvaluations returns the most recent evaluation of each interview, keyed by interview ID
+func (h *HybridStore) GetLatestEvaluations(interviewIDs []string) (map[string]*Evaluation, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.EvaluationRepo.GetLatestByInterviewIDs(interviewIDs)
+	}
+	return h.memoryStore.GetLatestEvaluations(interviewIDs)
+}
+
 // CreateChatSession creates a new chat session
 func (h *HybridStore) CreateChatSession(session *ChatSession) error {
 	if h.backend == BackendDatabase && h.dbService != nil {
@@ -134,6 +319,48 @@ func (h *HybridStore) CreateChatSession(session *ChatSession) error {
 	return h.memoryStore.CreateChatSession(session)
 }
 
+// CreateInterviewSession creates a chat session, enforcing one active session per interview and its retake limit.
+// A non-empty invitationID is used once, and the greeting, if any, stored, only if the session is created.
+func (h *HybridStore) CreateInterviewSession(session *ChatSession, invitationID string, greeting *ChatMessage, events ...*WebhookEvent) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.CreateForInterview(session, invitationID, greeting, events...)
+	}
+	return h.memoryStore.CreateInterviewSession(session, invitationID, greeting, events...)
+}
+
+// DeleteChatSession removes a finished chat session and its messages; its evaluations are kept
+func (h *HybridStore) DeleteChatSession(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.Delete(id)
+	}
+	return h.memoryStore.DeleteChatSession(id)
+}
+
+// AbandonInactiveChatSessions marks active or paused sessions without a message since inactiveSince as abandoned and returns them
+func (h *HybridStore) AbandonInactiveChatSessions(inactiveSince time.Time) ([]*ChatSession, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.AbandonInactive(inactiveSince)
+	}
+	return h.memoryStore.AbandonInactiveChatSessions(inactiveSince)
+}
+
+// PurgeAbandonedChatSessions deletes abandoned sessions that ended before endedBefore, returning how many were removed
+func (h *HybridStore) PurgeAbandonedChatSessions(endedBefore time.Time) (int, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		purged, err := h.dbService.ChatSessionRepo.PurgeAbandoned(endedBefore)
+		return int(purged), err
+	}
+	return h.memoryStore.PurgeAbandonedChatSessions(endedBefore)
+}
+
+// ListChatSessions returns all sessions of an interview, oldest first
+func (h *HybridStore) ListChatSessions(interviewID string) ([]*ChatSession, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.ListByInterviewID(interviewID)
+	}
+	return h.memoryStore.ListChatSessions(interviewID)
+}
+
 // GetChatSession retrieves a chat session by ID
 func (h *HybridStore) GetChatSession(id string) (*ChatSession, error) {
 	if h.backend == BackendDatabase && h.dbService != nil {
@@ -143,15 +370,27 @@ func (h *HybridStore) GetChatSession(id string) (*ChatSession, error) {
 }
 
 // UpdateChatSession updates a chat session
-func (h *HybridStore) UpdateChatSession(session *ChatSession) error {
+func (h *HybridStore) UpdateChatSession(session *ChatSession, events ...*WebhookEvent) error {
 	if h.backend == BackendDatabase && h.dbService != nil {
 		updates := map[string]interface{}{
-			"status":   session.Status,
-			"ended_at": session.EndedAt,
+			"status":            session.Status,
+			"ended_at":          session.EndedAt,
+			"paused_at":         session.PausedAt,
+			"paused_seconds":    session.PausedSeconds,
+			"resume_token_hash": session.ResumeTokenHash,
 		}
-		return h.dbService.ChatSessionRepo.Update(session.ID, updates)
+		return h.dbService.ChatSessionRepo.Update(session.ID, updates, events...)
+	}
+	return h.memoryStore.UpdateChatSession(session, events...)
+}
+
+// CompleteChatSession marks an active or paused session completed, queuing its evaluation job, if
+// any, with the webhook events in one step; a session that already ended yields ErrChatSessionEnded
+func (h *HybridStore) CompleteChatSession(id string, endedAt time.Time, job *Job, events ...*WebhookEvent) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.Complete(id, endedAt, job, events...)
 	}
-	return h.memoryStore.UpdateChatSession(session)
+	return h.memoryStore.CompleteChatSession(id, endedAt, job, events...)
 }
 
 // AddChatMessage adds a message to a chat session
@@ -164,7 +403,7 @@ func (h *HybridStore) AddChatMessage(sessionID string, message *ChatMessage) err
 	return h.memoryStore.AddChatMessage(message)
 }
 
-// GetChatMessages retrieves all messages for a chat session
+// GetChatMessages retrieves the visible messages of a chat session
 func (h *HybridStore) GetChatMessages(sessionID string) ([]*ChatMessage, error) {
 	if h.backend == BackendDatabase && h.dbService != nil {
 		return h.dbService.ChatSessionRepo.GetMessages(sessionID)
@@ -172,11 +411,609 @@ func (h *HybridStore) GetChatMessages(sessionID string) ([]*ChatMessage, error)
 	return h.memoryStore.GetChatMessages(sessionID)
 }
 
+// GetChatMessageHistory retrieves every message of a chat session, including superseded revisions
+func (h *HybridStore) GetChatMessageHistory(sessionID string) ([]*ChatMessage, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.GetMessageHistory(sessionID)
+	}
+	return h.memoryStore.GetChatMessageHistory(sessionID)
+}
+
+// ReplaceChatMessages supersedes the latest visible messages of a session, keyed by ID, with
+// their replacements and adds the appended messages in one step; the superseded messages are
+// kept as hidden revisions
+func (h *HybridStore) ReplaceChatMessages(sessionID string, replacements map[string]*ChatMessage, appended ...*ChatMessage) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.ReplaceMessages(sessionID, replacements, appended...)
+	}
+	return h.memoryStore.ReplaceChatMessages(sessionID, replacements, appended...)
+}
+
+// GetChatMessage retrieves a single chat message by ID
+func (h *HybridStore) GetChatMessage(id string) (*ChatMessage, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.ChatSessionRepo.GetMessageByID(id)
+	}
+	return h.memoryStore.GetChatMessage(id)
+}
+
+// CreateQuestion creates a new question bank entry
+func (h *HybridStore) CreateQuestion(question *Question) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.QuestionRepo.Create(question)
+	}
+	return h.memoryStore.CreateQuestion(question)
+}
+
+// GetQuestion retrieves a question bank entry by ID
+func (h *HybridStore) GetQuestion(id string) (*Question, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.QuestionRepo.GetByID(id)
+	}
+	return h.memoryStore.GetQuestion(id)
+}
+
+// GetQuestionsByIDs retrieves question bank entries in the order of the given IDs
+func (h *HybridStore) GetQuestionsByIDs(ids []string) ([]*Question, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.QuestionRepo.GetByIDs(ids)
+	}
+	return h.memoryStore.GetQuestionsByIDs(ids)
+}
+
+// GetQuestionsWithOptions retrieves question bank entries with pagination, filtering, and search
+func (h *HybridStore) GetQuestionsWithOptions(options ListQuestionsOptions) (*ListQuestionsResult, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		if options.Limit <= 0 {
+			options.Limit = 10
+		}
+		if options.Page > 0 {
+			options.Offset = (options.Page - 1) * options.Limit
+		}
+
+		filters := QuestionFilters{
+			Tags:          options.Tags,
+			InterviewType: options.InterviewType,
+			Difficulty:    options.Difficulty,
+			Search:        options.Search,
+			SortBy:        options.SortBy,
+		}
+
+		questions, total, err := h.dbService.QuestionRepo.List(options.Limit, options.Offset, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ListQuestionsResult{
+			Questions:  questions,
+			Total:      int(total),
+			Page:       options.Page,
+			Limit:      options.Limit,
+			TotalPages: (int(total) + options.Limit - 1) / options.Limit,
+		}, nil
+	}
+	return h.memoryStore.GetQuestionsWithOptions(options)
+}
+
+// UpdateQuestion updates a question bank entry
+func (h *HybridStore) UpdateQuestion(question *Question) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"text":            question.Text,
+			"translations":    question.Translations,
+			"tags":            question.Tags,
+			"type":            question.InterviewType,
+			"difficulty":      question.Difficulty,
+			"expected_answer": question.ExpectedAnswer,
+		}
+		return h.dbService.QuestionRepo.Update(question.ID, updates)
+	}
+	return h.memoryStore.UpdateQuestion(question)
+}
+
+// DeleteQuestion removes a question bank entry
+func (h *HybridStore) DeleteQuestion(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		if _, err := h.dbService.QuestionRepo.GetByID(id); err != nil {
+			return err
+		}
+		return h.dbService.QuestionRepo.Delete(id)
+	}
+	return h.memoryStore.DeleteQuestion(id)
+}
+
+// IncrementQuestionUsage bumps the usage count of the referenced question bank entries
+func (h *HybridStore) IncrementQuestionUsage(ids []string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.QuestionRepo.IncrementUsage(ids)
+	}
+	return h.memoryStore.IncrementQuestionUsage(ids)
+}
+
+// CreateInterviewTemplate creates a new interview template
+func (h *HybridStore) CreateInterviewTemplate(template *InterviewTemplate) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.TemplateRepo.Create(template)
+	}
+	return h.memoryStore.CreateInterviewTemplate(template)
+}
+
+// GetInterviewTemplate retrieves an interview template by ID
+func (h *HybridStore) GetInterviewTemplate(id string) (*InterviewTemplate, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.TemplateRepo.GetByID(id)
+	}
+	return h.memoryStore.GetInterviewTemplate(id)
+}
+
+// GetInterviewTemplates retrieves all interview templates ordered by name
+func (h *HybridStore) GetInterviewTemplates() ([]*InterviewTemplate, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.TemplateRepo.List()
+	}
+	return h.memoryStore.GetInterviewTemplates()
+}
+
+// UpdateInterviewTemplate updates an interview template
+func (h *HybridStore) UpdateInterviewTemplate(template *InterviewTemplate) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"name":                template.Name,
+			"description":         template.Description,
+			"type":                template.InterviewType,
+			"language":            template.InterviewLanguage,
+			"questions":           template.Questions,
+			"question_ids":        template.QuestionIDs,
+			"job_description":     template.JobDescription,
+			"end_policy":          template.EndPolicy,
+			"evaluation_criteria": template.EvalCriteria,
+			"interviewer_persona": template.Persona,
+			"rubric":              template.Rubric,
+			"evaluation_ensemble": template.Ensemble,
+			"evaluation_sampling": template.Sampling,
+			"max_retakes":         template.MaxRetakes,
+		}
+		return h.dbService.TemplateRepo.Update(template.ID, updates)
+	}
+	return h.memoryStore.UpdateInterviewTemplate(template)
+}
+
+// DeleteInterviewTemplate removes an interview template
+func (h *HybridStore) DeleteInterviewTemplate(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		if _, err := h.dbService.TemplateRepo.GetByID(id); err != nil {
+			return err
+		}
+		return h.dbService.TemplateRepo.Delete(id)
+	}
+	return h.memoryStore.DeleteInterviewTemplate(id)
+}
+
+// CreateCandidate creates a new candidate, rejecting a duplicate email or external ID
+func (h *HybridStore) CreateCandidate(candidate *Candidate) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.CandidateRepo.Create(candidate)
+	}
+	return h.memoryStore.CreateCandidate(candidate)
+}
+
+// GetCandidate retrieves a candidate by ID
+func (h *HybridStore) GetCandidate(id string) (*Candidate, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.CandidateRepo.GetByID(id)
+	}
+	return h.memoryStore.GetCandidate(id)
+}
+
+// ListCandidates retrieves a page of candidates ordered by name, with the total matching the filters
+func (h *HybridStore) ListCandidates(filters CandidateFilters, limit, offset int) ([]*Candidate, int, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		candidates, total, err := h.dbService.CandidateRepo.List(limit, offset, filters)
+		return candidates, int(total), err
+	}
+	return h.memoryStore.ListCandidates(filters, limit, offset)
+}
+
+// UpdateCandidate updates a candidate's details and consent
+func (h *HybridStore) UpdateCandidate(candidate *Candidate) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"name":                    candidate.Name,
+			"email":                   candidate.Email,
+			"phone":                   candidate.Phone,
+			"external_id":             candidate.ExternalID,
+			"locale":                  candidate.Locale,
+			"consent_data_processing": candidate.ConsentDataProcessing,
+			"consent_ai_evaluation":   candidate.ConsentAIEvaluation,
+			"consent_talent_pool":     candidate.ConsentTalentPool,
+			"consent_updated_at":      candidate.ConsentUpdatedAt,
+		}
+		return h.dbService.CandidateRepo.Update(candidate.ID, updates)
+	}
+	return h.memoryStore.UpdateCandidate(candidate)
+}
+
+// DeleteCandidate removes a candidate, failing with ErrCandidateHasInterviews while interviews reference them
+func (h *HybridStore) DeleteCandidate(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.CandidateRepo.Delete(id)
+	}
+	return h.memoryStore.DeleteCandidate(id)
+}
+
+// GetCandidateHistory retrieves the candidate's interviews, chat sessions and evaluations
+func (h *HybridStore) GetCandidateHistory(id string) (*CandidateHistory, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.CandidateRepo.History(id)
+	}
+	return h.memoryStore.GetCandidateHistory(id)
+}
+
+// CreatePosition creates a new position
+func (h *HybridStore) CreatePosition(position *Position) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.PositionRepo.Create(position)
+	}
+	return h.memoryStore.CreatePosition(position)
+}
+
+// GetPosition retrieves a position by ID
+func (h *HybridStore) GetPosition(id string) (*Position, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.PositionRepo.GetByID(id)
+	}
+	return h.memoryStore.GetPosition(id)
+}
+
+// ListPositions retrieves a page of positions ordered by title, with the total matching the filters
+func (h *HybridStore) ListPositions(filters PositionFilters, limit, offset int) ([]*Position, int, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		positions, total, err := h.dbService.PositionRepo.List(limit, offset, filters)
+		return positions, int(total), err
+	}
+	return h.memoryStore.ListPositions(filters, limit, offset)
+}
+
+// UpdatePosition updates a position's details
+func (h *HybridStore) UpdatePosition(position *Position) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"title":           position.Title,
+			"department":      position.Department,
+			"level":           position.Level,
+			"description":     position.Description,
+			"required_skills": position.RequiredSkills,
+			"template_id":     position.TemplateID,
+			"rubric":          position.Rubric,
+		}
+		return h.dbService.PositionRepo.Update(position.ID, updates)
+	}
+	return h.memoryStore.UpdatePosition(position)
+}
+
+// DeletePosition removes a position, failing with ErrPositionHasInterviews while interviews reference it
+func (h *HybridStore) DeletePosition(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.PositionRepo.Delete(id)
+	}
+	return h.memoryStore.DeletePosition(id)
+}
+
+// CreateInvitation creates a new candidate invitation
+func (h *HybridStore) CreateInvitation(invitation *Invitation) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InvitationRepo.Create(invitation)
+	}
+	return h.memoryStore.CreateInvitation(invitation)
+}
+
+// GetInvitation retrieves an invitation by ID
+func (h *HybridStore) GetInvitation(id string) (*Invitation, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InvitationRepo.GetByID(id)
+	}
+	return h.memoryStore.GetInvitation(id)
+}
+
+// ListInvitations retrieves an interview's invitations, newest first
+func (h *HybridStore) ListInvitations(interviewID string) ([]*Invitation, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InvitationRepo.ListByInterview(interviewID)
+	}
+	return h.memoryStore.ListInvitations(interviewID)
+}
+
+// UseInvitation counts one use of an invitation, failing with ErrInvitationUnusable if it is
+// revoked, expired or has no uses left
+func (h *HybridStore) UseInvitation(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InvitationRepo.Use(id)
+	}
+	return h.memoryStore.UseInvitation(id)
+}
+
+// RevokeInvitation stops an invitation from granting access
+func (h *HybridStore) RevokeInvitation(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.InvitationRepo.Revoke(id)
+	}
+	return h.memoryStore.RevokeInvitation(id)
+}
+
+// CreateAPIKey stores a new API key
+func (h *HybridStore) CreateAPIKey(key *APIKey) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.APIKeyRepo.Create(key)
+	}
+	return h.memoryStore.CreateAPIKey(key)
+}
+
+// GetAPIKey retrieves an API key by ID
+func (h *HybridStore) GetAPIKey(id string) (*APIKey, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.APIKeyRepo.GetByID(id)
+	}
+	return h.memoryStore.GetAPIKey(id)
+}
+
+// GetAPIKeyByHash retrieves the API key with the given key hash
+func (h *HybridStore) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.APIKeyRepo.GetByHash(hash)
+	}
+	return h.memoryStore.GetAPIKeyByHash(hash)
+}
+
+// ListAPIKeys retrieves every API key, revoked ones included, newest first
+func (h *HybridStore) ListAPIKeys() ([]*APIKey, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.APIKeyRepo.List()
+	}
+	return h.memoryStore.ListAPIKeys()
+}
+
+// TouchAPIKey records when an API key was last used
+func (h *HybridStore) TouchAPIKey(id string, usedAt time.Time) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.APIKeyRepo.Touch(id, usedAt)
+	}
+	return h.memoryStore.TouchAPIKey(id, usedAt)
+}
+
+// RevokeAPIKey stops an API key from authenticating
+func (h *HybridStore) RevokeAPIKey(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.APIKeyRepo.Revoke(id)
+	}
+	return h.memoryStore.RevokeAPIKey(id)
+}
+
 // GetBackend returns the current backend type
 func (h *HybridStore) GetBackend() StoreBackend {
 	return h.backend
 }
 
+// CreateJob queues a new background job
+func (h *HybridStore) CreateJob(job *Job) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.Create(job)
+	}
+	return h.memoryStore.CreateJob(job)
+}
+
+// GetJob retrieves a background job by ID
+func (h *HybridStore) GetJob(id string) (*Job, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.GetByID(id)
+	}
+	return h.memoryStore.GetJob(id)
+}
+
+// ListJobs retrieves up to limit of the most recently created jobs matching the filters
+func (h *HybridStore) ListJobs(filters JobFilters, limit int) ([]*Job, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.List(filters, limit)
+	}
+	return h.memoryStore.ListJobs(filters, limit)
+}
+
+// ClaimNextJob marks the oldest due pending job running and returns it, or nil when none is due
+func (h *HybridStore) ClaimNextJob(now time.Time) (*Job, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.ClaimNext(now)
+	}
+	return h.memoryStore.ClaimNextJob(now)
+}
+
+// CompleteJob marks a running job succeeded with the ID of the record it produced, failing with
+// ErrJobAttemptSuperseded if attempt is no longer the job's running one
+func (h *HybridStore) CompleteJob(id string, attempt int, resultID string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.Complete(id, attempt, resultID)
+	}
+	return h.memoryStore.CompleteJob(id, attempt, resultID)
+}
+
+// FailJob records a failed attempt, rescheduling the job at retryAt or dead-lettering it when retryAt is nil
+func (h *HybridStore) FailJob(id string, attempt int, lastError string, retryAt *time.Time) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.Fail(id, attempt, lastError, retryAt)
+	}
+	return h.memoryStore.FailJob(id, attempt, lastError, retryAt)
+}
+
+// RequeueStaleJobs returns jobs whose attempt started before startedBefore to the queue, dead-lettering
+// those that have used all their attempts
+func (h *HybridStore) RequeueStaleJobs(startedBefore time.Time) (requeued, dead int, err error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		requeuedRows, deadRows, err := h.dbService.JobRepo.RequeueStale(startedBefore)
+		return int(requeuedRows), int(deadRows), err
+	}
+	return h.memoryStore.RequeueStaleJobs(startedBefore)
+}
+
+// RetryJob puts a dead job back on the queue with a fresh set of attempts
+func (h *HybridStore) RetryJob(id string) (*Job, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.JobRepo.Retry(id)
+	}
+	return h.memoryStore.RetryJob(id)
+}
+
+// GetFile retrieves an uploaded file by ID
+func (h *HybridStore) GetFile(id string) (*File, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.FileRepo.GetByID(id)
+	}
+	return h.memoryStore.GetFile(id)
+}
+
+// ListInterviewFiles retrieves every file stored for an interview, so their contents can be removed with it
+func (h *HybridStore) ListInterviewFiles(interviewID string) ([]File, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.FileRepo.ListByInterview(interviewID)
+	}
+	return h.memoryStore.ListInterviewFiles(interviewID)
+}
+
+// DeleteFile removes a file record once its contents are gone from the blob store
+func (h *HybridStore) DeleteFile(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.FileRepo.Delete(id)
+	}
+	return h.memoryStore.DeleteFile(id)
+}
+
+// AttachInterviewResume stores the file as its interview's resume, replacing any previous one, and
+// returns the replaced file so the caller can remove its contents from the blob store and then its record
+func (h *HybridStore) AttachInterviewResume(file *File) (*File, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.FileRepo.AttachResume(file)
+	}
+	return h.memoryStore.AttachInterviewResume(file)
+}
+
+// SetInterviewCandidateProfile stores the interview's structured candidate profile; a non-empty
+// resumeFileID guards against storing a profile parsed from a resume that has since been replaced
+func (h *HybridStore) SetInterviewCandidateProfile(interviewID, resumeFileID string, profile CandidateProfile) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.FileRepo.SetCandidateProfile(interviewID, resumeFileID, profile)
+	}
+	return h.memoryStore.SetInterviewCandidateProfile(interviewID, resumeFileID, profile)
+}
+
+// CreateWebhookSubscription stores a new webhook subscription
+func (h *HybridStore) CreateWebhookSubscription(subscription *WebhookSubscription) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.CreateSubscription(subscription)
+	}
+	return h.memoryStore.CreateWebhookSubscription(subscription)
+}
+
+// GetWebhookSubscription retrieves a webhook subscription by ID
+func (h *HybridStore) GetWebhookSubscription(id string) (*WebhookSubscription, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.GetSubscription(id)
+	}
+	return h.memoryStore.GetWebhookSubscription(id)
+}
+
+// GetWebhookSubscriptions retrieves all webhook subscriptions, oldest first
+func (h *HybridStore) GetWebhookSubscriptions() ([]*WebhookSubscription, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.ListSubscriptions()
+	}
+	return h.memoryStore.GetWebhookSubscriptions()
+}
+
+// UpdateWebhookSubscription saves a webhook subscription's settings
+func (h *HybridStore) UpdateWebhookSubscription(subscription *WebhookSubscription) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"url":         subscription.URL,
+			"secret":      subscription.Secret,
+			"event_types": subscription.EventTypes,
+			"description": subscription.Description,
+			"active":      subscription.Active,
+		}
+		return h.dbService.WebhookRepo.UpdateSubscription(subscription.ID, updates)
+	}
+	return h.memoryStore.UpdateWebhookSubscription(subscription)
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription and its delivery log
+func (h *HybridStore) DeleteWebhookSubscription(id string) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.DeleteSubscription(id)
+	}
+	return h.memoryStore.DeleteWebhookSubscription(id)
+}
+
+// GetWebhookEvent retrieves an outbox event by ID
+func (h *HybridStore) GetWebhookEvent(id string) (*WebhookEvent, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.GetEvent(id)
+	}
+	return h.memoryStore.GetWebhookEvent(id)
+}
+
+// DispatchWebhookEvents fans up to limit outbox events out into deliveries due at now, returning how many were created
+func (h *HybridStore) DispatchWebhookEvents(now time.Time, limit int) (int, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.DispatchEvents(now, limit)
+	}
+	return h.memoryStore.DispatchWebhookEvents(now, limit)
+}
+
+// GetWebhookDelivery retrieves a webhook delivery by ID
+func (h *HybridStore) GetWebhookDelivery(id string) (*WebhookDelivery, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.GetDelivery(id)
+	}
+	return h.memoryStore.GetWebhookDelivery(id)
+}
+
+// ListWebhookDeliveries retrieves up to limit of a subscription's most recent deliveries matching the filters
+func (h *HybridStore) ListWebhookDeliveries(subscriptionID string, filters WebhookDeliveryFilters, limit int) ([]*WebhookDelivery, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.ListDeliveries(subscriptionID, filters, limit)
+	}
+	return h.memoryStore.ListWebhookDeliveries(subscriptionID, filters, limit)
+}
+
+// ClaimDueWebhookDeliveries starts an attempt on up to limit due pending deliveries, moving their
+// next attempt lease into the future so an attempt lost to a crash is retried
+func (h *HybridStore) ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.ClaimDueDeliveries(now, lease, limit)
+	}
+	return h.memoryStore.ClaimDueWebhookDeliveries(now, lease, limit)
+}
+
+// CompleteWebhookDelivery records a successful attempt
+func (h *HybridStore) CompleteWebhookDelivery(id string, responseStatus int) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.CompleteDelivery(id, responseStatus)
+	}
+	return h.memoryStore.CompleteWebhookDelivery(id, responseStatus)
+}
+
+// FailWebhookDelivery records a failed attempt, retrying at retryAt or giving up when retryAt is nil
+func (h *HybridStore) FailWebhookDelivery(id string, responseStatus int, lastError string, retryAt *time.Time) error {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.FailDelivery(id, responseStatus, lastError, retryAt)
+	}
+	return h.memoryStore.FailWebhookDelivery(id, responseStatus, lastError, retryAt)
+}
+
+// ReplayWebhookDelivery queues a new delivery of the same event to the same subscription
+func (h *HybridStore) ReplayWebhookDelivery(id string) (*WebhookDelivery, error) {
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WebhookRepo.ReplayDelivery(id)
+	}
+	return h.memoryStore.ReplayWebhookDelivery(id)
+}
+
 // Health checks the health of the current backend
 func (h *HybridStore) Health() error {
 	if h.backend == BackendDatabase && h.dbService != nil {
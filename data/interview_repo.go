@@ -3,27 +3,44 @@ package data
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Interview edit and delete conflicts
+var (
+	ErrInterviewSessionActive  = errors.New("interview has an active chat session")
+	ErrInterviewHasDependents  = errors.New("interview has chat sessions or evaluations")
+	ErrInvalidStatusTransition = errors.New("invalid interview status transition")
 )
 
 // InterviewFilters defines filter options for interview queries
 type InterviewFilters struct {
-	CandidateName string
-	Status        string
-	Type          string
-	CreatedAfter  time.Time
-	CreatedBefore time.Time
+	CandidateName  string
+	CandidateID    string
+	PositionID     string
+	Status         string
+	Type           string
+	TemplateID     string
+	JobDescription string // Exact match
+	CreatedBy      string // Interviews created by this principal
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
 }
 
 // InterviewRepository interface defines the contract for interview data access
 type InterviewRepository interface {
-	Create(interview *Interview) error
+	Create(interview *Interview, events ...*WebhookEvent) error
+	CreateBatch(candidates []*Candidate, interviews []*Interview, events []*WebhookEvent) error
 	GetByID(id string) (*Interview, error)
 	List(limit, offset int, filters InterviewFilters) ([]*Interview, int64, error)
 	Update(id string, updates map[string]interface{}) error
-	Delete(id string) error
+	Edit(id string, updates map[string]interface{}) error
+	Transition(id, status string) (*Interview, error)
+	Delete(id string, cascade bool) error
 	GetWithEvaluation(id string) (*Interview, *Evaluation, error)
 }
 
@@ -37,11 +54,41 @@ func NewInterviewRepository(db *gorm.DB) InterviewRepository {
 	return &interviewRepository{db: db}
 }
 
-// Create creates a new interview
-func (r *interviewRepository) Create(interview *Interview) error {
+// Create creates a new interview, recording the webhook events in the same transaction
+func (r *interviewRepository) Create(interview *Interview, events ...*WebhookEvent) error {
 	interview.CreatedAt = time.Now()
 	interview.UpdatedAt = time.Now()
-	return r.db.Create(interview).Error
+	return withWebhookEvents(r.db, events, func(tx *gorm.DB) error {
+		return tx.Create(interview).Error
+	})
+}
+
+// CreateBatch creates new candidates and the interviews referencing them in one transaction,
+// rejecting the whole batch if any candidate's email or external ID is already taken
+func (r *interviewRepository) CreateBatch(candidates []*Candidate, interviews []*Interview, events []*WebhookEvent) error {
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, candidate := range candidates {
+			if err := checkCandidateUnique(tx, candidate.Email, candidate.ExternalID, ""); err != nil {
+				return err
+			}
+			candidate.CreatedAt = now
+			candidate.UpdatedAt = now
+			if err := tx.Create(candidate).Error; err != nil {
+				return err
+			}
+		}
+		for _, interview := range interviews {
+			interview.CreatedAt = now
+			interview.UpdatedAt = now
+		}
+		if len(interviews) > 0 {
+			if err := tx.CreateInBatches(interviews, 100).Error; err != nil {
+				return err
+			}
+		}
+		return createWebhookEvents(tx, events)
+	})
 }
 
 // GetByID retrieves an interview by ID
@@ -64,12 +111,27 @@ func (r *interviewRepository) List(limit, offset int, filters InterviewFilters)
 	if filters.CandidateName != "" {
 		query = query.Where("candidate_name ILIKE ?", "%"+filters.CandidateName+"%")
 	}
+	if filters.CandidateID != "" {
+		query = query.Where("candidate_id = ?", filters.CandidateID)
+	}
+	if filters.PositionID != "" {
+		query = query.Where("position_id = ?", filters.PositionID)
+	}
 	if filters.Status != "" {
 		query = query.Where("status = ?", filters.Status)
 	}
 	if filters.Type != "" {
 		query = query.Where("type = ?", filters.Type)
 	}
+	if filters.TemplateID != "" {
+		query = query.Where("template_id = ?", filters.TemplateID)
+	}
+	if filters.JobDescription != "" {
+		query = query.Where("job_description = ?", filters.JobDescription)
+	}
+	if filters.CreatedBy != "" {
+		query = query.Where("created_by = ?", filters.CreatedBy)
+	}
 	if !filters.CreatedAfter.IsZero() {
 		query = query.Where("created_at >= ?", filters.CreatedAfter)
 	}
@@ -91,9 +153,118 @@ func (r *interviewRepository) Update(id string, updates map[string]interface{})
 	return r.db.Model(&Interview{}).Where("id = ?", id).Updates(updates).Error
 }
 
-// Delete deletes an interview (soft delete could be implemented here)
-func (r *interviewRepository) Delete(id string) error {
-	return r.db.Where("id = ?", id).Delete(&Interview{}).Error
+// Edit applies user edits to an interview, rejecting them while a chat session is active
+func (r *interviewRepository) Edit(id string, updates map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the interview row so concurrent edits and deletes are serialized
+		var interview Interview
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&interview).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("interview not found")
+			}
+			return err
+		}
+		if err := checkNoActiveSession(tx, id); err != nil {
+			return err
+		}
+		updates["updated_at"] = time.Now()
+		return tx.Model(&Interview{}).Where("id = ?", id).Updates(updates).Error
+	})
+}
+
+// Transition moves an interview to a new lifecycle status if the transition is allowed
+func (r *interviewRepository) Transition(id, status string) (*Interview, error) {
+	var interview Interview
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&interview).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("interview not found")
+			}
+			return err
+		}
+		if !CanTransitionInterview(interview.Status, status) {
+			return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, normalizeInterviewStatus(interview.Status), status)
+		}
+		if EndsInterviewSessions(status) {
+			if err := checkNoActiveSession(tx, id); err != nil {
+				return err
+			}
+		}
+		interview.Status = status
+		interview.UpdatedAt = time.Now()
+		return tx.Model(&Interview{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": interview.UpdatedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &interview, nil
+}
+
+// Delete deletes an interview. With cascade its chat sessions, messages, evaluations and their
+// reviews and ratings are deleted in the same transaction; without it any of those block the delete.
+// An active chat session always blocks the delete.
+func (r *interviewRepository) Delete(id string, cascade bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkNoActiveSession(tx, id); err != nil {
+			return err
+		}
+
+		sessionIDs := tx.Model(&ChatSession{}).Select("id").Where("interview_id = ?", id)
+		evaluationIDs := tx.Model(&Evaluation{}).Select("id").Where("interview_id = ?", id)
+
+		if !cascade {
+			var sessions, evaluations int64
+			if err := tx.Model(&ChatSession{}).Where("interview_id = ?", id).Count(&sessions).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&Evaluation{}).Where("interview_id = ?", id).Count(&evaluations).Error; err != nil {
+				return err
+			}
+			if sessions+evaluations > 0 {
+				return ErrInterviewHasDependents
+			}
+		}
+
+		// Children before parents so the foreign keys are never violated
+		steps := []*gorm.DB{
+			tx.Where("evaluation_id IN (?)", evaluationIDs).Delete(&EvaluationRating{}),
+			tx.Where("evaluation_id IN (?)", evaluationIDs).Delete(&EvaluationRevision{}),
+			tx.Where("interview_id = ?", id).Delete(&Evaluation{}),
+			tx.Where("session_id IN (?)", sessionIDs).Delete(&ChatMessage{}),
+			tx.Where("interview_id = ?", id).Delete(&ChatSession{}),
+			tx.Where("interview_id = ?", id).Delete(&File{}),
+			tx.Where("interview_id = ?", id).Delete(&Invitation{}),
+		}
+		for _, step := range steps {
+			if step.Error != nil {
+				return step.Error
+			}
+		}
+
+		result := tx.Where("id = ?", id).Delete(&Interview{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("interview not found")
+		}
+		return nil
+	})
+}
+
+// checkNoActiveSession returns ErrInterviewSessionActive if the interview has an active chat session
+func checkNoActiveSession(tx *gorm.DB, interviewID string) error {
+	var active int64
+	if err := tx.Model(&ChatSession{}).Where("interview_id = ? AND status IN ?", interviewID, openSessionStatuses).Count(&active).Error; err != nil {
+		return err
+	}
+	if active > 0 {
+		return ErrInterviewSessionActive
+	}
+	return nil
 }
 
 // GetWithEvaluation retrieves an interview with its evaluation
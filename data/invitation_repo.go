@@ -0,0 +1,95 @@
+// Candidate invitation data access
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvitationUnusable is returned when using an invitation that is revoked, expired or used up
+var ErrInvitationUnusable = errors.New("invitation is revoked, expired or used up")
+
+// InvitationRepository interface defines the contract for invitation data access
+type InvitationRepository interface {
+	Create(invitation *Invitation) error
+	GetByID(id string) (*Invitation, error)
+	ListByInterview(interviewID string) ([]*Invitation, error)
+	Use(id string) error
+	Revoke(id string) error
+}
+
+// invitationRepository implements InvitationRepository interface
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new invitation repository
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+// Create creates a new invitation
+func (r *invitationRepository) Create(invitation *Invitation) error {
+	invitation.CreatedAt = time.Now()
+	invitation.UpdatedAt = time.Now()
+	return r.db.Create(invitation).Error
+}
+
+// GetByID retrieves an invitation by ID
+func (r *invitationRepository) GetByID(id string) (*Invitation, error) {
+	var invitation Invitation
+	err := r.db.Where("id = ?", id).First(&invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("invitation not found")
+	}
+	return &invitation, err
+}
+
+// ListByInterview retrieves an interview's invitations, newest first
+func (r *invitationRepository) ListByInterview(interviewID string) ([]*Invitation, error) {
+	var invitations []*Invitation
+	err := r.db.Where("interview_id = ?", interviewID).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// Use counts one use of an invitation. The checks and the increment are a single UPDATE so
+// concurrent requests cannot use more than the allowed uses.
+func (r *invitationRepository) Use(id string) error {
+	return useInvitation(r.db, id)
+}
+
+// useInvitation counts one use of an invitation within db, which may be a transaction
+func useInvitation(db *gorm.DB, id string) error {
+	now := time.Now()
+	result := db.Model(&Invitation{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND uses < max_uses", id, now).
+		Updates(map[string]interface{}{
+			"uses":         gorm.Expr("uses + 1"),
+			"last_used_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	if err := db.Where("id = ?", id).First(&Invitation{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invitation not found")
+		}
+		return err
+	}
+	return ErrInvitationUnusable
+}
+
+// Revoke stops an invitation from granting access; revoking it again is a no-op
+func (r *invitationRepository) Revoke(id string) error {
+	now := time.Now()
+	result := r.db.Model(&Invitation{}).Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": now, "updated_at": now})
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+	_, err := r.GetByID(id)
+	return err
+}
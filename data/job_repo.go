@@ -0,0 +1,221 @@
+// Background job queue data access
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrJobNotDead is returned when retrying a job that has not been dead-lettered
+var ErrJobNotDead = errors.New("only dead jobs can be retried")
+
+// ErrJobAttemptSuperseded is returned when recording the outcome of an attempt that is no longer the
+// job's running one, e.g. because it timed out and the job was requeued or dead-lettered
+var ErrJobAttemptSuperseded = errors.New("job attempt is no longer running")
+
+// staleJobError is recorded on jobs dead-lettered after their last attempt ran past the timeout
+const staleJobError = "attempt timed out"
+
+// JobFilters narrows job listings; empty fields match every job
+type JobFilters struct {
+	Status string
+	Type   string
+}
+
+// JobRepository interface defines the contract for background job data access
+type JobRepository interface {
+	Create(job *Job) error
+	GetByID(id string) (*Job, error)
+	List(filters JobFilters, limit int) ([]*Job, error)
+	ClaimNext(now time.Time) (*Job, error)
+	Complete(id string, attempt int, resultID string) error
+	Fail(id string, attempt int, lastError string, retryAt *time.Time) error
+	RequeueStale(startedBefore time.Time) (requeued, dead int64, err error)
+	Retry(id string) (*Job, error)
+}
+
+// jobRepository implements JobRepository interface
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new background job repository
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// Create stores a new job
+func (r *jobRepository) Create(job *Job) error {
+	return r.db.Create(job).Error
+}
+
+// GetByID retrieves a job by ID
+func (r *jobRepository) GetByID(id string) (*Job, error) {
+	var job Job
+	err := r.db.Where("id = ?", id).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("job not found")
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// List retrieves the most recently created jobs matching the filters
+func (r *jobRepository) List(filters JobFilters, limit int) ([]*Job, error) {
+	var jobs []*Job
+	query := r.db.Model(&Job{})
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.Type != "" {
+		query = query.Where("type = ?", filters.Type)
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
+
+// ClaimNext marks the oldest due pending job running and returns it, or nil when none is due.
+// Locked rows are skipped so concurrent workers never claim the same job.
+func (r *jobRepository) ClaimNext(now time.Time) (*Job, error) {
+	var claimed *Job
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var job Job
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ?", JobStatusPending, now).
+			Order("run_at ASC").Limit(1).Take(&job).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		job.Status = JobStatusRunning
+		job.Attempts++
+		job.StartedAt = &now
+		job.UpdatedAt = now
+		if err := tx.Model(&Job{}).Where("id = ?", job.ID).Updates(map[string]interface{}{
+			"status":     job.Status,
+			"attempts":   job.Attempts,
+			"started_at": now,
+			"updated_at": now,
+		}).Error; err != nil {
+			return err
+		}
+		claimed = &job
+		return nil
+	})
+	return claimed, err
+}
+
+// Complete marks a running job succeeded with the ID of the record it produced. Only the claimed
+// attempt may complete it; ErrJobAttemptSuperseded is returned once it has been requeued or dead-lettered.
+func (r *jobRepository) Complete(id string, attempt int, resultID string) error {
+	now := time.Now()
+	return r.finishAttempt(id, attempt, map[string]interface{}{
+		"status":      JobStatusSucceeded,
+		"result_id":   resultID,
+		"last_error":  "",
+		"finished_at": now,
+		"updated_at":  now,
+	})
+}
+
+// Fail records a failed attempt, rescheduling the job at retryAt or dead-lettering it when retryAt is nil.
+// Like Complete it only applies to the claimed attempt.
+func (r *jobRepository) Fail(id string, attempt int, lastError string, retryAt *time.Time) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     JobStatusDead,
+		"last_error": lastError,
+		"updated_at": now,
+	}
+	if retryAt != nil {
+		updates["status"] = JobStatusPending
+		updates["run_at"] = *retryAt
+	} else {
+		updates["finished_at"] = now
+	}
+	return r.finishAttempt(id, attempt, updates)
+}
+
+// finishAttempt applies the outcome of an attempt if it is still the job's running one
+func (r *jobRepository) finishAttempt(id string, attempt int, updates map[string]interface{}) error {
+	result := r.db.Model(&Job{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, JobStatusRunning, attempt).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrJobAttemptSuperseded
+	}
+	return nil
+}
+
+// RequeueStale returns jobs whose attempt started before startedBefore to the queue, recovering
+// work interrupted by a crash or restart. The interrupted attempt still counts, so jobs that have
+// used all their attempts are dead-lettered instead.
+func (r *jobRepository) RequeueStale(startedBefore time.Time) (requeued, dead int64, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		stale := "status = ? AND started_at < ?"
+		result := tx.Model(&Job{}).
+			Where(stale+" AND attempts >= max_attempts", JobStatusRunning, startedBefore).
+			Updates(map[string]interface{}{"status": JobStatusDead, "last_error": staleJobError, "finished_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		dead = result.RowsAffected
+		result = tx.Model(&Job{}).
+			Where(stale, JobStatusRunning, startedBefore).
+			Updates(map[string]interface{}{"status": JobStatusPending, "run_at": now, "updated_at": now})
+		requeued = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return requeued, dead, nil
+}
+
+// Retry puts a dead job back on the queue with a fresh set of attempts
+func (r *jobRepository) Retry(id string) (*Job, error) {
+	var retried *Job
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var job Job
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&job).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("job not found")
+			}
+			return err
+		}
+		if job.Status != JobStatusDead {
+			return ErrJobNotDead
+		}
+
+		now := time.Now()
+		job.Status = JobStatusPending
+		job.Attempts = 0
+		job.RunAt = now
+		job.FinishedAt = nil
+		job.UpdatedAt = now
+		if err := tx.Model(&Job{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":      job.Status,
+			"attempts":    0,
+			"run_at":      now,
+			"finished_at": nil,
+			"updated_at":  now,
+		}).Error; err != nil {
+			return err
+		}
+		retried = &job
+		return nil
+	})
+	return retried, err
+}
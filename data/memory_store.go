@@ -2,6 +2,7 @@ package data
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -15,7 +16,28 @@ type MemoryStore struct {
 	evaluations  map[string]*Evaluation
 	chatSessions map[string]*ChatSession
 	chatMessages map[string][]*ChatMessage
-	mu           sync.RWMutex
+	questions    map[string]*Question
+	templates    map[string]*InterviewTemplate
+	// Human review history per evaluation ID
+	evaluationRevisions map[string][]*EvaluationRevision
+	// Blind calibration ratings per evaluation ID
+	evaluationRatings map[string][]*EvaluationRating
+	// Background jobs; unlike the database backend these do not survive a restart
+	jobs map[string]*Job
+	// Uploaded files; their contents live in the blob store
+	files      map[string]*File
+	candidates map[string]*Candidate
+	positions  map[string]*Position
+	// Candidate invitation links
+	invitations map[string]*Invitation
+	// Integration API keys
+	apiKeys map[string]*APIKey
+	// Webhook subscriptions, outbox events, the events not yet fanned out, and deliveries
+	webhookSubscriptions map[string]*WebhookSubscription
+	webhookEvents        map[string]*WebhookEvent
+	webhookOutbox        []*WebhookEvent
+	webhookDeliveries    map[string]*WebhookDelivery
+	mu                   sync.RWMutex
 }
 
 // NewMemoryStore creates a new in-memory store
@@ -25,6 +47,21 @@ func NewMemoryStore() *MemoryStore {
 		evaluations:  make(map[string]*Evaluation),
 		chatSessions: make(map[string]*ChatSession),
 		chatMessages: make(map[string][]*ChatMessage),
+		questions:    make(map[string]*Question),
+		templates:    make(map[string]*InterviewTemplate),
+
+		evaluationRevisions: make(map[string][]*EvaluationRevision),
+		evaluationRatings:   make(map[string][]*EvaluationRating),
+		jobs:                make(map[string]*Job),
+		files:               make(map[string]*File),
+		candidates:          make(map[string]*Candidate),
+		positions:           make(map[string]*Position),
+		invitations:         make(map[string]*Invitation),
+		apiKeys:             make(map[string]*APIKey),
+
+		webhookSubscriptions: make(map[string]*WebhookSubscription),
+		webhookEvents:        make(map[string]*WebhookEvent),
+		webhookDeliveries:    make(map[string]*WebhookDelivery),
 	}
 }
 
@@ -32,10 +69,40 @@ func NewMemoryStore() *MemoryStore {
 var Store = NewMemoryStore()
 
 // Interview operations
-func (ms *MemoryStore) CreateInterview(interview *Interview) error {
+func (ms *MemoryStore) CreateInterview(interview *Interview, events ...*WebhookEvent) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 	ms.interviews[interview.ID] = interview
+	ms.recordWebhookEvents(events)
+	return nil
+}
+
+// CreateInterviewBatch creates new candidates and their interviews together, rejecting the
+// whole batch if any candidate's email or external ID is already taken
+func (ms *MemoryStore) CreateInterviewBatch(candidates []*Candidate, interviews []*Interview, events []*WebhookEvent) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for i, candidate := range candidates {
+		if ms.candidateTaken(candidate.Email, candidate.ExternalID, "") {
+			return ErrDuplicateCandidate
+		}
+		// Candidates earlier in the batch count too
+		for _, other := range candidates[:i] {
+			if (candidate.Email != "" && candidate.Email == other.Email) || (candidate.ExternalID != "" && candidate.ExternalID == other.ExternalID) {
+				return ErrDuplicateCandidate
+			}
+		}
+	}
+	now := time.Now()
+	for _, candidate := range candidates {
+		candidate.CreatedAt = now
+		candidate.UpdatedAt = now
+		ms.candidates[candidate.ID] = candidate
+	}
+	for _, interview := range interviews {
+		ms.interviews[interview.ID] = interview
+	}
+	ms.recordWebhookEvents(events)
 	return nil
 }
 
@@ -59,17 +126,122 @@ func (ms *MemoryStore) GetInterviews() ([]*Interview, error) {
 	return interviews, nil
 }
 
+// hasActiveSession reports whether the interview has an active or paused chat session; callers hold the lock
+func (ms *MemoryStore) hasActiveSession(interviewID string) bool {
+	for _, session := range ms.chatSessions {
+		if session.InterviewID == interviewID && session.IsOpen() {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateInterview replaces an interview, rejecting edits while a chat session is active
+func (ms *MemoryStore) UpdateInterview(interview *Interview) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	existing, exists := ms.interviews[interview.ID]
+	if !exists {
+		return fmt.Errorf("interview not found")
+	}
+	if ms.hasActiveSession(interview.ID) {
+		return ErrInterviewSessionActive
+	}
+	// Status only changes through TransitionInterview
+	interview.Status = existing.Status
+	interview.UpdatedAt = time.Now()
+	ms.interviews[interview.ID] = interview
+	return nil
+}
+
+// TransitionInterview moves an interview to a new lifecycle status if the transition is allowed
+func (ms *MemoryStore) TransitionInterview(id, status string) (*Interview, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[id]
+	if !exists {
+		return nil, fmt.Errorf("interview not found")
+	}
+	if !CanTransitionInterview(interview.Status, status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, normalizeInterviewStatus(interview.Status), status)
+	}
+	if EndsInterviewSessions(status) && ms.hasActiveSession(id) {
+		return nil, ErrInterviewSessionActive
+	}
+	// Replace rather than mutate so readers holding the previous pointer see a consistent record
+	updated := *interview
+	updated.Status = status
+	updated.UpdatedAt = time.Now()
+	ms.interviews[id] = &updated
+	return &updated, nil
+}
+
+// DeleteInterview removes an interview, cascading to its sessions, messages, evaluations,
+// reviews and ratings when cascade is set and refusing otherwise if any exist
+func (ms *MemoryStore) DeleteInterview(id string, cascade bool) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.interviews[id]; !exists {
+		return fmt.Errorf("interview not found")
+	}
+	if ms.hasActiveSession(id) {
+		return ErrInterviewSessionActive
+	}
+
+	var sessionIDs, evaluationIDs []string
+	for sessionID, session := range ms.chatSessions {
+		if session.InterviewID == id {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	for evaluationID, evaluation := range ms.evaluations {
+		if evaluation.InterviewID == id {
+			evaluationIDs = append(evaluationIDs, evaluationID)
+		}
+	}
+	if !cascade && len(sessionIDs)+len(evaluationIDs) > 0 {
+		return ErrInterviewHasDependents
+	}
+
+	for _, evaluationID := range evaluationIDs {
+		delete(ms.evaluationRatings, evaluationID)
+		delete(ms.evaluationRevisions, evaluationID)
+		delete(ms.evaluations, evaluationID)
+	}
+	for _, sessionID := range sessionIDs {
+		delete(ms.chatMessages, sessionID)
+		delete(ms.chatSessions, sessionID)
+	}
+	for fileID, file := range ms.files {
+		if file.InterviewID != nil && *file.InterviewID == id {
+			delete(ms.files, fileID)
+		}
+	}
+	for invitationID, invitation := range ms.invitations {
+		if invitation.InterviewID == id {
+			delete(ms.invitations, invitationID)
+		}
+	}
+	delete(ms.interviews, id)
+	return nil
+}
+
 // ListInterviewsOptions defines options for listing interviews with pagination, filtering and sorting
 type ListInterviewsOptions struct {
-	Limit         int       // Page size (default: 10)
-	Offset        int       // Number of records to skip (default: 0)
-	Page          int       // Page number (1-based, used to calculate offset if provided)
-	CandidateName string    // Filter by candidate name (case-insensitive partial match)
-	Status        string    // Filter by status
-	DateFrom      time.Time // Filter interviews created after this date
-	DateTo        time.Time // Filter interviews created before this date
-	SortBy        string    // Sort field: "date", "name", "status" (default: "date")
-	SortOrder     string    // Sort order: "asc", "desc" (default: "desc")
+	Limit          int       // Page size (default: 10)
+	Offset         int       // Number of records to skip (default: 0)
+	Page           int       // Page number (1-based, used to calculate offset if provided)
+	CandidateName  string    // Filter by candidate name (case-insensitive partial match)
+	CandidateID    string    // Filter by candidate record
+	PositionID     string    // Filter by position
+	Status         string    // Filter by status
+	TemplateID     string    // Filter by source template
+	JobDescription string    // Filter by job description (exact match)
+	CreatedBy      string    // Filter by the principal that created the interview
+	DateFrom       time.Time // Filter interviews created after this date
+	DateTo         time.Time // Filter interviews created before this date
+	SortBy         string    // Sort field: "date", "name", "status" (default: "date")
+	SortOrder      string    // Sort order: "asc", "desc" (default: "desc")
 }
 
 // ListInterviewsResult contains the result of listing interviews with pagination info
@@ -110,10 +282,30 @@ func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*Li
 			}
 		}
 
+		if opts.CandidateID != "" && (interview.CandidateID == nil || *interview.CandidateID != opts.CandidateID) {
+			continue
+		}
+
+		if opts.PositionID != "" && (interview.PositionID == nil || *interview.PositionID != opts.PositionID) {
+			continue
+		}
+
 		if opts.Status != "" && interview.Status != opts.Status {
 			continue
 		}
 
+		if opts.TemplateID != "" && interview.TemplateID != opts.TemplateID {
+			continue
+		}
+
+		if opts.JobDescription != "" && interview.JobDescription != opts.JobDescription {
+			continue
+		}
+
+		if opts.CreatedBy != "" && interview.CreatedBy != opts.CreatedBy {
+			continue
+		}
+
 		if !opts.DateFrom.IsZero() && interview.CreatedAt.Before(opts.DateFrom) {
 			continue
 		}
@@ -181,14 +373,218 @@ func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*Li
 	}, nil
 }
 
+// ListEvaluationsOptions defines options for listing evaluations
+type ListEvaluationsOptions struct {
+	Limit     int // Page size (default: 10)
+	Offset    int // Number of records to skip (default: 0)
+	Page      int // Page number (1-based, used to calculate offset if provided)
+	Filters   EvaluationFilters
+	SortBy    string // Sort field: "date", "score" (default: "date")
+	SortOrder string // Sort order: "asc", "desc" (default: "desc")
+}
+
+// ListEvaluationsResult contains the result of listing evaluations with pagination info
+type ListEvaluationsResult struct {
+	Evaluations []*Evaluation
+	Total       int
+	Page        int
+	Limit       int
+	TotalPages  int
+}
+
+// matchesEvaluationFilters mirrors the database filter semantics for the memory store; callers hold the lock
+func (ms *MemoryStore) matchesEvaluationFilters(evaluation *Evaluation, filters EvaluationFilters) bool {
+	if filters.InterviewID != "" && evaluation.InterviewID != filters.InterviewID {
+		return false
+	}
+	if filters.PositionID != "" && ms.interviewPosition(evaluation.InterviewID) != filters.PositionID {
+		return false
+	}
+	if filters.CreatedBy != "" {
+		if interview, exists := ms.interviews[evaluation.InterviewID]; !exists || interview.CreatedBy != filters.CreatedBy {
+			return false
+		}
+	}
+	if filters.MinScore != nil && evaluation.Score < *filters.MinScore {
+		return false
+	}
+	if filters.MaxScore != nil && evaluation.Score > *filters.MaxScore {
+		return false
+	}
+	if !filters.CreatedAfter.IsZero() && evaluation.CreatedAt.Before(filters.CreatedAfter) {
+		return false
+	}
+	if !filters.CreatedBefore.IsZero() && evaluation.CreatedAt.After(filters.CreatedBefore) {
+		return false
+	}
+	if filters.Decision == DecisionNone && evaluation.Decision != "" {
+		return false
+	}
+	if filters.Decision != "" && filters.Decision != DecisionNone && evaluation.Decision != filters.Decision {
+		return false
+	}
+	if filters.NeedsReview != nil && evaluation.NeedsReview != *filters.NeedsReview {
+		return false
+	}
+	if filters.LowConfidence != nil && evaluation.LowConfidence != *filters.LowConfidence {
+		return false
+	}
+	return true
+}
+
+// ListEvaluations returns evaluations with pagination, filtering, and sorting
+func (ms *MemoryStore) ListEvaluations(opts ListEvaluationsOptions) (*ListEvaluationsResult, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	// Set defaults
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	if opts.Page > 0 {
+		opts.Offset = (opts.Page - 1) * opts.Limit
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+
+	evaluations := make([]*Evaluation, 0)
+	for _, evaluation := range ms.evaluations {
+		if ms.matchesEvaluationFilters(evaluation, opts.Filters) {
+			evaluations = append(evaluations, evaluation)
+		}
+	}
+
+	// Sort like the database: by the chosen field, then by ID in the same direction
+	sort.Slice(evaluations, func(i, j int) bool {
+		a, b := evaluations[i], evaluations[j]
+		if opts.SortOrder != SortOrderAsc {
+			a, b = b, a
+		}
+		if opts.SortBy == EvaluationSortScore {
+			if a.Score != b.Score {
+				return a.Score < b.Score
+			}
+		} else if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+
+	total := len(evaluations)
+	start := opts.Offset
+	if start > total {
+		start = total
+	}
+	end := start + opts.Limit
+	if end > total {
+		end = total
+	}
+
+	return &ListEvaluationsResult{
+		Evaluations: evaluations[start:end],
+		Total:       total,
+	}, nil
+}
+
+// GetEvaluationStatistics aggregates scores of the evaluations matching the filters
+func (ms *MemoryStore) GetEvaluationStatistics(filters EvaluationFilters) (*EvaluationStatistics, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	stats := &EvaluationStatistics{ScoreDistribution: make(map[string]int)}
+	var sum float64
+	for _, evaluation := range ms.evaluations {
+		if !ms.matchesEvaluationFilters(evaluation, filters) {
+			continue
+		}
+		if stats.TotalEvaluations == 0 || evaluation.Score < stats.MinScore {
+			stats.MinScore = evaluation.Score
+		}
+		if stats.TotalEvaluations == 0 || evaluation.Score > stats.MaxScore {
+			stats.MaxScore = evaluation.Score
+		}
+		stats.TotalEvaluations++
+		sum += evaluation.Score
+		stats.ScoreDistribution[scoreRange(evaluation.Score)]++
+	}
+	if stats.TotalEvaluations > 0 {
+		stats.AverageScore = sum / float64(stats.TotalEvaluations)
+	}
+	return stats, nil
+}
+
+// GetEvaluationStatisticsByPosition aggregates the filtered evaluations per position of their interview
+func (ms *MemoryStore) GetEvaluationStatisticsByPosition(filters EvaluationFilters) (map[string]*EvaluationStatistics, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	groups := make(map[string]*EvaluationStatistics)
+	sums := make(map[string]float64)
+	for _, evaluation := range ms.evaluations {
+		positionID := ms.interviewPosition(evaluation.InterviewID)
+		if positionID == "" || !ms.matchesEvaluationFilters(evaluation, filters) {
+			continue
+		}
+		stats, exists := groups[positionID]
+		if !exists {
+			stats = &EvaluationStatistics{ScoreDistribution: make(map[string]int), MinScore: evaluation.Score, MaxScore: evaluation.Score}
+			groups[positionID] = stats
+		}
+		stats.TotalEvaluations++
+		stats.MinScore = min(stats.MinScore, evaluation.Score)
+		stats.MaxScore = max(stats.MaxScore, evaluation.Score)
+		stats.ScoreDistribution[scoreRange(evaluation.Score)]++
+		sums[positionID] += evaluation.Score
+	}
+	for positionID, stats := range groups {
+		stats.AverageScore = sums[positionID] / float64(stats.TotalEvaluations)
+	}
+	return groups, nil
+}
+
+// interviewPosition returns the position of an interview, or "" if it has none; callers hold the lock
+func (ms *MemoryStore) interviewPosition(interviewID string) string {
+	if interview, exists := ms.interviews[interviewID]; exists && interview.PositionID != nil {
+		return *interview.PositionID
+	}
+	return ""
+}
+
 // Evaluation operations
-func (ms *MemoryStore) CreateEvaluation(evaluation *Evaluation) error {
+func (ms *MemoryStore) CreateEvaluation(evaluation *Evaluation, events ...*WebhookEvent) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.evaluations[evaluation.ID] = evaluation
+	ms.recordWebhookEvents(events)
+	return nil
+}
+
+// CreateJobEvaluation stores the evaluation produced by attempt of its background job, provided
+// that attempt is still running
+func (ms *MemoryStore) CreateJobEvaluation(evaluation *Evaluation, attempt int, events ...*WebhookEvent) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
+	if _, err := ms.runningJobAttempt(evaluation.JobID, attempt); err != nil {
+		return err
+	}
 	ms.evaluations[evaluation.ID] = evaluation
+	ms.recordWebhookEvents(events)
 	return nil
 }
 
+// GetJobEvaluation returns the evaluation produced by a background job, or nil if it has not stored one
+func (ms *MemoryStore) GetJobEvaluation(jobID string) (*Evaluation, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	for _, evaluation := range ms.evaluations {
+		if evaluation.JobID == jobID {
+			return evaluation, nil
+		}
+	}
+	return nil, nil
+}
+
 func (ms *MemoryStore) GetEvaluation(id string) (*Evaluation, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
@@ -199,6 +595,105 @@ func (ms *MemoryStore) GetEvaluation(id string) (*Evaluation, error) {
 	return evaluation, nil
 }
 
+// ReviewEvaluation stores the evaluation's human review fields and appends the audit revision
+func (ms *MemoryStore) ReviewEvaluation(evaluation *Evaluation, revision *EvaluationRevision, events ...*WebhookEvent) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.evaluations[evaluation.ID]; !exists {
+		return fmt.Errorf("evaluation not found")
+	}
+	evaluation.UpdatedAt = time.Now()
+	ms.evaluations[evaluation.ID] = evaluation
+
+	revision.Revision = len(ms.evaluationRevisions[evaluation.ID]) + 1
+	revision.CreatedAt = time.Now()
+	ms.evaluationRevisions[evaluation.ID] = append(ms.evaluationRevisions[evaluation.ID], revision)
+	ms.recordWebhookEvents(events)
+	return nil
+}
+
+// GetEvaluationRevisions returns the human review history of an evaluation, oldest first
+func (ms *MemoryStore) GetEvaluationRevisions(evaluationID string) ([]*EvaluationRevision, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return append([]*EvaluationRevision(nil), ms.evaluationRevisions[evaluationID]...), nil
+}
+
+// AssignEvaluationRaters sets the reviewers expected to rate an evaluation
+func (ms *MemoryStore) AssignEvaluationRaters(evaluationID string, raters []string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	evaluation, exists := ms.evaluations[evaluationID]
+	if !exists {
+		return fmt.Errorf("evaluation not found")
+	}
+	evaluation.CalibrationRaters = raters
+	evaluation.UpdatedAt = time.Now()
+	return nil
+}
+
+// CreateEvaluationRating stores a calibration rating, rejecting a second rating by the same rater
+func (ms *MemoryStore) CreateEvaluationRating(rating *EvaluationRating) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for _, existing := range ms.evaluationRatings[rating.EvaluationID] {
+		if existing.Rater == rating.Rater {
+			return ErrDuplicateRating
+		}
+	}
+	rating.CreatedAt = time.Now()
+	ms.evaluationRatings[rating.EvaluationID] = append(ms.evaluationRatings[rating.EvaluationID], rating)
+	return nil
+}
+
+// GetEvaluationRatings returns the calibration ratings of the given evaluations
+func (ms *MemoryStore) GetEvaluationRatings(evaluationIDs []string) ([]*EvaluationRating, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	ratings := []*EvaluationRating{}
+	for _, id := range evaluationIDs {
+		ratings = append(ratings, ms.evaluationRatings[id]...)
+	}
+	return ratings, nil
+}
+
+// GetEvaluationsByTemplate returns evaluations of interviews created from the given template, oldest first
+func (ms *MemoryStore) GetEvaluationsByTemplate(templateID string) ([]*Evaluation, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	evaluations := []*Evaluation{}
+	for _, evaluation := range ms.evaluations {
+		if interview, exists := ms.interviews[evaluation.InterviewID]; exists && interview.TemplateID == templateID {
+			evaluations = append(evaluations, evaluation)
+		}
+	}
+	sort.Slice(evaluations, func(i, j int) bool {
+		return evaluations[i].CreatedAt.Before(evaluations[j].CreatedAt)
+	})
+	return evaluations, nil
+}
+
+// GetLatestEvaluations returns the most recent evaluation of each interview, keyed by interview ID
+func (ms *MemoryStore) GetLatestEvaluations(interviewIDs []string) (map[string]*Evaluation, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(interviewIDs))
+	for _, id := range interviewIDs {
+		wanted[id] = true
+	}
+	latest := make(map[string]*Evaluation, len(interviewIDs))
+	for _, evaluation := range ms.evaluations {
+		if !wanted[evaluation.InterviewID] {
+			continue
+		}
+		if current, ok := latest[evaluation.InterviewID]; !ok || evaluation.CreatedAt.After(current.CreatedAt) {
+			latest[evaluation.InterviewID] = evaluation
+		}
+	}
+	return latest, nil
+}
+
 // Chat session operations
 func (ms *MemoryStore) CreateChatSession(session *ChatSession) error {
 	ms.mu.Lock()
@@ -208,6 +703,48 @@ func (ms *MemoryStore) CreateChatSession(session *ChatSession) error {
 	return nil
 }
 
+// CreateInterviewSession creates a chat session if the interview has no active session and has retakes left,
+// using the invitation it is started with, if any, and starting the conversation with the greeting
+func (ms *MemoryStore) CreateInterviewSession(session *ChatSession, invitationID string, greeting *ChatMessage, events ...*WebhookEvent) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[session.InterviewID]
+	if !exists {
+		return fmt.Errorf("interview not found")
+	}
+	// The invitation is checked first so a spent one is reported as such, and used only with the session
+	var invitation *Invitation
+	if invitationID != "" {
+		var err error
+		if invitation, err = ms.usableInvitation(invitationID); err != nil {
+			return err
+		}
+	}
+	if ms.hasActiveSession(interview.ID) {
+		return ErrInterviewSessionActive
+	}
+	total := 0
+	for _, existing := range ms.chatSessions {
+		if existing.InterviewID == interview.ID {
+			total++
+		}
+	}
+	if total >= interview.MaxSessions() {
+		return ErrSessionLimitReached
+	}
+	if invitation != nil {
+		countInvitationUse(invitation)
+	}
+	ms.chatSessions[session.ID] = session
+	ms.chatMessages[session.ID] = []*ChatMessage{}
+	if greeting != nil {
+		greeting.SessionID = session.ID
+		ms.chatMessages[session.ID] = append(ms.chatMessages[session.ID], greeting)
+	}
+	ms.recordWebhookEvents(events)
+	return nil
+}
+
 func (ms *MemoryStore) GetChatSession(id string) (*ChatSession, error) {
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
@@ -218,7 +755,7 @@ func (ms *MemoryStore) GetChatSession(id string) (*ChatSession, error) {
 	return session, nil
 }
 
-func (ms *MemoryStore) UpdateChatSession(session *ChatSession) error {
+func (ms *MemoryStore) UpdateChatSession(session *ChatSession, events ...*WebhookEvent) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 	if _, exists := ms.chatSessions[session.ID]; !exists {
@@ -226,26 +763,1356 @@ func (ms *MemoryStore) UpdateChatSession(session *ChatSession) error {
 	}
 	session.UpdatedAt = time.Now()
 	ms.chatSessions[session.ID] = session
+	ms.recordWebhookEvents(events)
 	return nil
 }
 
-// Chat message operations
-func (ms *MemoryStore) AddChatMessage(message *ChatMessage) error {
+// CompleteChatSession marks an active or paused session completed and queues its evaluation job,
+// if any, returning ErrChatSessionEnded for a session that already ended
+func (ms *MemoryStore) CompleteChatSession(id string, endedAt time.Time, job *Job, events ...*WebhookEvent) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
-	if _, exists := ms.chatMessages[message.SessionID]; !exists {
+	session, exists := ms.chatSessions[id]
+	if !exists {
 		return fmt.Errorf("chat session not found")
 	}
-	ms.chatMessages[message.SessionID] = append(ms.chatMessages[message.SessionID], message)
+	if !session.IsOpen() {
+		return ErrChatSessionEnded
+	}
+	// Replace rather than mutate so readers holding the previous pointer see a consistent record
+	updated := *session
+	updated.Status = "completed"
+	updated.EndedAt = &endedAt
+	updated.UpdatedAt = time.Now()
+	ms.chatSessions[id] = &updated
+	if job != nil {
+		if job.CreatedAt.IsZero() {
+			job.CreatedAt = time.Now()
+		}
+		job.UpdatedAt = job.CreatedAt
+		ms.jobs[job.ID] = job
+	}
+	ms.recordWebhookEvents(events)
 	return nil
 }
 
-func (ms *MemoryStore) GetChatMessages(sessionID string) ([]*ChatMessage, error) {
-	ms.mu.RLock()
-	defer ms.mu.RUnlock()
-	messages, exists := ms.chatMessages[sessionID]
+// DeleteChatSession removes a finished chat session and its messages, unlinking its evaluations
+func (ms *MemoryStore) DeleteChatSession(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[id]
 	if !exists {
-		return nil, fmt.Errorf("chat session not found")
+		return fmt.Errorf("chat session not found")
+	}
+	if session.IsOpen() {
+		return ErrChatSessionActive
+	}
+	ms.deleteChatSession(id)
+	return nil
+}
+
+// deleteChatSession removes a session, its messages and its evaluations' link to it; callers hold the lock
+func (ms *MemoryStore) deleteChatSession(id string) {
+	for evaluationID, evaluation := range ms.evaluations {
+		if evaluation.SessionID != nil && *evaluation.SessionID == id {
+			unlinked := *evaluation
+			unlinked.SessionID = nil
+			ms.evaluations[evaluationID] = &unlinked
+		}
+	}
+	delete(ms.chatMessages, id)
+	delete(ms.chatSessions, id)
+}
+
+// AbandonInactiveChatSessions marks active or paused sessions without a message since inactiveSince as abandoned and returns them
+func (ms *MemoryStore) AbandonInactiveChatSessions(inactiveSince time.Time) ([]*ChatSession, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	abandoned := []*ChatSession{}
+	now := time.Now()
+	for id, session := range ms.chatSessions {
+		if !session.IsOpen() {
+			continue
+		}
+		lastActivity := session.StartedAt
+		for _, message := range ms.chatMessages[id] {
+			if message.Timestamp.After(lastActivity) {
+				lastActivity = message.Timestamp
+			}
+		}
+		if !lastActivity.Before(inactiveSince) {
+			continue
+		}
+		// Replace rather than mutate so readers holding the previous pointer see a consistent record
+		updated := *session
+		updated.Status = "abandoned"
+		updated.EndedAt = &now
+		updated.UpdatedAt = now
+		ms.chatSessions[id] = &updated
+		abandoned = append(abandoned, &updated)
+	}
+	return abandoned, nil
+}
+
+// PurgeAbandonedChatSessions deletes abandoned sessions that ended before endedBefore, with their messages
+func (ms *MemoryStore) PurgeAbandonedChatSessions(endedBefore time.Time) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	purged := 0
+	for id, session := range ms.chatSessions {
+		if session.Status == "abandoned" && session.EndedAt != nil && session.EndedAt.Before(endedBefore) {
+			ms.deleteChatSession(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// ListChatSessions returns all sessions of an interview, oldest first
+func (ms *MemoryStore) ListChatSessions(interviewID string) ([]*ChatSession, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	sessions := []*ChatSession{}
+	for _, session := range ms.chatSessions {
+		if session.InterviewID == interviewID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// Chat message operations
+func (ms *MemoryStore) AddChatMessage(message *ChatMessage) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.chatMessages[message.SessionID]; !exists {
+		return fmt.Errorf("chat session not found")
+	}
+	ms.chatMessages[message.SessionID] = append(ms.chatMessages[message.SessionID], message)
+	return nil
+}
+
+// GetChatMessages returns the visible messages of a session, leaving out superseded revisions
+func (ms *MemoryStore) GetChatMessages(sessionID string) ([]*ChatMessage, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	messages, exists := ms.chatMessages[sessionID]
+	if !exists {
+		return nil, fmt.Errorf("chat session not found")
+	}
+	visible := make([]*ChatMessage, 0, len(messages))
+	for _, message := range messages {
+		if message.SupersededBy == "" {
+			visible = append(visible, message)
+		}
+	}
+	return visible, nil
+}
+
+// GetChatMessageHistory returns every message of a session, including superseded revisions
+func (ms *MemoryStore) GetChatMessageHistory(sessionID string) ([]*ChatMessage, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	messages, exists := ms.chatMessages[sessionID]
+	if !exists {
+		return nil, fmt.Errorf("chat session not found")
+	}
+	return append([]*ChatMessage{}, messages...), nil
+}
+
+// ReplaceChatMessages supersedes the visible messages keyed by ID with their replacements and adds
+// the appended messages, provided the replaced ones are still the latest visible messages of the session
+func (ms *MemoryStore) ReplaceChatMessages(sessionID string, replacements map[string]*ChatMessage, appended ...*ChatMessage) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	messages, exists := ms.chatMessages[sessionID]
+	if !exists {
+		return fmt.Errorf("chat session not found")
+	}
+
+	// Messages are kept in order, so every visible message from the first replaced one on must be replaced
+	found := 0
+	for _, message := range messages {
+		if message.SupersededBy != "" {
+			continue
+		}
+		if _, ok := replacements[message.ID]; ok {
+			found++
+		} else if found > 0 {
+			return ErrStaleChatMessage
+		}
+	}
+	if found != len(replacements) {
+		return ErrStaleChatMessage
+	}
+
+	for i, message := range messages {
+		if replacement, ok := replacements[message.ID]; ok && message.SupersededBy == "" {
+			// Replace rather than mutate so readers holding the previous pointer see a consistent record
+			superseded := *message
+			superseded.SupersededBy = replacement.ID
+			messages[i] = &superseded
+		}
+	}
+	added := make([]*ChatMessage, 0, len(replacements)+len(appended))
+	for _, replacement := range replacements {
+		replacement.SessionID = sessionID
+		added = append(added, replacement)
+	}
+	for _, message := range appended {
+		message.SessionID = sessionID
+		added = append(added, message)
+	}
+	sort.SliceStable(added, func(i, j int) bool {
+		return added[i].Timestamp.Before(added[j].Timestamp)
+	})
+	ms.chatMessages[sessionID] = append(messages, added...)
+	return nil
+}
+
+// GetChatMessage finds a chat message by ID across all sessions
+func (ms *MemoryStore) GetChatMessage(id string) (*ChatMessage, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	for _, messages := range ms.chatMessages {
+		for _, message := range messages {
+			if message.ID == id {
+				return message, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("chat message not found")
+}
+
+// ListQuestionsOptions defines options for listing question bank entries with pagination, filtering and sorting
+type ListQuestionsOptions struct {
+	Limit         int      // Page size (default: 10)
+	Offset        int      // Number of records to skip (default: 0)
+	Page          int      // Page number (1-based, used to calculate offset if provided)
+	Tags          []string // Filter by tags (question must carry all of them)
+	InterviewType string   // Filter by interview type
+	Difficulty    string   // Filter by difficulty
+	Search        string   // Full-text search over text, translations and expected answer notes
+	SortBy        string   // Sort field: "date", "usage" (default: "date")
+}
+
+// ListQuestionsResult contains the result of listing question bank entries with pagination info
+type ListQuestionsResult struct {
+	Questions  []*Question
+	Total      int
+	Page       int
+	Limit      int
+	TotalPages int
+}
+
+// Question bank operations
+func (ms *MemoryStore) CreateQuestion(question *Question) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.questions[question.ID] = question
+	return nil
+}
+
+func (ms *MemoryStore) GetQuestion(id string) (*Question, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	question, exists := ms.questions[id]
+	if !exists {
+		return nil, fmt.Errorf("question not found")
+	}
+	return question, nil
+}
+
+// GetQuestionsByIDs returns questions in the order of the given IDs
+func (ms *MemoryStore) GetQuestionsByIDs(ids []string) ([]*Question, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	questions := make([]*Question, 0, len(ids))
+	for _, id := range ids {
+		question, exists := ms.questions[id]
+		if !exists {
+			return nil, fmt.Errorf("question not found: %s", id)
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+func (ms *MemoryStore) UpdateQuestion(question *Question) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.questions[question.ID]; !exists {
+		return fmt.Errorf("question not found")
+	}
+	question.UpdatedAt = time.Now()
+	ms.questions[question.ID] = question
+	return nil
+}
+
+func (ms *MemoryStore) DeleteQuestion(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.questions[id]; !exists {
+		return fmt.Errorf("question not found")
+	}
+	for _, interview := range ms.interviews {
+		if slices.Contains(interview.QuestionIDs, id) {
+			return ErrQuestionInUse
+		}
+	}
+	for _, template := range ms.templates {
+		if slices.Contains(template.QuestionIDs, id) {
+			return ErrQuestionInUse
+		}
+	}
+	delete(ms.questions, id)
+	return nil
+}
+
+// IncrementQuestionUsage bumps the usage count of every referenced question
+func (ms *MemoryStore) IncrementQuestionUsage(ids []string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if question, exists := ms.questions[id]; exists {
+			question.UsageCount++
+		}
+	}
+	return nil
+}
+
+// GetQuestionsWithOptions returns question bank entries with pagination, filtering, and sorting
+func (ms *MemoryStore) GetQuestionsWithOptions(opts ListQuestionsOptions) (*ListQuestionsResult, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	// Set defaults
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	if opts.Page > 0 {
+		opts.Offset = (opts.Page - 1) * opts.Limit
+	}
+
+	search := strings.ToLower(opts.Search)
+	allQuestions := make([]*Question, 0)
+	for _, question := range ms.questions {
+		if opts.InterviewType != "" && question.InterviewType != opts.InterviewType {
+			continue
+		}
+		if opts.Difficulty != "" && question.Difficulty != opts.Difficulty {
+			continue
+		}
+		if !hasAllTags(question.Tags, opts.Tags) {
+			continue
+		}
+		if search != "" && !questionMatches(question, search) {
+			continue
+		}
+		allQuestions = append(allQuestions, question)
+	}
+
+	sort.Slice(allQuestions, func(i, j int) bool {
+		if opts.SortBy == "usage" && allQuestions[i].UsageCount != allQuestions[j].UsageCount {
+			return allQuestions[i].UsageCount > allQuestions[j].UsageCount
+		}
+		return allQuestions[i].CreatedAt.After(allQuestions[j].CreatedAt)
+	})
+
+	total := len(allQuestions)
+	totalPages := (total + opts.Limit - 1) / opts.Limit
+
+	start := opts.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > total {
+		start = total
+	}
+	end := start + opts.Limit
+	if end > total {
+		end = total
+	}
+
+	return &ListQuestionsResult{
+		Questions:  allQuestions[start:end],
+		Total:      total,
+		Page:       opts.Page,
+		Limit:      opts.Limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// hasAllTags reports whether every wanted tag is present (case-insensitive)
+func hasAllTags(tags []string, wanted []string) bool {
+	for _, want := range wanted {
+		found := false
+		for _, tag := range tags {
+			if strings.EqualFold(tag, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// questionMatches performs a case-insensitive substring search over all searchable question fields
+func questionMatches(question *Question, search string) bool {
+	if strings.Contains(strings.ToLower(question.Text), search) ||
+		strings.Contains(strings.ToLower(question.ExpectedAnswer), search) {
+		return true
+	}
+	for _, translation := range question.Translations {
+		if strings.Contains(strings.ToLower(translation), search) {
+			return true
+		}
+	}
+	for _, tag := range question.Tags {
+		if strings.Contains(strings.ToLower(tag), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// Interview template operations
+func (ms *MemoryStore) CreateInterviewTemplate(template *InterviewTemplate) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.templateNameTaken(template.Name, "") {
+		return ErrDuplicateTemplateName
+	}
+	ms.templates[template.ID] = template
+	return nil
+}
+
+func (ms *MemoryStore) GetInterviewTemplate(id string) (*InterviewTemplate, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	template, exists := ms.templates[id]
+	if !exists {
+		return nil, fmt.Errorf("template not found")
+	}
+	return template, nil
+}
+
+// GetInterviewTemplates returns all templates ordered by name
+func (ms *MemoryStore) GetInterviewTemplates() ([]*InterviewTemplate, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	templates := make([]*InterviewTemplate, 0, len(ms.templates))
+	for _, template := range ms.templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+func (ms *MemoryStore) UpdateInterviewTemplate(template *InterviewTemplate) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.templates[template.ID]; !exists {
+		return fmt.Errorf("template not found")
+	}
+	if ms.templateNameTaken(template.Name, template.ID) {
+		return ErrDuplicateTemplateName
+	}
+	template.UpdatedAt = time.Now()
+	ms.templates[template.ID] = template
+	return nil
+}
+
+func (ms *MemoryStore) DeleteInterviewTemplate(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.templates[id]; !exists {
+		return fmt.Errorf("template not found")
+	}
+	delete(ms.templates, id)
+	return nil
+}
+
+// templateNameTaken reports whether another template already uses the name; caller must hold the lock
+func (ms *MemoryStore) templateNameTaken(name, excludeID string) bool {
+	for id, existing := range ms.templates {
+		if id != excludeID && existing.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Background job operations
+func (ms *MemoryStore) CreateJob(job *Job) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	job.UpdatedAt = job.CreatedAt
+	ms.jobs[job.ID] = job
+	return nil
+}
+
+func (ms *MemoryStore) GetJob(id string) (*Job, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	job, exists := ms.jobs[id]
+	if !exists {
+		return nil, fmt.Errorf("job not found")
+	}
+	return job, nil
+}
+
+// ListJobs returns up to limit of the most recently created jobs matching the filters
+func (ms *MemoryStore) ListJobs(filters JobFilters, limit int) ([]*Job, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	jobs := []*Job{}
+	for _, job := range ms.jobs {
+		if (filters.Status == "" || job.Status == filters.Status) && (filters.Type == "" || job.Type == filters.Type) {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt.After(jobs[j].CreatedAt)
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+// ClaimNextJob marks the oldest due pending job running and returns it, or nil when none is due
+func (ms *MemoryStore) ClaimNextJob(now time.Time) (*Job, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var next *Job
+	for _, job := range ms.jobs {
+		if job.Status == JobStatusPending && !job.RunAt.After(now) && (next == nil || job.RunAt.Before(next.RunAt)) {
+			next = job
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	// Replace rather than mutate so readers holding the previous pointer see a consistent record
+	claimed := *next
+	claimed.Status = JobStatusRunning
+	claimed.Attempts++
+	claimed.StartedAt = &now
+	claimed.UpdatedAt = now
+	ms.jobs[claimed.ID] = &claimed
+	return &claimed, nil
+}
+
+// CompleteJob marks a running job succeeded with the ID of the record it produced, if attempt is still its running one
+func (ms *MemoryStore) CompleteJob(id string, attempt int, resultID string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	job, err := ms.runningJobAttempt(id, attempt)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	completed := *job
+	completed.Status = JobStatusSucceeded
+	completed.ResultID = resultID
+	completed.LastError = ""
+	completed.FinishedAt = &now
+	completed.UpdatedAt = now
+	ms.jobs[id] = &completed
+	return nil
+}
+
+// FailJob records a failed attempt, rescheduling the job at retryAt or dead-lettering it when retryAt is nil
+func (ms *MemoryStore) FailJob(id string, attempt int, lastError string, retryAt *time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	job, err := ms.runningJobAttempt(id, attempt)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	failed := *job
+	failed.LastError = lastError
+	failed.UpdatedAt = now
+	if retryAt != nil {
+		failed.Status = JobStatusPending
+		failed.RunAt = *retryAt
+	} else {
+		failed.Status = JobStatusDead
+		failed.FinishedAt = &now
+	}
+	ms.jobs[id] = &failed
+	return nil
+}
+
+// runningJobAttempt returns the job if attempt is its running one; callers hold the lock
+func (ms *MemoryStore) runningJobAttempt(id string, attempt int) (*Job, error) {
+	job, exists := ms.jobs[id]
+	if !exists {
+		return nil, fmt.Errorf("job not found")
+	}
+	if job.Status != JobStatusRunning || job.Attempts != attempt {
+		return nil, ErrJobAttemptSuperseded
+	}
+	return job, nil
+}
+
+// RequeueStaleJobs returns jobs whose attempt started before startedBefore to the queue, dead-lettering
+// those that have used all their attempts
+func (ms *MemoryStore) RequeueStaleJobs(startedBefore time.Time) (requeued, dead int, err error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	now := time.Now()
+	for id, job := range ms.jobs {
+		if job.Status != JobStatusRunning || job.StartedAt == nil || !job.StartedAt.Before(startedBefore) {
+			continue
+		}
+		stale := *job
+		stale.UpdatedAt = now
+		if stale.Attempts >= stale.MaxAttempts {
+			stale.Status = JobStatusDead
+			stale.LastError = staleJobError
+			stale.FinishedAt = &now
+			dead++
+		} else {
+			stale.Status = JobStatusPending
+			stale.RunAt = now
+			requeued++
+		}
+		ms.jobs[id] = &stale
+	}
+	return requeued, dead, nil
+}
+
+// RetryJob puts a dead job back on the queue with a fresh set of attempts
+func (ms *MemoryStore) RetryJob(id string) (*Job, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	job, exists := ms.jobs[id]
+	if !exists {
+		return nil, fmt.Errorf("job not found")
+	}
+	if job.Status != JobStatusDead {
+		return nil, ErrJobNotDead
+	}
+	now := time.Now()
+	retried := *job
+	retried.Status = JobStatusPending
+	retried.Attempts = 0
+	retried.RunAt = now
+	retried.FinishedAt = nil
+	retried.UpdatedAt = now
+	ms.jobs[id] = &retried
+	return &retried, nil
+}
+
+// Candidate operations
+func (ms *MemoryStore) CreateCandidate(candidate *Candidate) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if ms.candidateTaken(candidate.Email, candidate.ExternalID, "") {
+		return ErrDuplicateCandidate
+	}
+	candidate.CreatedAt = time.Now()
+	candidate.UpdatedAt = candidate.CreatedAt
+	ms.candidates[candidate.ID] = candidate
+	return nil
+}
+
+func (ms *MemoryStore) GetCandidate(id string) (*Candidate, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	candidate, exists := ms.candidates[id]
+	if !exists {
+		return nil, fmt.Errorf("candidate not found")
+	}
+	return candidate, nil
+}
+
+// ListCandidates returns a page of the candidates matching the filters, ordered by name, and the total count
+func (ms *MemoryStore) ListCandidates(filters CandidateFilters, limit, offset int) ([]*Candidate, int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	matched := []*Candidate{}
+	for _, candidate := range ms.candidates {
+		if filters.Name != "" && !strings.Contains(strings.ToLower(candidate.Name), strings.ToLower(filters.Name)) {
+			continue
+		}
+		if filters.Email != "" && candidate.Email != filters.Email {
+			continue
+		}
+		if filters.ExternalID != "" && candidate.ExternalID != filters.ExternalID {
+			continue
+		}
+		if filters.CreatedBy != "" && candidate.CreatedBy != filters.CreatedBy && !ms.candidateInterviewedBy(candidate.ID, filters.CreatedBy) {
+			continue
+		}
+		matched = append(matched, candidate)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Name != matched[j].Name {
+			return matched[i].Name < matched[j].Name
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	total := len(matched)
+	start := min(max(offset, 0), total)
+	end := min(start+limit, total)
+	return matched[start:end], total, nil
+}
+
+// candidateInterviewedBy reports whether an interview created by the principal is linked to the
+// candidate; callers hold the lock
+func (ms *MemoryStore) candidateInterviewedBy(candidateID, createdBy string) bool {
+	for _, interview := range ms.interviews {
+		if interview.CandidateID != nil && *interview.CandidateID == candidateID && interview.CreatedBy == createdBy {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateCandidate replaces a candidate, rejecting an email or external ID used by another candidate
+func (ms *MemoryStore) UpdateCandidate(candidate *Candidate) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.candidates[candidate.ID]; !exists {
+		return fmt.Errorf("candidate not found")
+	}
+	if ms.candidateTaken(candidate.Email, candidate.ExternalID, candidate.ID) {
+		return ErrDuplicateCandidate
+	}
+	candidate.UpdatedAt = time.Now()
+	ms.candidates[candidate.ID] = candidate
+	return nil
+}
+
+// DeleteCandidate removes a candidate who has no interviews
+func (ms *MemoryStore) DeleteCandidate(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.candidates[id]; !exists {
+		return fmt.Errorf("candidate not found")
+	}
+	for _, interview := range ms.interviews {
+		if interview.CandidateID != nil && *interview.CandidateID == id {
+			return ErrCandidateHasInterviews
+		}
+	}
+	delete(ms.candidates, id)
+	return nil
+}
+
+// GetCandidateHistory returns the candidate's interviews with their chat sessions and evaluations, oldest first
+func (ms *MemoryStore) GetCandidateHistory(id string) (*CandidateHistory, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	history := &CandidateHistory{}
+	interviewIDs := make(map[string]bool)
+	for _, interview := range ms.interviews {
+		if interview.CandidateID != nil && *interview.CandidateID == id {
+			history.Interviews = append(history.Interviews, interview)
+			interviewIDs[interview.ID] = true
+		}
+	}
+	for _, session := range ms.chatSessions {
+		if interviewIDs[session.InterviewID] {
+			history.Sessions = append(history.Sessions, session)
+		}
+	}
+	for _, evaluation := range ms.evaluations {
+		if interviewIDs[evaluation.InterviewID] {
+			history.Evaluations = append(history.Evaluations, evaluation)
+		}
+	}
+	sort.Slice(history.Interviews, func(i, j int) bool {
+		return history.Interviews[i].CreatedAt.Before(history.Interviews[j].CreatedAt)
+	})
+	sort.Slice(history.Sessions, func(i, j int) bool {
+		return history.Sessions[i].StartedAt.Before(history.Sessions[j].StartedAt)
+	})
+	sort.Slice(history.Evaluations, func(i, j int) bool {
+		return history.Evaluations[i].CreatedAt.Before(history.Evaluations[j].CreatedAt)
+	})
+	return history, nil
+}
+
+// Position operations
+func (ms *MemoryStore) CreatePosition(position *Position) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	position.CreatedAt = time.Now()
+	position.UpdatedAt = position.CreatedAt
+	ms.positions[position.ID] = position
+	return nil
+}
+
+func (ms *MemoryStore) GetPosition(id string) (*Position, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	position, exists := ms.positions[id]
+	if !exists {
+		return nil, fmt.Errorf("position not found")
+	}
+	return position, nil
+}
+
+// ListPositions returns a page of the positions matching the filters, ordered by title, and the total count
+func (ms *MemoryStore) ListPositions(filters PositionFilters, limit, offset int) ([]*Position, int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	matched := []*Position{}
+	for _, position := range ms.positions {
+		if filters.Title != "" && !strings.Contains(strings.ToLower(position.Title), strings.ToLower(filters.Title)) {
+			continue
+		}
+		if filters.Department != "" && position.Department != filters.Department {
+			continue
+		}
+		if filters.CreatedBy != "" && position.CreatedBy != filters.CreatedBy {
+			continue
+		}
+		matched = append(matched, position)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Title != matched[j].Title {
+			return matched[i].Title < matched[j].Title
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	total := len(matched)
+	start := min(max(offset, 0), total)
+	end := min(start+limit, total)
+	return matched[start:end], total, nil
+}
+
+// UpdatePosition replaces a position
+func (ms *MemoryStore) UpdatePosition(position *Position) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.positions[position.ID]; !exists {
+		return fmt.Errorf("position not found")
+	}
+	position.UpdatedAt = time.Now()
+	ms.positions[position.ID] = position
+	return nil
+}
+
+// DeletePosition removes a position that no interview references
+func (ms *MemoryStore) DeletePosition(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.positions[id]; !exists {
+		return fmt.Errorf("position not found")
+	}
+	for _, interview := range ms.interviews {
+		if interview.PositionID != nil && *interview.PositionID == id {
+			return ErrPositionHasInterviews
+		}
+	}
+	delete(ms.positions, id)
+	return nil
+}
+
+// Invitation operations
+func (ms *MemoryStore) CreateInvitation(invitation *Invitation) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	invitation.CreatedAt = time.Now()
+	invitation.UpdatedAt = invitation.CreatedAt
+	ms.invitations[invitation.ID] = invitation
+	return nil
+}
+
+func (ms *MemoryStore) GetInvitation(id string) (*Invitation, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	invitation, exists := ms.invitations[id]
+	if !exists {
+		return nil, fmt.Errorf("invitation not found")
+	}
+	return invitation, nil
+}
+
+// ListInvitations returns an interview's invitations, newest first
+func (ms *MemoryStore) ListInvitations(interviewID string) ([]*Invitation, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	invitations := []*Invitation{}
+	for _, invitation := range ms.invitations {
+		if invitation.InterviewID == interviewID {
+			invitations = append(invitations, invitation)
+		}
+	}
+	sort.Slice(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt.After(invitations[j].CreatedAt)
+	})
+	return invitations, nil
+}
+
+// UseInvitation counts one use of an invitation, failing with ErrInvitationUnusable if it is
+// revoked, expired or has no uses left
+func (ms *MemoryStore) UseInvitation(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	invitation, err := ms.usableInvitation(id)
+	if err != nil {
+		return err
+	}
+	countInvitationUse(invitation)
+	return nil
+}
+
+// usableInvitation returns the invitation if it can be used; callers hold the lock
+func (ms *MemoryStore) usableInvitation(id string) (*Invitation, error) {
+	invitation, exists := ms.invitations[id]
+	if !exists {
+		return nil, fmt.Errorf("invitation not found")
+	}
+	if !invitation.Usable(time.Now()) {
+		return nil, ErrInvitationUnusable
+	}
+	return invitation, nil
+}
+
+// countInvitationUse counts one use of an invitation; callers hold the lock
+func countInvitationUse(invitation *Invitation) {
+	now := time.Now()
+	invitation.Uses++
+	invitation.LastUsedAt = &now
+	invitation.UpdatedAt = now
+}
+
+// RevokeInvitation stops an invitation from granting access; revoking it again is a no-op
+func (ms *MemoryStore) RevokeInvitation(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	invitation, exists := ms.invitations[id]
+	if !exists {
+		return fmt.Errorf("invitation not found")
+	}
+	if invitation.RevokedAt == nil {
+		now := time.Now()
+		invitation.RevokedAt = &now
+		invitation.UpdatedAt = now
+	}
+	return nil
+}
+
+// API key operations
+func (ms *MemoryStore) CreateAPIKey(key *APIKey) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	key.CreatedAt = time.Now()
+	key.UpdatedAt = key.CreatedAt
+	ms.apiKeys[key.ID] = key
+	return nil
+}
+
+func (ms *MemoryStore) GetAPIKey(id string) (*APIKey, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	key, exists := ms.apiKeys[id]
+	if !exists {
+		return nil, fmt.Errorf("api key not found")
+	}
+	return key, nil
+}
+
+// GetAPIKeyByHash finds the API key with the given key hash
+func (ms *MemoryStore) GetAPIKeyByHash(hash string) (*APIKey, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	for _, key := range ms.apiKeys {
+		if key.KeyHash == hash {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("api key not found")
+}
+
+// ListAPIKeys returns every API key, revoked ones included, newest first
+func (ms *MemoryStore) ListAPIKeys() ([]*APIKey, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	keys := make([]*APIKey, 0, len(ms.apiKeys))
+	for _, key := range ms.apiKeys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// TouchAPIKey records when an API key was last used
+func (ms *MemoryStore) TouchAPIKey(id string, usedAt time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	key, exists := ms.apiKeys[id]
+	if !exists {
+		return fmt.Errorf("api key not found")
+	}
+	key.LastUsedAt = &usedAt
+	return nil
+}
+
+// RevokeAPIKey stops an API key from authenticating; revoking it again is a no-op
+func (ms *MemoryStore) RevokeAPIKey(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	key, exists := ms.apiKeys[id]
+	if !exists {
+		return fmt.Errorf("api key not found")
+	}
+	if key.RevokedAt == nil {
+		now := time.Now()
+		key.RevokedAt = &now
+		key.UpdatedAt = now
+	}
+	return nil
+}
+
+// candidateTaken reports whether another candidate already uses the email or external ID; caller must hold the lock
+func (ms *MemoryStore) candidateTaken(email, externalID, excludeID string) bool {
+	for id, existing := range ms.candidates {
+		if id == excludeID {
+			continue
+		}
+		if (email != "" && existing.Email == email) || (externalID != "" && existing.ExternalID == externalID) {
+			return true
+		}
+	}
+	return false
+}
+
+// File operations
+func (ms *MemoryStore) GetFile(id string) (*File, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	file, exists := ms.files[id]
+	if !exists {
+		return nil, fmt.Errorf("file not found")
+	}
+	return file, nil
+}
+
+// ListInterviewFiles returns every file stored for an interview, oldest first
+func (ms *MemoryStore) ListInterviewFiles(interviewID string) ([]File, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	var files []File
+	for _, file := range ms.files {
+		if file.InterviewID != nil && *file.InterviewID == interviewID {
+			files = append(files, *file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].CreatedAt.Before(files[j].CreatedAt) })
+	return files, nil
+}
+
+// DeleteFile removes a file record
+func (ms *MemoryStore) DeleteFile(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delete(ms.files, id)
+	return nil
+}
+
+// AttachInterviewResume stores the file as its interview's resume, copying the extracted text onto
+// the interview and clearing the previous profile, and returns the resume it replaced, if any; the
+// replaced record is kept until its contents are removed
+func (ms *MemoryStore) AttachInterviewResume(file *File) (*File, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[*file.InterviewID]
+	if !exists {
+		return nil, fmt.Errorf("interview not found")
+	}
+	if ms.hasActiveSession(interview.ID) {
+		return nil, ErrInterviewSessionActive
+	}
+
+	file.CreatedAt = time.Now()
+	ms.files[file.ID] = file
+	replaced := ms.files[interview.ResumeFileID]
+
+	// Replace rather than mutate so readers holding the previous pointer see a consistent record
+	updated := *interview
+	updated.ResumeFileID = file.ID
+	updated.ResumeText = file.ExtractedText
+	updated.CandidateProfile = CandidateProfile{}
+	updated.UpdatedAt = time.Now()
+	ms.interviews[interview.ID] = &updated
+	return replaced, nil
+}
+
+// SetInterviewCandidateProfile stores the interview's structured candidate profile, failing with
+// ErrResumeReplaced if resumeFileID is set and is no longer the interview's resume
+func (ms *MemoryStore) SetInterviewCandidateProfile(interviewID, resumeFileID string, profile CandidateProfile) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[interviewID]
+	if !exists {
+		return fmt.Errorf("interview not found")
+	}
+	if resumeFileID != "" && interview.ResumeFileID != resumeFileID {
+		return ErrResumeReplaced
+	}
+
+	updated := *interview
+	updated.CandidateProfile = profile
+	updated.UpdatedAt = time.Now()
+	ms.interviews[interviewID] = &updated
+	return nil
+}
+
+// recordWebhookEvents adds events to the outbox along with the change they describe; callers hold the lock
+func (ms *MemoryStore) recordWebhookEvents(events []*WebhookEvent) {
+	now := time.Now()
+	for _, event := range events {
+		event.CreatedAt = now
+		ms.webhookEvents[event.ID] = event
+		ms.webhookOutbox = append(ms.webhookOutbox, event)
+	}
+}
+
+// Webhook operations
+func (ms *MemoryStore) CreateWebhookSubscription(subscription *WebhookSubscription) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = subscription.CreatedAt
+	ms.webhookSubscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (ms *MemoryStore) GetWebhookSubscription(id string) (*WebhookSubscription, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	subscription, exists := ms.webhookSubscriptions[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook subscription not found")
+	}
+	return subscription, nil
+}
+
+// GetWebhookSubscriptions returns all webhook subscriptions, oldest first
+func (ms *MemoryStore) GetWebhookSubscriptions() ([]*WebhookSubscription, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	subscriptions := make([]*WebhookSubscription, 0, len(ms.webhookSubscriptions))
+	for _, subscription := range ms.webhookSubscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+func (ms *MemoryStore) UpdateWebhookSubscription(subscription *WebhookSubscription) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.webhookSubscriptions[subscription.ID]; !exists {
+		return fmt.Errorf("webhook subscription not found")
+	}
+	subscription.UpdatedAt = time.Now()
+	ms.webhookSubscriptions[subscription.ID] = subscription
+	return nil
+}
+
+// DeleteWebhookSubscription removes a webhook subscription and its delivery log
+func (ms *MemoryStore) DeleteWebhookSubscription(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.webhookSubscriptions[id]; !exists {
+		return fmt.Errorf("webhook subscription not found")
+	}
+	for deliveryID, delivery := range ms.webhookDeliveries {
+		if delivery.SubscriptionID == id {
+			delete(ms.webhookDeliveries, deliveryID)
+		}
+	}
+	delete(ms.webhookSubscriptions, id)
+	return nil
+}
+
+func (ms *MemoryStore) GetWebhookEvent(id string) (*WebhookEvent, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	event, exists := ms.webhookEvents[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook event not found")
+	}
+	return event, nil
+}
+
+// DispatchWebhookEvents fans up to limit outbox events out into deliveries due at now, returning how many were created
+func (ms *MemoryStore) DispatchWebhookEvents(now time.Time, limit int) (int, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	events := ms.webhookOutbox[:min(limit, len(ms.webhookOutbox))]
+	subscriptions := make([]*WebhookSubscription, 0, len(ms.webhookSubscriptions))
+	for _, subscription := range ms.webhookSubscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	deliveries := newWebhookDeliveries(events, subscriptions, now)
+	for _, delivery := range deliveries {
+		ms.webhookDeliveries[delivery.ID] = delivery
+	}
+	for _, event := range events {
+		// Replace rather than mutate so readers holding the previous pointer see a consistent record
+		dispatched := *event
+		dispatched.DispatchedAt = &now
+		ms.webhookEvents[event.ID] = &dispatched
+	}
+	ms.webhookOutbox = ms.webhookOutbox[len(events):]
+	return len(deliveries), nil
+}
+
+func (ms *MemoryStore) GetWebhookDelivery(id string) (*WebhookDelivery, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	delivery, exists := ms.webhookDeliveries[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook delivery not found")
+	}
+	return delivery, nil
+}
+
+// ListWebhookDeliveries returns up to limit of a subscription's most recent deliveries matching the filters
+func (ms *MemoryStore) ListWebhookDeliveries(subscriptionID string, filters WebhookDeliveryFilters, limit int) ([]*WebhookDelivery, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	deliveries := []*WebhookDelivery{}
+	for _, delivery := range ms.webhookDeliveries {
+		if delivery.SubscriptionID != subscriptionID ||
+			(filters.Status != "" && delivery.Status != filters.Status) ||
+			(filters.EventType != "" && delivery.EventType != filters.EventType) {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// ClaimDueWebhookDeliveries starts an attempt on up to limit due pending deliveries, moving their
+// next attempt lease into the future
+func (ms *MemoryStore) ClaimDueWebhookDeliveries(now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	due := []*WebhookDelivery{}
+	for _, delivery := range ms.webhookDeliveries {
+		if delivery.Status == WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	// Deliveries queued together go out in the order of their events
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return ms.webhookEventTime(due[i].EventID).Before(ms.webhookEventTime(due[j].EventID))
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	claimed := make([]*WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		attempt := *delivery
+		attempt.Attempts++
+		attempt.NextAttemptAt = now.Add(lease)
+		attempt.UpdatedAt = now
+		ms.webhookDeliveries[attempt.ID] = &attempt
+		claimed = append(claimed, &attempt)
+	}
+	return claimed, nil
+}
+
+// webhookEventTime returns when an event was recorded, or the zero time if it is gone; callers hold the lock
+func (ms *MemoryStore) webhookEventTime(eventID string) time.Time {
+	if event, exists := ms.webhookEvents[eventID]; exists {
+		return event.CreatedAt
+	}
+	return time.Time{}
+}
+
+// CompleteWebhookDelivery records a successful attempt
+func (ms *MemoryStore) CompleteWebhookDelivery(id string, responseStatus int) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delivery, exists := ms.webhookDeliveries[id]
+	if !exists {
+		return fmt.Errorf("webhook delivery not found")
+	}
+	now := time.Now()
+	completed := *delivery
+	completed.Status = WebhookDeliverySucceeded
+	completed.ResponseStatus = responseStatus
+	completed.LastError = ""
+	completed.DeliveredAt = &now
+	completed.UpdatedAt = now
+	ms.webhookDeliveries[id] = &completed
+	return nil
+}
+
+// FailWebhookDelivery records a failed attempt, retrying at retryAt or giving up when retryAt is nil
+func (ms *MemoryStore) FailWebhookDelivery(id string, responseStatus int, lastError string, retryAt *time.Time) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	delivery, exists := ms.webhookDeliveries[id]
+	if !exists {
+		return fmt.Errorf("webhook delivery not found")
+	}
+	failed := *delivery
+	failed.Status = WebhookDeliveryFailed
+	failed.ResponseStatus = responseStatus
+	failed.LastError = lastError
+	failed.UpdatedAt = time.Now()
+	if retryAt != nil {
+		failed.Status = WebhookDeliveryPending
+		failed.NextAttemptAt = *retryAt
+	}
+	ms.webhookDeliveries[id] = &failed
+	return nil
+}
+
+// ReplayWebhookDelivery queues a new delivery of the same event to the same subscription
+func (ms *MemoryStore) ReplayWebhookDelivery(id string) (*WebhookDelivery, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	original, exists := ms.webhookDeliveries[id]
+	if !exists {
+		return nil, fmt.Errorf("webhook delivery not found")
 	}
-	return messages, nil
+	replay := newWebhookReplay(original, time.Now())
+	ms.webhookDeliveries[replay.ID] = replay
+	return replay, nil
 }
@@ -1,6 +1,7 @@
 package data_test
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -509,3 +510,783 @@ func TestMemoryStore_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestMemoryStore_QuestionBankOperations(t *testing.T) {
+	store := data.NewMemoryStore()
+
+	questions := []*data.Question{
+		{
+			ID:            "q-1",
+			Text:          "Explain goroutines and channels",
+			Translations:  data.StringMap{"zh-TW": "請解釋 goroutine 與 channel"},
+			Tags:          data.StringArray{"golang", "concurrency"},
+			InterviewType: "technical",
+			Difficulty:    "medium",
+			CreatedAt:     time.Now().Add(-2 * time.Hour),
+		},
+		{
+			ID:             "q-2",
+			Text:           "Tell me about a conflict with a coworker",
+			Tags:           data.StringArray{"teamwork"},
+			InterviewType:  "behavioral",
+			Difficulty:     "easy",
+			ExpectedAnswer: "Uses the STAR format",
+			CreatedAt:      time.Now().Add(-1 * time.Hour),
+		},
+	}
+	for _, q := range questions {
+		if err := store.CreateQuestion(q); err != nil {
+			t.Fatalf("CreateQuestion failed: %v", err)
+		}
+	}
+
+	// Filter by tag
+	result, err := store.GetQuestionsWithOptions(data.ListQuestionsOptions{Tags: []string{"golang"}})
+	if err != nil {
+		t.Fatalf("GetQuestionsWithOptions failed: %v", err)
+	}
+	if result.Total != 1 || result.Questions[0].ID != "q-1" {
+		t.Errorf("expected only q-1 for tag filter, got %d results", result.Total)
+	}
+
+	// Full-text search covers translations and expected answer notes
+	result, _ = store.GetQuestionsWithOptions(data.ListQuestionsOptions{Search: "goroutine 與"})
+	if result.Total != 1 || result.Questions[0].ID != "q-1" {
+		t.Errorf("expected translation search to match q-1, got %d results", result.Total)
+	}
+	result, _ = store.GetQuestionsWithOptions(data.ListQuestionsOptions{Search: "star"})
+	if result.Total != 1 || result.Questions[0].ID != "q-2" {
+		t.Errorf("expected answer-notes search to match q-2, got %d results", result.Total)
+	}
+
+	// Usage counts drive "usage" sorting
+	if err := store.IncrementQuestionUsage([]string{"q-1", "q-1"}); err != nil {
+		t.Fatalf("IncrementQuestionUsage failed: %v", err)
+	}
+	result, _ = store.GetQuestionsWithOptions(data.ListQuestionsOptions{SortBy: "usage"})
+	if result.Questions[0].ID != "q-1" || result.Questions[0].UsageCount != 1 {
+		t.Errorf("expected q-1 first with usage 1, got %s with usage %d", result.Questions[0].ID, result.Questions[0].UsageCount)
+	}
+
+	// Lookup by IDs preserves order and fails on unknown IDs
+	ordered, err := store.GetQuestionsByIDs([]string{"q-2", "q-1"})
+	if err != nil {
+		t.Fatalf("GetQuestionsByIDs failed: %v", err)
+	}
+	if ordered[0].ID != "q-2" || ordered[1].ID != "q-1" {
+		t.Error("expected GetQuestionsByIDs to preserve requested order")
+	}
+	if _, err := store.GetQuestionsByIDs([]string{"missing"}); err == nil {
+		t.Error("expected error for unknown question ID")
+	}
+
+	if got := ordered[1].TextFor("zh-TW"); got != "請解釋 goroutine 與 channel" {
+		t.Errorf("expected zh-TW translation, got %s", got)
+	}
+	if got := ordered[1].TextFor("en"); got != ordered[1].Text {
+		t.Errorf("expected fallback to default text, got %s", got)
+	}
+
+	if err := store.DeleteQuestion("q-2"); err != nil {
+		t.Fatalf("DeleteQuestion failed: %v", err)
+	}
+	if _, err := store.GetQuestion("q-2"); err == nil {
+		t.Error("expected error after deleting question")
+	}
+}
+
+func TestMemoryStore_InterviewTemplateOperations(t *testing.T) {
+	store := data.NewMemoryStore()
+
+	template := &data.InterviewTemplate{
+		ID:            "tpl-1",
+		Name:          "Backend Engineer L4",
+		InterviewType: "technical",
+		Questions:     []string{"Q1"},
+		EndPolicy:     data.EndPolicy{MaxUserMessages: 6},
+	}
+	if err := store.CreateInterviewTemplate(template); err != nil {
+		t.Fatalf("CreateInterviewTemplate failed: %v", err)
+	}
+
+	// Names are unique
+	duplicate := &data.InterviewTemplate{ID: "tpl-2", Name: "Backend Engineer L4", InterviewType: "technical"}
+	if err := store.CreateInterviewTemplate(duplicate); err != data.ErrDuplicateTemplateName {
+		t.Errorf("expected ErrDuplicateTemplateName, got %v", err)
+	}
+
+	other := &data.InterviewTemplate{ID: "tpl-3", Name: "Arch Review", InterviewType: "technical"}
+	if err := store.CreateInterviewTemplate(other); err != nil {
+		t.Fatalf("CreateInterviewTemplate failed: %v", err)
+	}
+
+	templates, err := store.GetInterviewTemplates()
+	if err != nil {
+		t.Fatalf("GetInterviewTemplates failed: %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "Arch Review" {
+		t.Errorf("expected templates sorted by name, got %d templates", len(templates))
+	}
+
+	// Renaming onto an existing name is rejected
+	renamed := *other
+	renamed.Name = "Backend Engineer L4"
+	if err := store.UpdateInterviewTemplate(&renamed); err != data.ErrDuplicateTemplateName {
+		t.Errorf("expected ErrDuplicateTemplateName on rename, got %v", err)
+	}
+
+	if err := store.DeleteInterviewTemplate("tpl-1"); err != nil {
+		t.Fatalf("DeleteInterviewTemplate failed: %v", err)
+	}
+	if _, err := store.GetInterviewTemplate("tpl-1"); err == nil {
+		t.Error("expected error after deleting template")
+	}
+}
+
+func TestMemoryStore_EvaluationReview(t *testing.T) {
+	store := data.NewMemoryStore()
+
+	evaluation := &data.Evaluation{ID: "eval-1", InterviewID: "interview-1", Score: 0.7}
+	if err := store.CreateEvaluation(evaluation); err != nil {
+		t.Fatalf("CreateEvaluation failed: %v", err)
+	}
+
+	humanScore := 0.6
+	reviewed := *evaluation
+	reviewed.HumanScore = &humanScore
+	reviewed.Decision = data.DecisionHold
+	for i := 0; i < 2; i++ {
+		revision := &data.EvaluationRevision{ID: fmt.Sprintf("rev-%d", i), EvaluationID: "eval-1", Source: data.RevisionSourceHuman}
+		if err := store.ReviewEvaluation(&reviewed, revision); err != nil {
+			t.Fatalf("ReviewEvaluation failed: %v", err)
+		}
+		if revision.Revision != i+1 {
+			t.Errorf("expected revision %d, got %d", i+1, revision.Revision)
+		}
+	}
+
+	stored, _ := store.GetEvaluation("eval-1")
+	if stored.FinalScore() != 0.6 || stored.Score != 0.7 || stored.Decision != data.DecisionHold {
+		t.Errorf("expected human review stored alongside AI score, got %+v", stored)
+	}
+
+	revisions, _ := store.GetEvaluationRevisions("eval-1")
+	if len(revisions) != 2 {
+		t.Errorf("expected 2 revisions, got %d", len(revisions))
+	}
+
+	missing := &data.Evaluation{ID: "missing"}
+	if err := store.ReviewEvaluation(missing, &data.EvaluationRevision{}); err == nil {
+		t.Error("expected error reviewing a missing evaluation")
+	}
+}
+
+func TestMemoryStore_ListEvaluationsAndStatistics(t *testing.T) {
+	store := data.NewMemoryStore()
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	needsReview := true
+
+	for i, score := range []float64{0.95, 0.55, 0.82, 0.3, 0.82} {
+		evaluation := &data.Evaluation{
+			ID:          fmt.Sprintf("eval-%d", i),
+			InterviewID: fmt.Sprintf("interview-%d", i%2),
+			Score:       score,
+			NeedsReview: i == 1,
+			CreatedAt:   base.Add(time.Duration(i) * time.Hour),
+		}
+		if i == 0 {
+			evaluation.Decision = data.DecisionAdvance
+		}
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+	}
+
+	// Default order is newest first
+	result, _ := store.ListEvaluations(data.ListEvaluationsOptions{Limit: 2})
+	if result.Total != 5 || len(result.Evaluations) != 2 || result.Evaluations[0].ID != "eval-4" {
+		t.Errorf("unexpected default page: total=%d first=%v", result.Total, result.Evaluations)
+	}
+
+	// Score sort breaks ties by ID
+	result, _ = store.ListEvaluations(data.ListEvaluationsOptions{Limit: 10, SortBy: data.EvaluationSortScore, SortOrder: data.SortOrderAsc})
+	var ids []string
+	for _, evaluation := range result.Evaluations {
+		ids = append(ids, evaluation.ID)
+	}
+	if fmt.Sprint(ids) != "[eval-3 eval-1 eval-2 eval-4 eval-0]" {
+		t.Errorf("unexpected score order: %v", ids)
+	}
+
+	minScore, maxScore := 0.5, 0.9
+	result, _ = store.ListEvaluations(data.ListEvaluationsOptions{Limit: 10, Filters: data.EvaluationFilters{MinScore: &minScore, MaxScore: &maxScore}})
+	if result.Total != 3 {
+		t.Errorf("expected 3 evaluations in score range, got %d", result.Total)
+	}
+	result, _ = store.ListEvaluations(data.ListEvaluationsOptions{Limit: 10, Filters: data.EvaluationFilters{NeedsReview: &needsReview}})
+	if result.Total != 1 || result.Evaluations[0].ID != "eval-1" {
+		t.Errorf("expected only eval-1 to need review, got %d", result.Total)
+	}
+	result, _ = store.ListEvaluations(data.ListEvaluationsOptions{Limit: 10, Filters: data.EvaluationFilters{Decision: data.DecisionNone}})
+	if result.Total != 4 {
+		t.Errorf("expected 4 undecided evaluations, got %d", result.Total)
+	}
+
+	stats, _ := store.GetEvaluationStatistics(data.EvaluationFilters{InterviewID: "interview-0"})
+	if stats.TotalEvaluations != 3 || stats.MinScore != 0.82 || stats.MaxScore != 0.95 {
+		t.Errorf("unexpected statistics: %+v", stats)
+	}
+	if stats.ScoreDistribution["90-100"] != 1 || stats.ScoreDistribution["80-89"] != 2 {
+		t.Errorf("unexpected score distribution: %v", stats.ScoreDistribution)
+	}
+
+	empty, _ := store.GetEvaluationStatistics(data.EvaluationFilters{InterviewID: "missing"})
+	if empty.TotalEvaluations != 0 || empty.AverageScore != 0 {
+		t.Errorf("expected empty statistics, got %+v", empty)
+	}
+}
+
+func TestMemoryStore_UpdateAndDeleteInterview(t *testing.T) {
+	store := data.NewMemoryStore()
+	interview := &data.Interview{ID: "interview-1", CandidateName: "Before"}
+	if err := store.CreateInterview(interview); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	session := &data.ChatSession{ID: "session-1", InterviewID: "interview-1", Status: "active"}
+	if err := store.CreateChatSession(session); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	_ = store.AddChatMessage(&data.ChatMessage{ID: "message-1", SessionID: "session-1", Type: "user", Content: "hi"})
+	_ = store.CreateEvaluation(&data.Evaluation{ID: "eval-1", InterviewID: "interview-1"})
+	_ = store.CreateEvaluationRating(&data.EvaluationRating{ID: "rating-1", EvaluationID: "eval-1", Rater: "alice"})
+
+	edited := *interview
+	edited.CandidateName = "After"
+	if err := store.UpdateInterview(&edited); !errors.Is(err, data.ErrInterviewSessionActive) {
+		t.Errorf("expected ErrInterviewSessionActive on edit, got %v", err)
+	}
+	if err := store.DeleteInterview("interview-1", true); !errors.Is(err, data.ErrInterviewSessionActive) {
+		t.Errorf("expected ErrInterviewSessionActive on delete, got %v", err)
+	}
+
+	session.Status = "completed"
+	if err := store.UpdateChatSession(session); err != nil {
+		t.Fatalf("UpdateChatSession failed: %v", err)
+	}
+	if err := store.UpdateInterview(&edited); err != nil {
+		t.Fatalf("UpdateInterview failed: %v", err)
+	}
+	if stored, _ := store.GetInterview("interview-1"); stored.CandidateName != "After" {
+		t.Errorf("expected updated candidate name, got %s", stored.CandidateName)
+	}
+
+	if err := store.DeleteInterview("interview-1", false); !errors.Is(err, data.ErrInterviewHasDependents) {
+		t.Errorf("expected ErrInterviewHasDependents, got %v", err)
+	}
+	if err := store.DeleteInterview("interview-1", true); err != nil {
+		t.Fatalf("DeleteInterview failed: %v", err)
+	}
+	if _, err := store.GetChatMessage("message-1"); err == nil {
+		t.Error("expected chat messages to be deleted")
+	}
+	if ratings, _ := store.GetEvaluationRatings([]string{"eval-1"}); len(ratings) != 0 {
+		t.Error("expected evaluation ratings to be deleted")
+	}
+	if _, err := store.GetEvaluation("eval-1"); err == nil {
+		t.Error("expected evaluation to be deleted")
+	}
+	if err := store.DeleteInterview("interview-1", true); err == nil {
+		t.Error("expected error deleting a missing interview")
+	}
+}
+
+func TestMemoryStore_CompleteChatSession(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-1", Status: "paused"})
+	job := &data.Job{ID: "job-1", Type: data.JobTypeSessionEvaluation, Status: data.JobStatusPending}
+
+	if err := store.CompleteChatSession("session-1", time.Now(), job); err != nil {
+		t.Fatalf("CompleteChatSession failed: %v", err)
+	}
+	session, _ := store.GetChatSession("session-1")
+	if session.Status != "completed" || session.EndedAt == nil {
+		t.Errorf("expected a completed session, got %+v", session)
+	}
+	if _, err := store.GetJob("job-1"); err != nil {
+		t.Errorf("expected the evaluation job queued with the session, got %v", err)
+	}
+
+	// An ended session is left alone and queues nothing
+	err := store.CompleteChatSession("session-1", time.Now(), &data.Job{ID: "job-2"})
+	if !errors.Is(err, data.ErrChatSessionEnded) {
+		t.Errorf("expected ErrChatSessionEnded, got %v", err)
+	}
+	if _, err := store.GetJob("job-2"); err == nil {
+		t.Error("expected no job queued for an ended session")
+	}
+}
+
+func TestMemoryStore_ReplaceChatMessages(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-1", Status: "active"})
+	base := time.Now()
+	for i, id := range []string{"ai-1", "user-1", "ai-2"} {
+		msgType := "ai"
+		if id == "user-1" {
+			msgType = "user"
+		}
+		_ = store.AddChatMessage(&data.ChatMessage{ID: id, SessionID: "session-1", Type: msgType, Timestamp: base.Add(time.Duration(i) * time.Second)})
+	}
+
+	// Only the latest visible messages can be replaced
+	err := store.ReplaceChatMessages("session-1", map[string]*data.ChatMessage{"user-1": {ID: "user-2", Type: "user"}})
+	if !errors.Is(err, data.ErrStaleChatMessage) {
+		t.Errorf("expected ErrStaleChatMessage, got %v", err)
+	}
+
+	err = store.ReplaceChatMessages("session-1", map[string]*data.ChatMessage{
+		"user-1": {ID: "user-2", Type: "user", Timestamp: base.Add(3 * time.Second)},
+		"ai-2":   {ID: "ai-3", Type: "ai", Timestamp: base.Add(4 * time.Second)},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceChatMessages failed: %v", err)
+	}
+
+	visible, _ := store.GetChatMessages("session-1")
+	if len(visible) != 3 || visible[1].ID != "user-2" || visible[2].ID != "ai-3" {
+		t.Errorf("expected ai-1, user-2, ai-3, got %+v", visible)
+	}
+	history, _ := store.GetChatMessageHistory("session-1")
+	if len(history) != 5 || history[1].SupersededBy != "user-2" || history[2].SupersededBy != "ai-3" {
+		t.Errorf("expected superseded revisions kept in history, got %+v", history)
+	}
+
+	// A superseded message cannot be replaced again
+	err = store.ReplaceChatMessages("session-1", map[string]*data.ChatMessage{"ai-2": {ID: "ai-4", Type: "ai"}})
+	if !errors.Is(err, data.ErrStaleChatMessage) {
+		t.Errorf("expected ErrStaleChatMessage for a superseded message, got %v", err)
+	}
+
+	// Appended messages are only added along with their replacements
+	appended := &data.ChatMessage{ID: "ai-5", Type: "ai", Timestamp: base.Add(6 * time.Second)}
+	err = store.ReplaceChatMessages("session-1", map[string]*data.ChatMessage{"user-1": {ID: "user-3", Type: "user"}}, appended)
+	if !errors.Is(err, data.ErrStaleChatMessage) {
+		t.Errorf("expected ErrStaleChatMessage, got %v", err)
+	}
+	err = store.ReplaceChatMessages("session-1", map[string]*data.ChatMessage{
+		"ai-3": {ID: "ai-4", Type: "ai", Timestamp: base.Add(5 * time.Second)},
+	}, appended)
+	if err != nil {
+		t.Fatalf("ReplaceChatMessages with an appended message failed: %v", err)
+	}
+	visible, _ = store.GetChatMessages("session-1")
+	if len(visible) != 4 || visible[2].ID != "ai-4" || visible[3].ID != "ai-5" || visible[3].SessionID != "session-1" {
+		t.Errorf("expected ai-1, user-2, ai-4, ai-5, got %+v", visible)
+	}
+}
+
+func TestMemoryStore_JobQueue(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	_ = store.CreateJob(&data.Job{ID: "later", Type: data.JobTypeSessionEvaluation, Status: data.JobStatusPending, MaxAttempts: 2, RunAt: now.Add(time.Minute)})
+	_ = store.CreateJob(&data.Job{ID: "due", Type: data.JobTypeSessionEvaluation, Status: data.JobStatusPending, MaxAttempts: 3, RunAt: now.Add(-time.Second)})
+
+	// Only due jobs are claimed, and each only once
+	job, err := store.ClaimNextJob(now)
+	if err != nil || job == nil || job.ID != "due" || job.Status != data.JobStatusRunning || job.Attempts != 1 {
+		t.Fatalf("expected to claim the due job, got %+v (%v)", job, err)
+	}
+	if next, _ := store.ClaimNextJob(now); next != nil {
+		t.Fatalf("expected no other due job, got %+v", next)
+	}
+
+	// A failed attempt with a retry time goes back on the queue, and only once
+	retryAt := now.Add(time.Second)
+	if err := store.FailJob("due", 1, "boom", &retryAt); err != nil {
+		t.Fatalf("FailJob failed: %v", err)
+	}
+	if stored, _ := store.GetJob("due"); stored.Status != data.JobStatusPending || stored.LastError != "boom" {
+		t.Errorf("expected pending job with its last error, got %+v", stored)
+	}
+	if err := store.FailJob("due", 1, "boom", &retryAt); !errors.Is(err, data.ErrJobAttemptSuperseded) {
+		t.Errorf("expected ErrJobAttemptSuperseded for a finished attempt, got %v", err)
+	}
+	if _, err := store.RetryJob("due"); !errors.Is(err, data.ErrJobNotDead) {
+		t.Errorf("expected ErrJobNotDead, got %v", err)
+	}
+
+	// Jobs left running past the timeout are requeued, and the timed-out attempt can no longer finish
+	job, _ = store.ClaimNextJob(retryAt)
+	if requeued, dead, _ := store.RequeueStaleJobs(retryAt); requeued+dead != 0 {
+		t.Errorf("expected a job started at the cutoff to be kept running, got %d requeued and %d dead", requeued, dead)
+	}
+	if requeued, dead, _ := store.RequeueStaleJobs(retryAt.Add(time.Minute)); requeued != 1 || dead != 0 {
+		t.Errorf("expected 1 stale job requeued, got %d requeued and %d dead", requeued, dead)
+	}
+	job, _ = store.ClaimNextJob(retryAt.Add(time.Minute))
+	if job.Attempts != 3 {
+		t.Errorf("expected interrupted attempts to count, got %d", job.Attempts)
+	}
+	if err := store.CompleteJob("due", 2, "evaluation-late"); !errors.Is(err, data.ErrJobAttemptSuperseded) {
+		t.Errorf("expected the timed-out attempt unable to complete, got %v", err)
+	}
+
+	// A stale job on its last attempt is dead-lettered rather than run again
+	if requeued, dead, _ := store.RequeueStaleJobs(retryAt.Add(2 * time.Minute)); requeued != 0 || dead != 1 {
+		t.Errorf("expected the exhausted job dead-lettered, got %d requeued and %d dead", requeued, dead)
+	}
+	if err := store.FailJob("due", 3, "boom again", nil); !errors.Is(err, data.ErrJobAttemptSuperseded) {
+		t.Errorf("expected a dead-lettered attempt unable to fail again, got %v", err)
+	}
+	dead, _ := store.ListJobs(data.JobFilters{Status: data.JobStatusDead}, 10)
+	if len(dead) != 1 || dead[0].ID != "due" || dead[0].FinishedAt == nil || dead[0].LastError == "" {
+		t.Fatalf("expected the job in the dead-letter list, got %+v", dead)
+	}
+
+	// Only the claimed attempt completes a job
+	job, _ = store.ClaimNextJob(now.Add(time.Minute))
+	if err := store.CompleteJob("later", job.Attempts, "evaluation-1"); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+	if stored, _ := store.GetJob("later"); stored.Status != data.JobStatusSucceeded || stored.ResultID != "evaluation-1" {
+		t.Errorf("expected succeeded job with its result, got %+v", stored)
+	}
+
+	// A retry starts the attempts over
+	retried, err := store.RetryJob("due")
+	if err != nil || retried.Status != data.JobStatusPending || retried.Attempts != 0 {
+		t.Errorf("expected retried job pending with no attempts, got %+v (%v)", retried, err)
+	}
+}
+
+func TestMemoryStore_JobEvaluation(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	_ = store.CreateJob(&data.Job{ID: "job-1", Type: data.JobTypeAnswerEvaluation, Status: data.JobStatusPending, MaxAttempts: 2, RunAt: now})
+	job, _ := store.ClaimNextJob(now)
+
+	if stored, err := store.GetJobEvaluation(job.ID); err != nil || stored != nil {
+		t.Fatalf("expected no evaluation before the job stores one, got %+v (%v)", stored, err)
+	}
+
+	// Only the running attempt stores its evaluation
+	late := &data.Evaluation{ID: "eval-late", InterviewID: "interview-1", JobID: job.ID}
+	if err := store.CreateJobEvaluation(late, job.Attempts+1); !errors.Is(err, data.ErrJobAttemptSuperseded) {
+		t.Errorf("expected ErrJobAttemptSuperseded for another attempt, got %v", err)
+	}
+	evaluation := &data.Evaluation{ID: "eval-1", InterviewID: "interview-1", JobID: job.ID}
+	if err := store.CreateJobEvaluation(evaluation, job.Attempts); err != nil {
+		t.Fatalf("CreateJobEvaluation failed: %v", err)
+	}
+	if stored, err := store.GetJobEvaluation(job.ID); err != nil || stored == nil || stored.ID != "eval-1" {
+		t.Errorf("expected the job's evaluation, got %+v (%v)", stored, err)
+	}
+	if _, err := store.GetEvaluation("eval-late"); err == nil {
+		t.Error("expected the superseded attempt's evaluation not stored")
+	}
+}
+
+func TestMemoryStore_WebhookOutbox(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateWebhookSubscription(&data.WebhookSubscription{ID: "all", Active: true, EventTypes: data.StringArray{data.WebhookEventInterviewCreated}})
+	_ = store.CreateWebhookSubscription(&data.WebhookSubscription{ID: "other", Active: true, EventTypes: data.StringArray{data.WebhookEventEvaluationReady}})
+
+	// Events are recorded with the change and fanned out only to subscriptions that want them
+	event := &data.WebhookEvent{ID: "event", Type: data.WebhookEventInterviewCreated, Payload: "{}"}
+	if err := store.CreateInterview(&data.Interview{ID: "interview", CandidateName: "A"}, event); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	if created, _ := store.DispatchWebhookEvents(time.Now(), 10); created != 1 {
+		t.Fatalf("expected 1 delivery, got %d", created)
+	}
+	if created, _ := store.DispatchWebhookEvents(time.Now(), 10); created != 0 {
+		t.Errorf("expected events dispatched only once, got %d more deliveries", created)
+	}
+	if stored, _ := store.GetWebhookEvent("event"); stored.DispatchedAt == nil {
+		t.Error("expected the event marked dispatched")
+	}
+
+	// A claimed delivery is leased so it is not claimed again until the lease runs out
+	now := time.Now()
+	claimed, _ := store.ClaimDueWebhookDeliveries(now, time.Minute, 10)
+	if len(claimed) != 1 || claimed[0].SubscriptionID != "all" || claimed[0].Attempts != 1 {
+		t.Fatalf("expected to claim the delivery to the subscribed webhook, got %+v", claimed)
+	}
+	if again, _ := store.ClaimDueWebhookDeliveries(now, time.Minute, 10); len(again) != 0 {
+		t.Errorf("expected a leased delivery not to be claimed again, got %+v", again)
+	}
+	if expired, _ := store.ClaimDueWebhookDeliveries(now.Add(2*time.Minute), time.Minute, 10); len(expired) != 1 || expired[0].Attempts != 2 {
+		t.Errorf("expected the delivery reclaimed once its lease expired, got %+v", expired)
+	}
+}
+
+func TestMemoryStore_CandidateProfile(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateInterview(&data.Interview{ID: "interview", CandidateName: "A"})
+	interviewID := "interview"
+	if _, err := store.AttachInterviewResume(&data.File{ID: "resume-1", InterviewID: &interviewID, ExtractedText: "A"}); err != nil {
+		t.Fatalf("AttachInterviewResume failed: %v", err)
+	}
+
+	profile := data.CandidateProfile{
+		Skills:      []string{"Go"},
+		WorkHistory: []data.WorkExperience{{Company: "Acme", StartDate: "2020", EndDate: "present"}},
+		Source:      data.ProfileSourceAI,
+	}
+	if err := store.SetInterviewCandidateProfile("interview", "resume-1", profile); err != nil {
+		t.Fatalf("SetInterviewCandidateProfile failed: %v", err)
+	}
+	if interview, _ := store.GetInterview("interview"); interview.CandidateProfile.IsEmpty() {
+		t.Fatal("expected the profile stored")
+	}
+
+	// A new resume clears the profile, and a profile parsed from the old one is rejected
+	if _, err := store.AttachInterviewResume(&data.File{ID: "resume-2", InterviewID: &interviewID, ExtractedText: "B"}); err != nil {
+		t.Fatalf("AttachInterviewResume failed: %v", err)
+	}
+	if interview, _ := store.GetInterview("interview"); !interview.CandidateProfile.IsEmpty() {
+		t.Errorf("expected the profile cleared with the resume, got %+v", interview.CandidateProfile)
+	}
+	// The replaced record is kept until the caller has removed its contents
+	if files, _ := store.ListInterviewFiles("interview"); len(files) != 2 {
+		t.Errorf("expected both resumes listed before the replaced one is deleted, got %d", len(files))
+	}
+	_ = store.DeleteFile("resume-1")
+	if files, _ := store.ListInterviewFiles("interview"); len(files) != 1 || files[0].ID != "resume-2" {
+		t.Errorf("expected only the current resume left, got %+v", files)
+	}
+	if err := store.SetInterviewCandidateProfile("interview", "resume-1", profile); !errors.Is(err, data.ErrResumeReplaced) {
+		t.Errorf("expected ErrResumeReplaced, got %v", err)
+	}
+	if err := store.SetInterviewCandidateProfile("missing", "", profile); err == nil {
+		t.Error("expected an error for a missing interview")
+	}
+}
+
+func TestCandidateProfile_Validate(t *testing.T) {
+	valid := data.CandidateProfile{
+		YearsOfExperience: 5,
+		Skills:            []string{"Go"},
+		WorkHistory:       []data.WorkExperience{{Company: "Acme", StartDate: "2019-03", EndDate: "2021"}},
+		Education:         []data.Education{{Institution: "State University", GraduationYear: 2018}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected a valid profile, got %v", err)
+	}
+
+	invalid := map[string]func(p *data.CandidateProfile){
+		"negative experience": func(p *data.CandidateProfile) { p.YearsOfExperience = -1 },
+		"blank skill":         func(p *data.CandidateProfile) { p.Skills = []string{""} },
+		"missing company":     func(p *data.CandidateProfile) { p.WorkHistory[0].Company = "" },
+		"malformed date":      func(p *data.CandidateProfile) { p.WorkHistory[0].StartDate = "March 2019" },
+		"end before start":    func(p *data.CandidateProfile) { p.WorkHistory[0].EndDate = "2018-12" },
+		"present start":       func(p *data.CandidateProfile) { p.WorkHistory[0].StartDate = "present" },
+		"missing institution": func(p *data.CandidateProfile) { p.Education[0].Institution = "" },
+		"graduation year":     func(p *data.CandidateProfile) { p.Education[0].GraduationYear = 18 },
+		"unknown source":      func(p *data.CandidateProfile) { p.Source = "import" },
+	}
+	for name, mutate := range invalid {
+		profile := valid
+		profile.WorkHistory = append([]data.WorkExperience(nil), valid.WorkHistory...)
+		profile.Education = append([]data.Education(nil), valid.Education...)
+		mutate(&profile)
+		if err := profile.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestMemoryStore_Candidates(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateCandidate(&data.Candidate{ID: "jane", Name: "Jane", Email: "jane@example.com"}); err != nil {
+		t.Fatalf("CreateCandidate failed: %v", err)
+	}
+	if err := store.CreateCandidate(&data.Candidate{ID: "other", Name: "Other", Email: "jane@example.com"}); !errors.Is(err, data.ErrDuplicateCandidate) {
+		t.Errorf("expected ErrDuplicateCandidate, got %v", err)
+	}
+	if err := store.CreateCandidate(&data.Candidate{ID: "alex", Name: "Alex", ExternalID: "ats-2"}); err != nil {
+		t.Fatalf("CreateCandidate failed: %v", err)
+	}
+	if err := store.UpdateCandidate(&data.Candidate{ID: "alex", Name: "Alex", Email: "jane@example.com"}); !errors.Is(err, data.ErrDuplicateCandidate) {
+		t.Errorf("expected ErrDuplicateCandidate on update, got %v", err)
+	}
+
+	candidates, total, _ := store.ListCandidates(data.CandidateFilters{}, 1, 0)
+	if total != 2 || len(candidates) != 1 || candidates[0].ID != "alex" {
+		t.Errorf("expected the first page ordered by name, got %d candidates of %d", len(candidates), total)
+	}
+
+	candidateID := "jane"
+	_ = store.CreateInterview(&data.Interview{ID: "interview", CandidateName: "Jane", CandidateID: &candidateID})
+	_ = store.CreateChatSession(&data.ChatSession{ID: "session", InterviewID: "interview"})
+	_ = store.CreateInterview(&data.Interview{ID: "unrelated", CandidateName: "Someone"})
+	history, err := store.GetCandidateHistory("jane")
+	if err != nil || len(history.Interviews) != 1 || len(history.Sessions) != 1 {
+		t.Fatalf("expected the candidate's interview and session, got %+v (%v)", history, err)
+	}
+
+	if err := store.DeleteCandidate("jane"); !errors.Is(err, data.ErrCandidateHasInterviews) {
+		t.Errorf("expected ErrCandidateHasInterviews, got %v", err)
+	}
+	if err := store.DeleteCandidate("alex"); err != nil {
+		t.Errorf("DeleteCandidate failed: %v", err)
+	}
+}
+
+func TestMemoryStore_Positions(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreatePosition(&data.Position{ID: "backend", Title: "Backend Engineer"})
+	_ = store.CreatePosition(&data.Position{ID: "sales", Title: "Account Manager", Department: "Sales"})
+
+	positions, total, _ := store.ListPositions(data.PositionFilters{Title: "engineer"}, 10, 0)
+	if total != 1 || positions[0].ID != "backend" {
+		t.Errorf("expected the position found by title, got %d", total)
+	}
+
+	positionID := "backend"
+	_ = store.CreateInterview(&data.Interview{ID: "with-position", CandidateName: "A", PositionID: &positionID})
+	_ = store.CreateInterview(&data.Interview{ID: "without-position", CandidateName: "B"})
+	_ = store.CreateEvaluation(&data.Evaluation{ID: "e1", InterviewID: "with-position", Score: 0.6})
+	_ = store.CreateEvaluation(&data.Evaluation{ID: "e2", InterviewID: "with-position", Score: 0.9})
+	_ = store.CreateEvaluation(&data.Evaluation{ID: "e3", InterviewID: "without-position", Score: 0.3})
+
+	groups, err := store.GetEvaluationStatisticsByPosition(data.EvaluationFilters{})
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("expected one position group, got %v (%v)", groups, err)
+	}
+	if stats := groups["backend"]; stats.TotalEvaluations != 2 || stats.MinScore != 0.6 || stats.MaxScore != 0.9 || stats.AverageScore != 0.75 {
+		t.Errorf("unexpected position statistics: %+v", stats)
+	}
+	if stats, _ := store.GetEvaluationStatistics(data.EvaluationFilters{PositionID: "backend"}); stats.TotalEvaluations != 2 {
+		t.Errorf("expected evaluations filtered by position, got %d", stats.TotalEvaluations)
+	}
+
+	if err := store.DeletePosition("backend"); !errors.Is(err, data.ErrPositionHasInterviews) {
+		t.Errorf("expected ErrPositionHasInterviews, got %v", err)
+	}
+	if err := store.DeletePosition("sales"); err != nil {
+		t.Errorf("DeletePosition failed: %v", err)
+	}
+}
+
+func TestMemoryStore_CreateInterviewBatch(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateCandidate(&data.Candidate{ID: "jane", Name: "Jane", Email: "jane@example.com"})
+
+	alexID := "alex"
+	interviews := []*data.Interview{{ID: "i1", CandidateName: "Alex", CandidateID: &alexID}, {ID: "i2", CandidateName: "Sam"}}
+	taken := []*data.Candidate{{ID: "alex", Name: "Alex", Email: "alex@example.com"}, {ID: "dup", Name: "Dup", Email: "jane@example.com"}}
+	if err := store.CreateInterviewBatch(taken, interviews, nil); !errors.Is(err, data.ErrDuplicateCandidate) {
+		t.Errorf("expected ErrDuplicateCandidate, got %v", err)
+	}
+	repeated := []*data.Candidate{{ID: "alex", Name: "Alex", Email: "alex@example.com"}, {ID: "alex2", Name: "Alex", Email: "alex@example.com"}}
+	if err := store.CreateInterviewBatch(repeated, interviews, nil); !errors.Is(err, data.ErrDuplicateCandidate) {
+		t.Errorf("expected ErrDuplicateCandidate for a repeated email, got %v", err)
+	}
+	if _, err := store.GetInterview("i1"); err == nil {
+		t.Fatal("expected nothing created from a rejected batch")
+	}
+
+	if err := store.CreateInterviewBatch([]*data.Candidate{{ID: "alex", Name: "Alex", Email: "alex@example.com"}}, interviews, nil); err != nil {
+		t.Fatalf("CreateInterviewBatch failed: %v", err)
+	}
+	if candidate, err := store.GetCandidate("alex"); err != nil || candidate.CreatedAt.IsZero() {
+		t.Errorf("expected the candidate created, got %+v (%v)", candidate, err)
+	}
+	if _, err := store.GetInterview("i2"); err != nil {
+		t.Errorf("expected every interview created, got %v", err)
+	}
+}
+
+func TestMemoryStore_Invitations(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateInterview(&data.Interview{ID: "interview", CandidateName: "Jane"})
+	_ = store.CreateInvitation(&data.Invitation{ID: "invite", InterviewID: "interview", ExpiresAt: time.Now().Add(time.Hour), MaxUses: 2})
+	_ = store.CreateInvitation(&data.Invitation{ID: "expired", InterviewID: "interview", ExpiresAt: time.Now().Add(-time.Hour), MaxUses: 1})
+
+	for i := 0; i < 2; i++ {
+		if err := store.UseInvitation("invite"); err != nil {
+			t.Fatalf("UseInvitation %d failed: %v", i+1, err)
+		}
+	}
+	if err := store.UseInvitation("invite"); !errors.Is(err, data.ErrInvitationUnusable) {
+		t.Errorf("expected ErrInvitationUnusable once the uses are spent, got %v", err)
+	}
+	if err := store.UseInvitation("expired"); !errors.Is(err, data.ErrInvitationUnusable) {
+		t.Errorf("expected ErrInvitationUnusable for an expired invitation, got %v", err)
+	}
+	if err := store.RevokeInvitation("invite"); err != nil {
+		t.Fatalf("RevokeInvitation failed: %v", err)
+	}
+	if invitation, _ := store.GetInvitation("invite"); invitation.RevokedAt == nil || invitation.Uses != 2 || invitation.LastUsedAt == nil {
+		t.Errorf("expected the invitation revoked with its uses recorded, got %+v", invitation)
+	}
+
+	if err := store.DeleteInterview("interview", false); err != nil {
+		t.Fatalf("DeleteInterview failed: %v", err)
+	}
+	if invitations, _ := store.ListInvitations("interview"); len(invitations) != 0 {
+		t.Errorf("expected invitations deleted with their interview, got %d", len(invitations))
+	}
+}
+
+func TestMemoryStore_APIKeys(t *testing.T) {
+	store := data.NewMemoryStore()
+	_ = store.CreateAPIKey(&data.APIKey{ID: "key", Name: "ATS", Prefix: "aik_12345678", KeyHash: "hash"})
+
+	key, err := store.GetAPIKeyByHash("hash")
+	if err != nil || key.ID != "key" {
+		t.Fatalf("expected the key found by hash, got %+v (%v)", key, err)
+	}
+	if _, err := store.GetAPIKeyByHash("other"); err == nil {
+		t.Error("expected an unknown hash not found")
+	}
+
+	usedAt := time.Now()
+	if err := store.TouchAPIKey("key", usedAt); err != nil {
+		t.Fatalf("TouchAPIKey failed: %v", err)
+	}
+	if err := store.RevokeAPIKey("key"); err != nil {
+		t.Fatalf("RevokeAPIKey failed: %v", err)
+	}
+	keys, _ := store.ListAPIKeys()
+	if len(keys) != 1 || keys[0].RevokedAt == nil || keys[0].LastUsedAt == nil || !keys[0].LastUsedAt.Equal(usedAt) {
+		t.Errorf("expected the revoked key listed with its last use, got %+v", keys)
+	}
+	if err := store.RevokeAPIKey("missing"); err == nil {
+		t.Error("expected revoking a missing key to fail")
+	}
+}
+
+func TestMemoryStore_CreatedByFilters(t *testing.T) {
+	store := data.NewMemoryStore()
+	for _, id := range []string{"mine", "theirs"} {
+		_ = store.CreateInterview(&data.Interview{ID: id, CandidateName: id, CreatedBy: "recruiter-" + id})
+		_ = store.CreateEvaluation(&data.Evaluation{ID: "eval-" + id, InterviewID: id, Score: 0.5})
+		_ = store.CreatePosition(&data.Position{ID: "position-" + id, Title: id, CreatedBy: "recruiter-" + id})
+	}
+
+	interviews, _ := store.GetInterviewsWithOptions(data.ListInterviewsOptions{Limit: 10, CreatedBy: "recruiter-mine"})
+	if interviews.Total != 1 || interviews.Interviews[0].ID != "mine" {
+		t.Errorf("expected only the recruiter's interview, got %+v", interviews.Interviews)
+	}
+	evaluations, _ := store.ListEvaluations(data.ListEvaluationsOptions{Limit: 10, Filters: data.EvaluationFilters{CreatedBy: "recruiter-mine"}})
+	if evaluations.Total != 1 || evaluations.Evaluations[0].ID != "eval-mine" {
+		t.Errorf("expected only the evaluation of the recruiter's interview, got %+v", evaluations.Evaluations)
+	}
+	positions, total, _ := store.ListPositions(data.PositionFilters{CreatedBy: "recruiter-theirs"}, 10, 0)
+	if total != 1 || positions[0].ID != "position-theirs" {
+		t.Errorf("expected only the recruiter's position, got %+v", positions)
+	}
+
+	// Recruiters also see the candidates on their interviews
+	linked := "candidate-linked"
+	_ = store.CreateCandidate(&data.Candidate{ID: "candidate-own", Name: "Own", CreatedBy: "recruiter-other"})
+	_ = store.CreateCandidate(&data.Candidate{ID: linked, Name: "Linked", CreatedBy: "recruiter-mine"})
+	_ = store.CreateCandidate(&data.Candidate{ID: "candidate-hidden", Name: "Hidden", CreatedBy: "recruiter-mine"})
+	_ = store.CreateInterview(&data.Interview{ID: "other", CandidateName: "Linked", CandidateID: &linked, CreatedBy: "recruiter-other"})
+	candidates, total, _ := store.ListCandidates(data.CandidateFilters{CreatedBy: "recruiter-other"}, 10, 0)
+	if total != 2 || candidates[0].ID != linked || candidates[1].ID != "candidate-own" {
+		t.Errorf("expected the recruiter's own and interviewed candidates, got %+v", candidates)
+	}
+}
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"fmt"
+
 	"github.com/zidane0000/AI_Interview_Backend/utils"
 	"gorm.io/gorm"
 )
@@ -38,5 +40,57 @@ func AddPerformanceIndexes(db *gorm.DB) error { // Index for interview queries
 		utils.Warningf("Warning: Could not create chat message type index: %v\n", err)
 	}
 
+	// Indexes for question bank filtering and search
+	if err := db.Exec("CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_questions_tags ON questions USING GIN (tags);").Error; err != nil {
+		utils.Warningf("Warning: Could not create question tags index: %v\n", err)
+	}
+
+	if err := db.Exec("CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_questions_search ON questions USING GIN (to_tsvector('english', text || ' ' || coalesce(expected_answer, '')));").Error; err != nil {
+		utils.Warningf("Warning: Could not create question search index: %v\n", err)
+	}
+
+	return nil
+}
+
+// foreignKeys lists the referential constraints between interview data tables. Top-level
+// children restrict deletes so interviews are only removed through the explicit cascade in
+// InterviewRepository.Delete; leaf rows follow their parent, and an evaluation outlives the
+// session it came from. Uploaded files, invitations and webhook deliveries go with their
+// interview, event or subscription, and a candidate or position cannot be deleted while
+// interviews reference it.
+var foreignKeys = []struct {
+	Name, Table, Column, RefTable, OnDelete string
+}{
+	{"fk_chat_sessions_interview", "chat_sessions", "interview_id", "interviews", "RESTRICT"},
+	{"fk_evaluations_interview", "evaluations", "interview_id", "interviews", "RESTRICT"},
+	{"fk_chat_messages_session", "chat_messages", "session_id", "chat_sessions", "CASCADE"},
+	{"fk_evaluations_session", "evaluations", "session_id", "chat_sessions", "SET NULL"},
+	{"fk_evaluation_revisions_evaluation", "evaluation_revisions", "evaluation_id", "evaluations", "CASCADE"},
+	{"fk_evaluation_ratings_evaluation", "evaluation_ratings", "evaluation_id", "evaluations", "CASCADE"},
+	{"fk_files_interview", "files", "interview_id", "interviews", "CASCADE"},
+	{"fk_invitations_interview", "invitations", "interview_id", "interviews", "CASCADE"},
+	{"fk_interviews_candidate", "interviews", "candidate_id", "candidates", "RESTRICT"},
+	{"fk_interviews_position", "interviews", "position_id", "positions", "RESTRICT"},
+	{"fk_webhook_deliveries_event", "webhook_deliveries", "event_id", "webhook_events", "CASCADE"},
+	{"fk_webhook_deliveries_subscription", "webhook_deliveries", "subscription_id", "webhook_subscriptions", "CASCADE"},
+}
+
+// AddForeignKeys creates the foreign-key constraints that are missing. Existing orphaned rows
+// make a constraint fail to apply; that is logged rather than blocking startup.
+func AddForeignKeys(db *gorm.DB) error {
+	for _, fk := range foreignKeys {
+		var exists int64
+		if err := db.Raw("SELECT COUNT(*) FROM pg_constraint WHERE conname = ?", fk.Name).Scan(&exists).Error; err != nil {
+			return err
+		}
+		if exists > 0 {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s(id) ON DELETE %s;",
+			fk.Table, fk.Name, fk.Column, fk.RefTable, fk.OnDelete)
+		if err := db.Exec(stmt).Error; err != nil {
+			utils.Warningf("Warning: Could not create foreign key %s: %v\n", fk.Name, err)
+		}
+	}
 	return nil
 }
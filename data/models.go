@@ -21,6 +21,77 @@ const (
 	InterviewTypeBehavioral = "behavioral"
 )
 
+// Question difficulty constants
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// Review decision constants
+const (
+	DecisionAdvance = "advance"
+	DecisionHold    = "hold"
+	DecisionReject  = "reject"
+)
+
+// Interview lifecycle status constants
+const (
+	InterviewStatusDraft      = "draft"
+	InterviewStatusScheduled  = "scheduled"
+	InterviewStatusInProgress = "in_progress"
+	InterviewStatusCompleted  = "completed"
+	InterviewStatusArchived   = "archived"
+	InterviewStatusCancelled  = "cancelled"
+)
+
+// interviewTransitions lists the statuses each interview status may move to
+var interviewTransitions = map[string][]string{
+	InterviewStatusDraft:      {InterviewStatusScheduled, InterviewStatusInProgress, InterviewStatusCancelled},
+	InterviewStatusScheduled:  {InterviewStatusDraft, InterviewStatusInProgress, InterviewStatusCancelled},
+	InterviewStatusInProgress: {InterviewStatusCompleted, InterviewStatusCancelled},
+	InterviewStatusCompleted:  {InterviewStatusInProgress, InterviewStatusArchived}, // Back to in_progress for a retake
+	InterviewStatusCancelled:  {InterviewStatusArchived},
+	InterviewStatusArchived:   {},
+}
+
+// Evaluation revision sources
+const (
+	RevisionSourceAI    = "ai"
+	RevisionSourceHuman = "human"
+)
+
+// Background job types
+const (
+	JobTypeSessionEvaluation = "session_evaluation" // Evaluate a chat session transcript
+	JobTypeAnswerEvaluation  = "answer_evaluation"  // Evaluate answers submitted to POST /evaluation
+	JobTypeResumeParse       = "resume_parse"       // Parse an interview's resume into a candidate profile
+)
+
+// Background job status constants
+const (
+	JobStatusPending   = "pending" // Waiting for a worker, possibly until a retry is due
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead" // Out of attempts or failed permanently; kept until retried by hand
+)
+
+// Webhook event types
+const (
+	WebhookEventInterviewCreated     = "interview.created"
+	WebhookEventSessionStarted       = "session.started"
+	WebhookEventSessionCompleted     = "session.completed"
+	WebhookEventEvaluationReady      = "evaluation.ready"
+	WebhookEventEvaluationOverridden = "evaluation.overridden" // A reviewer changed the AI's result
+)
+
+// Webhook delivery status constants
+const (
+	WebhookDeliveryPending   = "pending" // Waiting for its first attempt or a retry
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed" // Out of attempts; can be replayed
+)
+
 // ValidateLanguage checks if the provided language code is supported
 func ValidateLanguage(lang string) bool {
 	return lang == LanguageEnglish || lang == LanguageTraditionalChinese
@@ -46,6 +117,46 @@ func ValidateInterviewType(interviewType string) bool {
 		interviewType == InterviewTypeBehavioral
 }
 
+// ValidateInterviewStatus checks if the provided status is part of the interview lifecycle
+func ValidateInterviewStatus(status string) bool {
+	_, ok := interviewTransitions[status]
+	return ok
+}
+
+// normalizeInterviewStatus treats interviews stored without a status as drafts
+func normalizeInterviewStatus(status string) string {
+	if status == "" {
+		return InterviewStatusDraft
+	}
+	return status
+}
+
+// NextInterviewStatuses returns the statuses an interview in the given status may move to
+func NextInterviewStatuses(status string) []string {
+	return append([]string{}, interviewTransitions[normalizeInterviewStatus(status)]...)
+}
+
+// CanTransitionInterview reports whether an interview may move from one status to another
+func CanTransitionInterview(from, to string) bool {
+	for _, next := range interviewTransitions[normalizeInterviewStatus(from)] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// EndsInterviewSessions reports whether moving to the status requires the interview's chat sessions to have ended
+func EndsInterviewSessions(status string) bool {
+	return status == InterviewStatusCompleted || status == InterviewStatusCancelled
+}
+
+// CanStartInterviewSession reports whether a chat session may be started in the given status
+func CanStartInterviewSession(status string) bool {
+	status = normalizeInterviewStatus(status)
+	return status == InterviewStatusInProgress || CanTransitionInterview(status, InterviewStatusInProgress)
+}
+
 // GetDefaultInterviewType returns the default interview type when none is specified
 func GetDefaultInterviewType() string {
 	return InterviewTypeGeneral
@@ -59,6 +170,37 @@ func GetValidatedInterviewType(interviewType string) string {
 	return GetDefaultInterviewType()
 }
 
+// ValidateDifficulty checks if the provided question difficulty is supported
+func ValidateDifficulty(difficulty string) bool {
+	return difficulty == DifficultyEasy ||
+		difficulty == DifficultyMedium ||
+		difficulty == DifficultyHard
+}
+
+// ValidateDecision checks if the provided review decision is supported
+func ValidateDecision(decision string) bool {
+	return decision == DecisionAdvance ||
+		decision == DecisionHold ||
+		decision == DecisionReject
+}
+
+// ValidateJobStatus checks if the provided status is a background job status
+func ValidateJobStatus(status string) bool {
+	return status == JobStatusPending ||
+		status == JobStatusRunning ||
+		status == JobStatusSucceeded ||
+		status == JobStatusDead
+}
+
+// ValidateWebhookEventType checks if the provided webhook event type is supported
+func ValidateWebhookEventType(eventType string) bool {
+	return eventType == WebhookEventInterviewCreated ||
+		eventType == WebhookEventSessionStarted ||
+		eventType == WebhookEventSessionCompleted ||
+		eventType == WebhookEventEvaluationReady ||
+		eventType == WebhookEventEvaluationOverridden
+}
+
 // StringArray is a custom type for handling PostgreSQL arrays with GORM
 type StringArray []string
 
@@ -115,29 +257,421 @@ func (s StringMap) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// FloatMap is a custom type for handling JSON maps of scores with GORM
+type FloatMap map[string]float64
+
+// Scan implements the Scanner interface for database/sql
+func (f *FloatMap) Scan(value interface{}) error {
+	if value == nil {
+		*f = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("cannot scan %T into FloatMap", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (f FloatMap) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	return json.Marshal(f)
+}
+
+// RubricAnchor describes what a given score level looks like for a criterion
+type RubricAnchor struct {
+	Score       float64 `json:"score"`       // 0.0-1.0
+	Description string  `json:"description"` // Observable behavior at this level
+}
+
+// RubricCriterion is a single weighted evaluation criterion
+type RubricCriterion struct {
+	Key         string         `json:"key"`                   // Category score key, e.g. "system_design"
+	Name        string         `json:"name,omitempty"`        // Human-readable name
+	Description string         `json:"description,omitempty"` // What the criterion measures
+	Weight      float64        `json:"weight"`                // Relative weight, normalized when scoring
+	Anchors     []RubricAnchor `json:"anchors,omitempty"`     // Per-level anchors
+}
+
+// Rubric is a set of weighted criteria used to compute an evaluation's overall score
+type Rubric struct {
+	Criteria []RubricCriterion `json:"criteria,omitempty"`
+}
+
+// IsEmpty reports whether the rubric defines no criteria
+func (r Rubric) IsEmpty() bool {
+	return len(r.Criteria) == 0
+}
+
+// Validate checks that criterion keys are present and unique, weights are positive and anchors are in range
+func (r Rubric) Validate() error {
+	seen := make(map[string]bool, len(r.Criteria))
+	for _, criterion := range r.Criteria {
+		if criterion.Key == "" {
+			return fmt.Errorf("rubric criterion key is required")
+		}
+		if seen[criterion.Key] {
+			return fmt.Errorf("duplicate rubric criterion key: %s", criterion.Key)
+		}
+		seen[criterion.Key] = true
+		if criterion.Weight <= 0 {
+			return fmt.Errorf("rubric criterion %s must have a positive weight", criterion.Key)
+		}
+		for _, anchor := range criterion.Anchors {
+			if anchor.Score < 0 || anchor.Score > 1 {
+				return fmt.Errorf("rubric criterion %s has an anchor outside 0.0-1.0", criterion.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// Scan implements the Scanner interface for database/sql
+func (r *Rubric) Scan(value interface{}) error {
+	if value == nil {
+		*r = Rubric{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return fmt.Errorf("cannot scan %T into Rubric", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (r Rubric) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// EnsembleConfig runs evaluation on several models and combines their scores
+type EnsembleConfig struct {
+	Models                []string `json:"models,omitempty"`                 // "provider/model" specs, e.g. "openai/gpt-4"
+	Aggregation           string   `json:"aggregation,omitempty"`            // "mean" or "median"
+	DisagreementThreshold float64  `json:"disagreement_threshold,omitempty"` // Score spread that flags human review
+}
+
+// IsEmpty reports whether the ensemble is disabled
+func (e EnsembleConfig) IsEmpty() bool {
+	return len(e.Models) == 0
+}
+
+// Scan implements the Scanner interface for database/sql
+func (e *EnsembleConfig) Scan(value interface{}) error {
+	if value == nil {
+		*e = EnsembleConfig{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("cannot scan %T into EnsembleConfig", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (e EnsembleConfig) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+// SamplingConfig repeats an evaluation several times to measure score consistency
+type SamplingConfig struct {
+	Samples     int     `json:"samples,omitempty"`     // Number of evaluation runs; disabled when below 2
+	Temperature float64 `json:"temperature,omitempty"` // Sampling temperature
+	MaxStdDev   float64 `json:"max_std_dev,omitempty"` // Standard deviation above which a result is low-confidence
+}
+
+// IsEmpty reports whether repeated sampling is disabled
+func (sc SamplingConfig) IsEmpty() bool {
+	return sc.Samples < 2
+}
+
+// Scan implements the Scanner interface for database/sql
+func (sc *SamplingConfig) Scan(value interface{}) error {
+	if value == nil {
+		*sc = SamplingConfig{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, sc)
+	case string:
+		return json.Unmarshal([]byte(v), sc)
+	default:
+		return fmt.Errorf("cannot scan %T into SamplingConfig", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (sc SamplingConfig) Value() (driver.Value, error) {
+	return json.Marshal(sc)
+}
+
+// ConsistencyStats records the spread of overall scores across repeated evaluation samples
+type ConsistencyStats struct {
+	Samples         int       `json:"samples"`
+	Requested       int       `json:"requested"`
+	Temperature     float64   `json:"temperature"`
+	Scores          []float64 `json:"scores"`
+	Mean            float64   `json:"mean"`
+	StdDev          float64   `json:"std_dev"`
+	ConfidenceLow   float64   `json:"confidence_low"`
+	ConfidenceHigh  float64   `json:"confidence_high"`
+	ConfidenceLevel float64   `json:"confidence_level"`
+}
+
+// IsEmpty reports whether the evaluation was not sampled
+func (cs ConsistencyStats) IsEmpty() bool {
+	return cs.Samples == 0
+}
+
+// Scan implements the Scanner interface for database/sql
+func (cs *ConsistencyStats) Scan(value interface{}) error {
+	if value == nil {
+		*cs = ConsistencyStats{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, cs)
+	case string:
+		return json.Unmarshal([]byte(v), cs)
+	default:
+		return fmt.Errorf("cannot scan %T into ConsistencyStats", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (cs ConsistencyStats) Value() (driver.Value, error) {
+	if cs.IsEmpty() {
+		return nil, nil
+	}
+	return json.Marshal(cs)
+}
+
+// ModelResult is one model's scores within an ensemble evaluation
+type ModelResult struct {
+	Provider       string             `json:"provider"`
+	Model          string             `json:"model"`
+	Score          float64            `json:"score"`
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+	Feedback       string             `json:"feedback,omitempty"`
+	Error          string             `json:"error,omitempty"`
+}
+
+// ModelResults is a custom type for handling JSON arrays of per-model results with GORM
+type ModelResults []ModelResult
+
+// Scan implements the Scanner interface for database/sql
+func (m *ModelResults) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	default:
+		return fmt.Errorf("cannot scan %T into ModelResults", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (m ModelResults) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+// MessageAnnotation is a reviewer comment attached to a transcript message
+type MessageAnnotation struct {
+	ID        string    `json:"id"`
+	MessageID string    `json:"message_id"`
+	Reviewer  string    `json:"reviewer"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Annotations is a custom type for handling JSON arrays of message annotations with GORM
+type Annotations []MessageAnnotation
+
+// Scan implements the Scanner interface for database/sql
+func (a *Annotations) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("cannot scan %T into Annotations", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (a Annotations) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// EndPolicy controls when a chat interview ends automatically; zero values fall back to the AI client default
+type EndPolicy struct {
+	MaxUserMessages    int `json:"max_user_messages,omitempty"`    // End after this many candidate messages
+	MaxDurationMinutes int `json:"max_duration_minutes,omitempty"` // End once the session has run this long
+}
+
+// Scan implements the Scanner interface for database/sql
+func (p *EndPolicy) Scan(value interface{}) error {
+	if value == nil {
+		*p = EndPolicy{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into EndPolicy", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (p EndPolicy) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
 // Interview model with proper GORM tags
 type Interview struct {
-	ID                string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	CandidateName     string      `gorm:"type:varchar(255);not null" json:"candidate_name"`
-	Questions         StringArray `gorm:"type:jsonb" json:"questions"`
-	InterviewLanguage string      `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"interview_language"` // Interview language: "en" or "zh-TW"
-	Status            string      `gorm:"type:varchar(50);not null;default:'draft'" json:"status"`                          // "draft", "active", "completed"
-	InterviewType     string      `gorm:"column:type;type:varchar(50);not null" json:"interview_type"`                      // "general", "technical", "behavioral"
-	JobDescription    string      `gorm:"type:text" json:"job_description,omitempty"`                                       // Optional: Job description text
-	// TODO: Resume file support will be added in future iteration
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                string           `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	CandidateName     string           `gorm:"type:varchar(255);not null" json:"candidate_name"`
+	CandidateID       *string          `gorm:"type:varchar(255);index" json:"candidate_id,omitempty"` // Optional: Candidate record linking their interviews
+	PositionID        *string          `gorm:"type:varchar(255);index" json:"position_id,omitempty"`  // Optional: Position the candidate is interviewing for
+	Questions         StringArray      `gorm:"type:jsonb" json:"questions"`
+	InterviewLanguage string           `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"interview_language"` // Interview language: "en" or "zh-TW"
+	Status            string           `gorm:"type:varchar(50);not null;default:'draft'" json:"status"`                          // Lifecycle status; see InterviewStatus constants
+	InterviewType     string           `gorm:"column:type;type:varchar(50);not null" json:"interview_type"`                      // "general", "technical", "behavioral"
+	JobDescription    string           `gorm:"type:text" json:"job_description,omitempty"`                                       // Optional: Job description text
+	QuestionIDs       StringArray      `gorm:"type:jsonb" json:"question_ids,omitempty"`                                         // Optional: Question bank references, resolved at read time
+	TemplateID        string           `gorm:"type:varchar(255);index" json:"template_id,omitempty"`                             // Optional: Template the interview was created from
+	EndPolicy         EndPolicy        `gorm:"type:jsonb" json:"end_policy"`                                                     // When the chat session should end automatically
+	EvalCriteria      StringArray      `gorm:"column:evaluation_criteria;type:jsonb" json:"evaluation_criteria,omitempty"`       // Optional: Criteria passed to the evaluator
+	Persona           string           `gorm:"column:interviewer_persona;type:text" json:"interviewer_persona,omitempty"`        // Optional: Interviewer persona for the system prompt
+	Rubric            Rubric           `gorm:"type:jsonb" json:"rubric"`                                                         // Optional: Weighted rubric for deterministic scoring
+	Ensemble          EnsembleConfig   `gorm:"column:evaluation_ensemble;type:jsonb" json:"evaluation_ensemble"`                 // Optional: Multi-model evaluation
+	Sampling          SamplingConfig   `gorm:"column:evaluation_sampling;type:jsonb" json:"evaluation_sampling"`                 // Optional: Repeated sampling for consistency
+	MaxRetakes        int              `gorm:"not null;default:0" json:"max_retakes"`                                            // Chat sessions allowed after the first; one may be active at a time
+	ResumeFileID      string           `gorm:"type:varchar(255)" json:"resume_file_id,omitempty"`                                // Optional: Uploaded resume; see File
+	ResumeText        string           `gorm:"type:text" json:"resume_text,omitempty"`                                           // Text extracted from the uploaded resume
+	CandidateProfile  CandidateProfile `gorm:"type:jsonb" json:"candidate_profile"`                                              // Structured resume, parsed by AI and editable by recruiters
+	CreatedBy         string           `gorm:"type:varchar(255);index" json:"created_by,omitempty"`                              // Subject of the principal that created it; recruiters see only their own
+	CreatedAt         time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// MaxSessions is the number of chat sessions the interview allows: the first plus any retakes
+func (i *Interview) MaxSessions() int {
+	return 1 + i.MaxRetakes
 }
 
 // Evaluation model with proper GORM tags
 type Evaluation struct {
-	ID          string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	InterviewID string    `gorm:"type:varchar(255);not null;index" json:"interview_id"`
-	Answers     StringMap `gorm:"type:jsonb" json:"answers"`
-	Score       float64   `gorm:"type:decimal(5,2)" json:"score"`
-	Feedback    string    `gorm:"type:text" json:"feedback"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	InterviewID string      `gorm:"type:varchar(255);not null;index" json:"interview_id"`
+	SessionID   *string     `gorm:"type:varchar(255);index" json:"session_id,omitempty"` // Chat session the evaluation came from, if any
+	Partial     bool        `gorm:"not null;default:false" json:"partial,omitempty"`     // Evaluated from an unfinished (abandoned) session
+	JobID       string      `gorm:"type:varchar(255);index" json:"job_id,omitempty"`     // Background job that produced the evaluation, if any
+	Answers     StringMap   `gorm:"type:jsonb" json:"answers"`
+	Score       float64     `gorm:"type:decimal(5,2)" json:"score"`
+	Feedback    string      `gorm:"type:text" json:"feedback"`
+	Strengths   StringArray `gorm:"type:jsonb" json:"strengths,omitempty"`  // AI-identified strengths
+	Weaknesses  StringArray `gorm:"type:jsonb" json:"weaknesses,omitempty"` // AI-identified areas for improvement
+	// Per-criterion AI scores and the rubric snapshot used to derive Score
+	CategoryScores FloatMap `gorm:"type:jsonb" json:"category_scores,omitempty"`
+	Rubric         Rubric   `gorm:"type:jsonb" json:"rubric"`
+	ScoringMethod  string   `gorm:"type:varchar(50)" json:"scoring_method,omitempty"` // "weighted_rubric" or "model"
+	// Ensemble evaluations keep each model's scores and flag large disagreements for human review
+	ModelResults ModelResults `gorm:"type:jsonb" json:"model_results,omitempty"`
+	Disagreement float64      `gorm:"type:decimal(5,2)" json:"disagreement,omitempty"`
+	NeedsReview  bool         `gorm:"default:false;index" json:"needs_review"`
+	// Sampled evaluations report Score as the mean and are low-confidence when the spread is too wide
+	Consistency   ConsistencyStats `gorm:"type:jsonb" json:"consistency"`
+	LowConfidence bool             `gorm:"default:false;index" json:"low_confidence"`
+	// Human review; the AI fields above are never modified after creation
+	HumanScore          *float64    `gorm:"type:decimal(5,2)" json:"human_score,omitempty"`
+	HumanCategoryScores FloatMap    `gorm:"type:jsonb" json:"human_category_scores,omitempty"`
+	Decision            string      `gorm:"type:varchar(20);index" json:"decision,omitempty"` // "advance", "hold" or "reject"
+	ReviewedBy          string      `gorm:"type:varchar(255)" json:"reviewed_by,omitempty"`
+	ReviewedAt          *time.Time  `json:"reviewed_at,omitempty"`
+	Annotations         Annotations `gorm:"type:jsonb" json:"annotations,omitempty"`
+	// Reviewers assigned to rate this evaluation independently for calibration
+	CalibrationRaters StringArray `gorm:"type:jsonb" json:"calibration_raters,omitempty"`
+	CreatedAt         time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// FinalScore returns the human score when a reviewer set one, otherwise the AI score
+func (e *Evaluation) FinalScore() float64 {
+	if e.HumanScore != nil {
+		return *e.HumanScore
+	}
+	return e.Score
+}
+
+// EvaluationRevision is an immutable audit record of one human review of an evaluation.
+// Revision 0 is the AI original, which is derived from the evaluation's AI fields.
+type EvaluationRevision struct {
+	ID             string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	EvaluationID   string      `gorm:"type:varchar(255);not null;uniqueIndex:idx_evaluation_revision" json:"evaluation_id"`
+	Revision       int         `gorm:"not null;uniqueIndex:idx_evaluation_revision" json:"revision"`
+	Source         string      `gorm:"type:varchar(20);not null" json:"source"` // "ai" or "human"
+	Reviewer       string      `gorm:"type:varchar(255)" json:"reviewer,omitempty"`
+	Score          float64     `gorm:"type:decimal(5,2)" json:"score"`
+	CategoryScores FloatMap    `gorm:"type:jsonb" json:"category_scores,omitempty"`
+	Decision       string      `gorm:"type:varchar(20)" json:"decision,omitempty"`
+	Comment        string      `gorm:"type:text" json:"comment,omitempty"`
+	Annotations    Annotations `gorm:"type:jsonb" json:"annotations,omitempty"` // Annotations added in this revision
+	CreatedAt      time.Time   `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// EvaluationRating is one reviewer's independent, blind rating of an evaluation used for calibration
+type EvaluationRating struct {
+	ID             string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	EvaluationID   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_evaluation_rater" json:"evaluation_id"`
+	Rater          string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_evaluation_rater" json:"rater"`
+	Score          float64   `gorm:"type:decimal(5,2)" json:"score"`
+	CategoryScores FloatMap  `gorm:"type:jsonb" json:"category_scores,omitempty"`
+	Decision       string    `gorm:"type:varchar(20);not null" json:"decision"`
+	Comment        string    `gorm:"type:text" json:"comment,omitempty"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
 
 // ChatSession model for conversational interviews with proper GORM tags
@@ -145,11 +679,31 @@ type ChatSession struct {
 	ID              string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
 	InterviewID     string     `gorm:"type:varchar(255);not null;index" json:"interview_id"`
 	SessionLanguage string     `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"session_language"` // Session language: "en" or "zh-TW"
-	Status          string     `gorm:"type:varchar(50);not null;default:'active'" json:"status"`                       // "active", "completed", "abandoned"
+	Status          string     `gorm:"type:varchar(50);not null;default:'active'" json:"status"`                       // "active", "paused", "completed", "abandoned"
 	StartedAt       time.Time  `gorm:"column:created_at;autoCreateTime" json:"started_at"`                             // When session started
 	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 	EndedAt         *time.Time `gorm:"type:timestamp" json:"ended_at,omitempty"`
+	PausedAt        *time.Time `gorm:"type:timestamp" json:"paused_at,omitempty"` // Set while the session is paused
+	PausedSeconds   int64      `gorm:"not null;default:0" json:"paused_seconds"`  // Total time spent in earlier pauses
+	ResumeTokenHash string     `gorm:"type:varchar(64)" json:"-"`                 // SHA-256 of the candidate's resume token
+}
+
+// openSessionStatuses are the statuses of sessions that have not ended yet
+var openSessionStatuses = []string{"active", "paused"}
+
+// IsOpen reports whether the session is still running or paused, i.e. it may continue
+func (s *ChatSession) IsOpen() bool {
+	return s.Status == "active" || s.Status == "paused"
+}
+
+// ActiveDuration is how long the session has run as of now, excluding time spent paused
+func (s *ChatSession) ActiveDuration(now time.Time) time.Duration {
+	paused := time.Duration(s.PausedSeconds) * time.Second
+	if s.PausedAt != nil {
+		paused += now.Sub(*s.PausedAt)
+	}
+	return now.Sub(s.StartedAt) - paused
 }
 
 // ChatMessage model with proper GORM tags
@@ -160,19 +714,360 @@ type ChatMessage struct {
 	Content   string    `gorm:"type:text;not null" json:"content"`
 	Timestamp time.Time `gorm:"not null" json:"timestamp"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	// Regenerated or edited messages stay as hidden revisions pointing at their replacement
+	SupersededBy string `gorm:"type:varchar(255);not null;default:''" json:"superseded_by,omitempty"`
+}
+
+// Question model for the reusable question bank with proper GORM tags
+type Question struct {
+	ID             string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Text           string      `gorm:"type:text;not null" json:"text"`
+	Translations   StringMap   `gorm:"type:jsonb" json:"translations,omitempty"`                     // Localized text keyed by language code: "en", "zh-TW"
+	Tags           StringArray `gorm:"type:jsonb" json:"tags"`                                       // Free-form tags, e.g. "golang", "system-design"
+	InterviewType  string      `gorm:"column:type;type:varchar(50);not null" json:"interview_type"`  // "general", "technical", "behavioral"
+	Difficulty     string      `gorm:"type:varchar(20);not null;default:'medium'" json:"difficulty"` // "easy", "medium", "hard"
+	ExpectedAnswer string      `gorm:"type:text" json:"expected_answer,omitempty"`                   // Notes on what a good answer covers
+	UsageCount     int         `gorm:"not null;default:0" json:"usage_count"`                        // Number of interviews referencing this question
+	CreatedAt      time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
-// TODO: Implement File model for resume uploads
-// type File struct {
-//     ID           string    `db:"id" json:"id"`
-//     OriginalName string    `db:"original_name" json:"original_name"`
-//     FileName     string    `db:"file_name" json:"file_name"`
-//     FilePath     string    `db:"file_path" json:"file_path"`
-//     FileSize     int64     `db:"file_size" json:"file_size"`
-//     ContentType  string    `db:"content_type" json:"content_type"`
-//     InterviewID  *string   `db:"interview_id" json:"interview_id,omitempty"`
-//     CreatedAt    time.Time `db:"created_at" json:"created_at"`
-// }
+// TextFor returns the question text in the requested language, falling back to the default text
+func (q *Question) TextFor(language string) string {
+	if text, exists := q.Translations[language]; exists && text != "" {
+		return text
+	}
+	return q.Text
+}
+
+// InterviewTemplate model for reusable interview configurations with proper GORM tags
+type InterviewTemplate struct {
+	ID                string         `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Name              string         `gorm:"type:varchar(255);not null;uniqueIndex" json:"name"` // e.g. "Backend Engineer L4"
+	Description       string         `gorm:"type:text" json:"description,omitempty"`
+	InterviewType     string         `gorm:"column:type;type:varchar(50);not null" json:"interview_type"`
+	InterviewLanguage string         `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"interview_language"`
+	Questions         StringArray    `gorm:"type:jsonb" json:"questions,omitempty"`
+	QuestionIDs       StringArray    `gorm:"type:jsonb" json:"question_ids,omitempty"`
+	JobDescription    string         `gorm:"type:text" json:"job_description,omitempty"`
+	EndPolicy         EndPolicy      `gorm:"type:jsonb" json:"end_policy"`
+	EvalCriteria      StringArray    `gorm:"column:evaluation_criteria;type:jsonb" json:"evaluation_criteria,omitempty"`
+	Persona           string         `gorm:"column:interviewer_persona;type:text" json:"interviewer_persona,omitempty"`
+	Rubric            Rubric         `gorm:"type:jsonb" json:"rubric"`
+	Ensemble          EnsembleConfig `gorm:"column:evaluation_ensemble;type:jsonb" json:"evaluation_ensemble"`
+	Sampling          SamplingConfig `gorm:"column:evaluation_sampling;type:jsonb" json:"evaluation_sampling"`
+	MaxRetakes        int            `gorm:"not null;default:0" json:"max_retakes"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Job is a unit of background work, such as an AI evaluation, run by the in-process worker pool.
+// Jobs are persisted so pending work survives a restart.
+type Job struct {
+	ID          string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Type        string     `gorm:"type:varchar(50);not null;index" json:"type"`                                                       // e.g. "session_evaluation"
+	Status      string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_jobs_status_run_at,priority:1" json:"status"` // "pending", "running", "succeeded", "dead"
+	Payload     StringMap  `gorm:"type:jsonb" json:"payload,omitempty"`                                                               // Job type specific arguments
+	Attempts    int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts int        `gorm:"not null;default:1" json:"max_attempts"` // Attempts before the job is dead-lettered
+	LastError   string     `gorm:"type:text" json:"last_error,omitempty"`
+	ResultID    string     `gorm:"type:varchar(255)" json:"result_id,omitempty"`                   // ID of the record the job produced, e.g. an evaluation
+	RunAt       time.Time  `gorm:"not null;index:idx_jobs_status_run_at,priority:2" json:"run_at"` // Earliest time a worker may pick the job up
+	StartedAt   *time.Time `gorm:"type:timestamp" json:"started_at,omitempty"`                     // Start of the latest attempt
+	FinishedAt  *time.Time `gorm:"type:timestamp" json:"finished_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// WebhookSubscription registers a URL to receive signed POSTs for the chosen event types
+type WebhookSubscription struct {
+	ID          string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	URL         string      `gorm:"type:text;not null" json:"url"`
+	Secret      string      `gorm:"type:varchar(255);not null" json:"-"` // HMAC-SHA256 key for payload signatures
+	EventTypes  StringArray `gorm:"type:jsonb" json:"event_types"`
+	Description string      `gorm:"type:text" json:"description,omitempty"`
+	Active      bool        `gorm:"not null;default:true" json:"active"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Subscribes reports whether the subscription should receive events of the given type
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	if !s.Active {
+		return false
+	}
+	for _, subscribed := range s.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent is the transactional outbox record of something that happened. It is written in the
+// same transaction as the change it describes and fanned out to subscriptions afterwards.
+type WebhookEvent struct {
+	ID           string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Type         string     `gorm:"type:varchar(50);not null" json:"type"`
+	Payload      string     `gorm:"type:text;not null" json:"payload"` // JSON body POSTed to subscribers
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	DispatchedAt *time.Time `gorm:"type:timestamp;index" json:"dispatched_at,omitempty"` // Set once deliveries are created
+}
+
+// WebhookDelivery tracks sending one event to one subscription, forming the delivery log
+type WebhookDelivery struct {
+	ID             string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	EventID        string     `gorm:"type:varchar(255);not null;index" json:"event_id"`
+	SubscriptionID string     `gorm:"type:varchar(255);not null;index" json:"subscription_id"`
+	EventType      string     `gorm:"type:varchar(50);not null" json:"event_type"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_deliveries_status_next,priority:1" json:"status"` // "pending", "succeeded", "failed"
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus int        `gorm:"not null;default:0" json:"response_status,omitempty"` // HTTP status of the latest attempt, 0 if none was received
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_deliveries_status_next,priority:2" json:"next_attempt_at"`
+	DeliveredAt    *time.Time `gorm:"type:timestamp" json:"delivered_at,omitempty"`
+	ReplayOf       string     `gorm:"type:varchar(255)" json:"replay_of,omitempty"` // Delivery this one replays
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Candidate profile sources
+const (
+	ProfileSourceAI        = "ai"        // Parsed from the resume text
+	ProfileSourceRecruiter = "recruiter" // Edited by a recruiter
+)
+
+// Candidate profile limits
+const (
+	maxProfileListItems    = 100 // Entries in any one profile list
+	maxYearsOfExperience   = 70
+	profileDatePresent     = "present" // End date of a current role
+	minProfileGraduationYr = 1900
+	maxProfileGraduationYr = 2100
+)
+
+// WorkExperience is one role in a candidate's work history
+type WorkExperience struct {
+	Company    string   `json:"company"`
+	Title      string   `json:"title,omitempty"`
+	StartDate  string   `json:"start_date,omitempty"` // "YYYY" or "YYYY-MM"
+	EndDate    string   `json:"end_date,omitempty"`   // "YYYY", "YYYY-MM" or "present"
+	Highlights []string `json:"highlights,omitempty"` // Notable projects and achievements, e.g. "Led the payment migration"
+}
+
+// Education is one entry in a candidate's education history
+type Education struct {
+	Institution    string `json:"institution"`
+	Degree         string `json:"degree,omitempty"`
+	Field          string `json:"field,omitempty"`
+	GraduationYear int    `json:"graduation_year,omitempty"`
+}
+
+// CandidateProfile is the structured form of a candidate's resume, parsed by the AI layer and
+// editable by recruiters
+type CandidateProfile struct {
+	Summary           string           `json:"summary,omitempty"`
+	YearsOfExperience float64          `json:"years_of_experience,omitempty"`
+	Skills            []string         `json:"skills,omitempty"`
+	Languages         []string         `json:"languages,omitempty"` // Spoken languages
+	WorkHistory       []WorkExperience `json:"work_history,omitempty"`
+	Education         []Education      `json:"education,omitempty"`
+	Source            string           `json:"source,omitempty"` // See the ProfileSource constants
+	UpdatedAt         *time.Time       `json:"updated_at,omitempty"`
+}
+
+// IsEmpty reports whether no profile has been stored
+func (p CandidateProfile) IsEmpty() bool {
+	return p.Summary == "" && p.YearsOfExperience == 0 && len(p.Skills) == 0 && len(p.Languages) == 0 &&
+		len(p.WorkHistory) == 0 && len(p.Education) == 0
+}
+
+// Validate checks the profile against its schema: required fields are present, lists are bounded,
+// dates are well formed and in order, and numbers are in range
+func (p CandidateProfile) Validate() error {
+	if p.YearsOfExperience < 0 || p.YearsOfExperience > maxYearsOfExperience {
+		return fmt.Errorf("years_of_experience must be between 0 and %d", maxYearsOfExperience)
+	}
+	if err := validateProfileList("skills", p.Skills); err != nil {
+		return err
+	}
+	if err := validateProfileList("languages", p.Languages); err != nil {
+		return err
+	}
+	if len(p.WorkHistory) > maxProfileListItems || len(p.Education) > maxProfileListItems {
+		return fmt.Errorf("work_history and education must have at most %d entries", maxProfileListItems)
+	}
+	for i, role := range p.WorkHistory {
+		if role.Company == "" {
+			return fmt.Errorf("work_history[%d].company is required", i)
+		}
+		start, ok := parseProfileDate(role.StartDate, false)
+		if !ok {
+			return fmt.Errorf("work_history[%d].start_date must be YYYY or YYYY-MM", i)
+		}
+		end, ok := parseProfileDate(role.EndDate, true)
+		if !ok {
+			return fmt.Errorf("work_history[%d].end_date must be YYYY, YYYY-MM or present", i)
+		}
+		if start != "" && end != "" && end != profileDatePresent && end < start {
+			return fmt.Errorf("work_history[%d].end_date is before its start_date", i)
+		}
+		if err := validateProfileList(fmt.Sprintf("work_history[%d].highlights", i), role.Highlights); err != nil {
+			return err
+		}
+	}
+	for i, entry := range p.Education {
+		if entry.Institution == "" {
+			return fmt.Errorf("education[%d].institution is required", i)
+		}
+		if entry.GraduationYear != 0 && (entry.GraduationYear < minProfileGraduationYr || entry.GraduationYear > maxProfileGraduationYr) {
+			return fmt.Errorf("education[%d].graduation_year must be between %d and %d", i, minProfileGraduationYr, maxProfileGraduationYr)
+		}
+	}
+	if p.Source != "" && p.Source != ProfileSourceAI && p.Source != ProfileSourceRecruiter {
+		return fmt.Errorf("invalid profile source: %s", p.Source)
+	}
+	return nil
+}
+
+// validateProfileList checks that a profile list is bounded and has no blank entries
+func validateProfileList(field string, items []string) error {
+	if len(items) > maxProfileListItems {
+		return fmt.Errorf("%s must have at most %d entries", field, maxProfileListItems)
+	}
+	for _, item := range items {
+		if item == "" {
+			return fmt.Errorf("%s must not contain empty entries", field)
+		}
+	}
+	return nil
+}
+
+// parseProfileDate checks a "YYYY" or "YYYY-MM" date, returning it as "YYYY-MM" so dates compare as
+// strings. A bare year is taken as its first month for start dates and its last for end dates, which
+// may also be "present"; an empty date is allowed.
+func parseProfileDate(date string, isEnd bool) (string, bool) {
+	switch {
+	case date == "":
+		return "", true
+	case isEnd && date == profileDatePresent:
+		return date, true
+	}
+	if _, err := time.Parse("2006-01", date); err == nil {
+		return date, true
+	}
+	if _, err := time.Parse("2006", date); err == nil {
+		if isEnd {
+			return date + "-12", true
+		}
+		return date + "-01", true
+	}
+	return "", false
+}
+
+// Scan implements the Scanner interface for database/sql
+func (p *CandidateProfile) Scan(value interface{}) error {
+	if value == nil {
+		*p = CandidateProfile{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into CandidateProfile", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (p CandidateProfile) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Candidate is a person being interviewed; their interviews reference them so repeat
+// applications are linked
+type Candidate struct {
+	ID         string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Name       string `gorm:"type:varchar(255);not null" json:"name"`
+	Email      string `gorm:"type:varchar(255);index" json:"email,omitempty"` // Lowercased; unique when set
+	Phone      string `gorm:"type:varchar(50)" json:"phone,omitempty"`
+	ExternalID string `gorm:"type:varchar(255);index" json:"external_id,omitempty"` // ID in an applicant tracking system; unique when set
+	Locale     string `gorm:"type:varchar(10)" json:"locale,omitempty"`             // Preferred interview language, e.g. "en" or "zh-TW"
+	// Consent flags; ConsentUpdatedAt records when any of them last changed
+	ConsentDataProcessing bool       `gorm:"not null;default:false" json:"consent_data_processing"` // Storing and processing their data
+	ConsentAIEvaluation   bool       `gorm:"not null;default:false" json:"consent_ai_evaluation"`   // AI scoring of their interviews
+	ConsentTalentPool     bool       `gorm:"not null;default:false" json:"consent_talent_pool"`     // Being considered for other roles
+	ConsentUpdatedAt      *time.Time `gorm:"type:timestamp" json:"consent_updated_at,omitempty"`
+	CreatedBy             string     `gorm:"type:varchar(255);index" json:"created_by,omitempty"` // Subject of the principal that created it; recruiters see their own and those on their interviews
+	CreatedAt             time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt             time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Position is an open role; interviews for it reference it so the evaluator sees its required
+// skills and results can be compared per role
+type Position struct {
+	ID             string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Title          string      `gorm:"type:varchar(255);not null" json:"title"` // e.g. "Backend Engineer"
+	Department     string      `gorm:"type:varchar(255);index" json:"department,omitempty"`
+	Level          string      `gorm:"type:varchar(50)" json:"level,omitempty"` // e.g. "senior" or "L4"
+	Description    string      `gorm:"type:text" json:"description,omitempty"`  // Job description given to the interviewer and evaluator
+	RequiredSkills StringArray `gorm:"type:jsonb" json:"required_skills,omitempty"`
+	TemplateID     string      `gorm:"type:varchar(255)" json:"template_id,omitempty"`      // Optional: Default template for its interviews
+	Rubric         Rubric      `gorm:"type:jsonb" json:"rubric"`                            // Optional: Default rubric for its interviews
+	CreatedBy      string      `gorm:"type:varchar(255);index" json:"created_by,omitempty"` // Subject of the principal that created it; recruiters see only their own
+	CreatedAt      time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Invitation lets one candidate into one interview through a signed link. The link stops working
+// once it expires or is revoked, and each chat session started with it uses one of its allowed uses.
+type Invitation struct {
+	ID          string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	InterviewID string     `gorm:"type:varchar(255);not null;index" json:"interview_id"`
+	CandidateID *string    `gorm:"type:varchar(255)" json:"candidate_id,omitempty"` // The interview's candidate when it was issued
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	MaxUses     int        `gorm:"not null" json:"max_uses"`
+	Uses        int        `gorm:"not null;default:0" json:"uses"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Usable reports whether the invitation can start another chat session at the given time
+func (i *Invitation) Usable(now time.Time) bool {
+	return i.RevokedAt == nil && now.Before(i.ExpiresAt) && i.Uses < i.MaxUses
+}
+
+// APIKey authenticates a server-to-server integration, such as an ATS. Only a hash of the key is
+// stored; the key itself is shown once when it is created.
+type APIKey struct {
+	ID         string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	Name       string     `gorm:"type:varchar(255);not null" json:"name"`
+	Prefix     string     `gorm:"type:varchar(20);not null" json:"prefix"` // Start of the key, to tell keys apart
+	KeyHash    string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	Role       string     `gorm:"type:varchar(20);not null;default:'recruiter'" json:"role"` // Role its requests act with; see the api package's roles
+	CreatedBy  string     `gorm:"type:varchar(255)" json:"created_by,omitempty"`             // Subject of the principal that created it
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// File is an uploaded document, such as a candidate resume, whose contents live in a blob store
+type File struct {
+	ID            string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	OriginalName  string    `gorm:"type:varchar(255);not null" json:"original_name"`
+	StorageKey    string    `gorm:"type:varchar(255);not null" json:"-"` // Key of the contents in the blob store
+	FileSize      int64     `gorm:"not null" json:"file_size"`
+	ContentType   string    `gorm:"type:varchar(255);not null" json:"content_type"`
+	InterviewID   *string   `gorm:"type:varchar(255);index" json:"interview_id,omitempty"`
+	ExtractedText string    `gorm:"type:text" json:"extracted_text,omitempty"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
 
 // TODO: Add database migration scripts
 // TODO: Add indexes for performance optimization
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -84,6 +85,58 @@ func TestValidateInterviewType(t *testing.T) {
 	}
 }
 
+// Test interview lifecycle transitions
+func TestCanTransitionInterview(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		expected bool
+	}{
+		{"draft to scheduled", data.InterviewStatusDraft, data.InterviewStatusScheduled, true},
+		{"legacy empty status is a draft", "", data.InterviewStatusScheduled, true},
+		{"scheduled to in progress", data.InterviewStatusScheduled, data.InterviewStatusInProgress, true},
+		{"in progress to completed", data.InterviewStatusInProgress, data.InterviewStatusCompleted, true},
+		{"completed to archived", data.InterviewStatusCompleted, data.InterviewStatusArchived, true},
+		{"completed back to in progress for a retake", data.InterviewStatusCompleted, data.InterviewStatusInProgress, true},
+		{"cancel before completion", data.InterviewStatusInProgress, data.InterviewStatusCancelled, true},
+		{"draft cannot complete", data.InterviewStatusDraft, data.InterviewStatusCompleted, false},
+		{"completed cannot be cancelled", data.InterviewStatusCompleted, data.InterviewStatusCancelled, false},
+		{"archived is final", data.InterviewStatusArchived, data.InterviewStatusDraft, false},
+		{"same status", data.InterviewStatusDraft, data.InterviewStatusDraft, false},
+		{"unknown target", data.InterviewStatusDraft, "active", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, data.CanTransitionInterview(tt.from, tt.to))
+		})
+	}
+
+	assert.True(t, data.CanStartInterviewSession(data.InterviewStatusScheduled))
+	assert.True(t, data.CanStartInterviewSession(data.InterviewStatusInProgress))
+	assert.True(t, data.CanStartInterviewSession(data.InterviewStatusCompleted)) // Retakes; the session limit still applies
+	assert.False(t, data.CanStartInterviewSession(data.InterviewStatusCancelled))
+}
+
+func TestChatSession_ActiveDuration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	session := data.ChatSession{StartedAt: start, PausedSeconds: 600}
+	now := start.Add(30 * time.Minute)
+
+	// Earlier pauses are excluded
+	assert.Equal(t, 20*time.Minute, session.ActiveDuration(now))
+
+	// So is the current pause
+	pausedAt := start.Add(25 * time.Minute)
+	session.PausedAt = &pausedAt
+	session.Status = "paused"
+	assert.Equal(t, 15*time.Minute, session.ActiveDuration(now))
+	assert.True(t, session.IsOpen())
+
+	session.Status = "abandoned"
+	assert.False(t, session.IsOpen())
+}
+
 func TestGetDefaultInterviewType(t *testing.T) {
 	result := data.GetDefaultInterviewType()
 	assert.Equal(t, data.InterviewTypeGeneral, result)
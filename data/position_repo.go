@@ -0,0 +1,106 @@
+// Position data access (CRUD operations)
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrPositionHasInterviews is returned when deleting a position that interviews still reference
+var ErrPositionHasInterviews = errors.New("position has interviews")
+
+// PositionFilters defines filter options for position queries
+type PositionFilters struct {
+	Title      string // Case-insensitive partial match
+	Department string // Exact match
+	CreatedBy  string // Positions created by this principal
+}
+
+// PositionRepository interface defines the contract for position data access
+type PositionRepository interface {
+	Create(position *Position) error
+	GetByID(id string) (*Position, error)
+	List(limit, offset int, filters PositionFilters) ([]*Position, int64, error)
+	Update(id string, updates map[string]interface{}) error
+	Delete(id string) error
+}
+
+// positionRepository implements PositionRepository interface
+type positionRepository struct {
+	db *gorm.DB
+}
+
+// NewPositionRepository creates a new position repository
+func NewPositionRepository(db *gorm.DB) PositionRepository {
+	return &positionRepository{db: db}
+}
+
+// Create creates a new position
+func (r *positionRepository) Create(position *Position) error {
+	position.CreatedAt = time.Now()
+	position.UpdatedAt = time.Now()
+	return r.db.Create(position).Error
+}
+
+// GetByID retrieves a position by ID
+func (r *positionRepository) GetByID(id string) (*Position, error) {
+	var position Position
+	err := r.db.Where("id = ?", id).First(&position).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("position not found")
+	}
+	return &position, err
+}
+
+// List retrieves positions by title with pagination and filtering
+func (r *positionRepository) List(limit, offset int, filters PositionFilters) ([]*Position, int64, error) {
+	var positions []*Position
+	var total int64
+
+	query := r.db.Model(&Position{})
+	if filters.Title != "" {
+		query = query.Where("title ILIKE ?", "%"+filters.Title+"%")
+	}
+	if filters.Department != "" {
+		query = query.Where("department = ?", filters.Department)
+	}
+	if filters.CreatedBy != "" {
+		query = query.Where("created_by = ?", filters.CreatedBy)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("title ASC").Order("id ASC").Limit(limit).Offset(offset).Find(&positions).Error
+	return positions, total, err
+}
+
+// Update updates a position
+func (r *positionRepository) Update(id string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	result := r.db.Model(&Position{}).Where("id = ?", id).Updates(updates)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return errors.New("position not found")
+	}
+	return result.Error
+}
+
+// Delete deletes a position that no interview references
+func (r *positionRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var interviews int64
+		if err := tx.Model(&Interview{}).Where("position_id = ?", id).Count(&interviews).Error; err != nil {
+			return err
+		}
+		if interviews > 0 {
+			return ErrPositionHasInterviews
+		}
+		result := tx.Where("id = ?", id).Delete(&Position{})
+		if result.Error == nil && result.RowsAffected == 0 {
+			return errors.New("position not found")
+		}
+		return result.Error
+	})
+}
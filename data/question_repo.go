@@ -0,0 +1,165 @@
+// Question bank data access (CRUD operations)
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrQuestionInUse is returned when deleting a question that interviews or templates still reference
+var ErrQuestionInUse = errors.New("question is referenced by interviews or templates")
+
+// QuestionFilters defines filter options for question bank queries
+type QuestionFilters struct {
+	Tags          []string // All tags must be present on the question
+	InterviewType string
+	Difficulty    string
+	Search        string // Full-text search over text, translations and answer notes
+	SortBy        string // "date", "usage" (default: "date")
+}
+
+// QuestionRepository interface defines the contract for question bank data access
+type QuestionRepository interface {
+	Create(question *Question) error
+	GetByID(id string) (*Question, error)
+	GetByIDs(ids []string) ([]*Question, error)
+	List(limit, offset int, filters QuestionFilters) ([]*Question, int64, error)
+	Update(id string, updates map[string]interface{}) error
+	Delete(id string) error
+	IncrementUsage(ids []string) error
+}
+
+// questionRepository implements QuestionRepository interface
+type questionRepository struct {
+	db *gorm.DB
+}
+
+// NewQuestionRepository creates a new question repository
+func NewQuestionRepository(db *gorm.DB) QuestionRepository {
+	return &questionRepository{db: db}
+}
+
+// Create creates a new question bank entry
+func (r *questionRepository) Create(question *Question) error {
+	question.CreatedAt = time.Now()
+	question.UpdatedAt = time.Now()
+	return r.db.Create(question).Error
+}
+
+// GetByID retrieves a question by ID
+func (r *questionRepository) GetByID(id string) (*Question, error) {
+	var question Question
+	err := r.db.Where("id = ?", id).First(&question).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("question not found")
+	}
+	return &question, err
+}
+
+// GetByIDs retrieves questions by ID, preserving the order of the given IDs
+func (r *questionRepository) GetByIDs(ids []string) ([]*Question, error) {
+	if len(ids) == 0 {
+		return []*Question{}, nil
+	}
+
+	var found []*Question
+	if err := r.db.Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Question, len(found))
+	for _, question := range found {
+		byID[question.ID] = question
+	}
+
+	questions := make([]*Question, 0, len(ids))
+	for _, id := range ids {
+		question, exists := byID[id]
+		if !exists {
+			return nil, errors.New("question not found: " + id)
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+// List retrieves questions with pagination, filtering and full-text search
+func (r *questionRepository) List(limit, offset int, filters QuestionFilters) ([]*Question, int64, error) {
+	var questions []*Question
+	var total int64
+
+	query := r.db.Model(&Question{})
+
+	// Apply filters
+	if len(filters.Tags) > 0 {
+		tagsJSON, err := json.Marshal(filters.Tags)
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("tags @> ?::jsonb", string(tagsJSON))
+	}
+	if filters.InterviewType != "" {
+		query = query.Where("type = ?", filters.InterviewType)
+	}
+	if filters.Difficulty != "" {
+		query = query.Where("difficulty = ?", filters.Difficulty)
+	}
+	if filters.Search != "" {
+		// tsvector handles stemmed English matches; ILIKE covers CJK text that the parser can't tokenize
+		like := "%" + filters.Search + "%"
+		query = query.Where(
+			"to_tsvector('english', text || ' ' || coalesce(expected_answer, '')) @@ plainto_tsquery('english', ?) OR text ILIKE ? OR translations::text ILIKE ? OR expected_answer ILIKE ?",
+			filters.Search, like, like, like,
+		)
+	}
+
+	// Get total count
+	query.Count(&total)
+
+	// Apply pagination and ordering
+	order := "created_at DESC"
+	if filters.SortBy == "usage" {
+		order = "usage_count DESC, created_at DESC"
+	}
+	err := query.Order(order).Limit(limit).Offset(offset).Find(&questions).Error
+	return questions, total, err
+}
+
+// Update updates a question
+func (r *questionRepository) Update(id string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	return r.db.Model(&Question{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete deletes a question
+// Questions still referenced by an interview or template are kept, so their answer indices stay stable
+func (r *questionRepository) Delete(id string) error {
+	idJSON, err := json.Marshal([]string{id})
+	if err != nil {
+		return err
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&Interview{}, &InterviewTemplate{}} {
+			var references int64
+			if err := tx.Model(model).Where("question_ids @> ?::jsonb", string(idJSON)).Count(&references).Error; err != nil {
+				return err
+			}
+			if references > 0 {
+				return ErrQuestionInUse
+			}
+		}
+		return tx.Where("id = ?", id).Delete(&Question{}).Error
+	})
+}
+
+// IncrementUsage bumps the usage count of every referenced question
+func (r *questionRepository) IncrementUsage(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&Question{}).Where("id IN ?", ids).
+		UpdateColumn("usage_count", gorm.Expr("usage_count + ?", 1)).Error
+}
@@ -0,0 +1,54 @@
+// Calibration rating data access
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateRating is returned when a rater has already rated an evaluation
+var ErrDuplicateRating = errors.New("rater has already rated this evaluation")
+
+// RatingRepository interface defines the contract for calibration rating data access
+type RatingRepository interface {
+	Create(rating *EvaluationRating) error
+	ListByEvaluationIDs(evaluationIDs []string) ([]*EvaluationRating, error)
+}
+
+// ratingRepository implements RatingRepository interface
+type ratingRepository struct {
+	db *gorm.DB
+}
+
+// NewRatingRepository creates a new calibration rating repository
+func NewRatingRepository(db *gorm.DB) RatingRepository {
+	return &ratingRepository{db: db}
+}
+
+// Create stores a rating, rejecting a second rating by the same rater
+func (r *ratingRepository) Create(rating *EvaluationRating) error {
+	var count int64
+	if err := r.db.Model(&EvaluationRating{}).
+		Where("evaluation_id = ? AND rater = ?", rating.EvaluationID, rating.Rater).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateRating
+	}
+
+	rating.CreatedAt = time.Now()
+	return r.db.Create(rating).Error
+}
+
+// ListByEvaluationIDs retrieves the ratings of the given evaluations, oldest first
+func (r *ratingRepository) ListByEvaluationIDs(evaluationIDs []string) ([]*EvaluationRating, error) {
+	var ratings []*EvaluationRating
+	if len(evaluationIDs) == 0 {
+		return ratings, nil
+	}
+	err := r.db.Where("evaluation_id IN ?", evaluationIDs).Order("created_at ASC").Find(&ratings).Error
+	return ratings, err
+}
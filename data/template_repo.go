@@ -0,0 +1,92 @@
+// Interview template data access (CRUD operations)
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateTemplateName is returned when a template name is already in use
+var ErrDuplicateTemplateName = errors.New("template name already exists")
+
+// TemplateRepository interface defines the contract for interview template data access
+type TemplateRepository interface {
+	Create(template *InterviewTemplate) error
+	GetByID(id string) (*InterviewTemplate, error)
+	List() ([]*InterviewTemplate, error)
+	Update(id string, updates map[string]interface{}) error
+	Delete(id string) error
+}
+
+// templateRepository implements TemplateRepository interface
+type templateRepository struct {
+	db *gorm.DB
+}
+
+// NewTemplateRepository creates a new interview template repository
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+// Create creates a new interview template, rejecting duplicate names
+func (r *templateRepository) Create(template *InterviewTemplate) error {
+	if err := r.checkNameAvailable(template.Name, ""); err != nil {
+		return err
+	}
+
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = time.Now()
+	return r.db.Create(template).Error
+}
+
+// GetByID retrieves an interview template by ID
+func (r *templateRepository) GetByID(id string) (*InterviewTemplate, error) {
+	var template InterviewTemplate
+	err := r.db.Where("id = ?", id).First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("template not found")
+	}
+	return &template, err
+}
+
+// List retrieves all interview templates ordered by name
+func (r *templateRepository) List() ([]*InterviewTemplate, error) {
+	var templates []*InterviewTemplate
+	err := r.db.Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Update updates an interview template, rejecting renames onto an existing name
+func (r *templateRepository) Update(id string, updates map[string]interface{}) error {
+	if name, ok := updates["name"].(string); ok {
+		if err := r.checkNameAvailable(name, id); err != nil {
+			return err
+		}
+	}
+
+	updates["updated_at"] = time.Now()
+	return r.db.Model(&InterviewTemplate{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete deletes an interview template
+func (r *templateRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&InterviewTemplate{}).Error
+}
+
+// checkNameAvailable returns ErrDuplicateTemplateName if another template already uses the name
+func (r *templateRepository) checkNameAvailable(name, excludeID string) error {
+	var count int64
+	query := r.db.Model(&InterviewTemplate{}).Where("name = ?", name)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicateTemplateName
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+// Webhook subscription, outbox and delivery data access
+package data
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookDeliveryFilters narrows a subscription's delivery log; empty fields match every delivery
+type WebhookDeliveryFilters struct {
+	Status    string
+	EventType string
+}
+
+// WebhookRepository interface defines the contract for webhook data access
+type WebhookRepository interface {
+	CreateSubscription(subscription *WebhookSubscription) error
+	GetSubscription(id string) (*WebhookSubscription, error)
+	ListSubscriptions() ([]*WebhookSubscription, error)
+	UpdateSubscription(id string, updates map[string]interface{}) error
+	DeleteSubscription(id string) error
+	GetEvent(id string) (*WebhookEvent, error)
+	DispatchEvents(now time.Time, limit int) (int, error)
+	GetDelivery(id string) (*WebhookDelivery, error)
+	ListDeliveries(subscriptionID string, filters WebhookDeliveryFilters, limit int) ([]*WebhookDelivery, error)
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+	CompleteDelivery(id string, responseStatus int) error
+	FailDelivery(id string, responseStatus int, lastError string, retryAt *time.Time) error
+	ReplayDelivery(id string) (*WebhookDelivery, error)
+}
+
+// webhookRepository implements WebhookRepository interface
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// createWebhookEvents writes outbox events inside the caller's transaction
+func createWebhookEvents(tx *gorm.DB, events []*WebhookEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for _, event := range events {
+		event.CreatedAt = now
+	}
+	return tx.Create(&events).Error
+}
+
+// withWebhookEvents runs fn and records the events in one transaction, so an event is stored
+// exactly when the change it describes is
+func withWebhookEvents(db *gorm.DB, events []*WebhookEvent, fn func(tx *gorm.DB) error) error {
+	if len(events) == 0 {
+		return fn(db)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return createWebhookEvents(tx, events)
+	})
+}
+
+// CreateSubscription stores a new webhook subscription
+func (r *webhookRepository) CreateSubscription(subscription *WebhookSubscription) error {
+	subscription.CreatedAt = time.Now()
+	subscription.UpdatedAt = time.Now()
+	return r.db.Create(subscription).Error
+}
+
+// GetSubscription retrieves a webhook subscription by ID
+func (r *webhookRepository) GetSubscription(id string) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := r.db.Where("id = ?", id).First(&subscription).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("webhook subscription not found")
+	}
+	return &subscription, err
+}
+
+// ListSubscriptions retrieves all webhook subscriptions, oldest first
+func (r *webhookRepository) ListSubscriptions() ([]*WebhookSubscription, error) {
+	var subscriptions []*WebhookSubscription
+	err := r.db.Order("created_at ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// UpdateSubscription updates a webhook subscription
+func (r *webhookRepository) UpdateSubscription(id string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	return r.db.Model(&WebhookSubscription{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteSubscription deletes a webhook subscription and its delivery log
+func (r *webhookRepository) DeleteSubscription(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&WebhookSubscription{}).Error
+	})
+}
+
+// GetEvent retrieves an outbox event by ID
+func (r *webhookRepository) GetEvent(id string) (*WebhookEvent, error) {
+	var event WebhookEvent
+	err := r.db.Where("id = ?", id).First(&event).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("webhook event not found")
+	}
+	return &event, err
+}
+
+// DispatchEvents fans up to limit undispatched outbox events, oldest first, out into one pending
+// delivery due at now per subscribed subscription, returning the number of deliveries created.
+// Locked events are skipped so concurrent dispatchers never fan out the same event twice.
+func (r *webhookRepository) DispatchEvents(now time.Time, limit int) (int, error) {
+	created := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var events []*WebhookEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched_at IS NULL").Order("created_at ASC").Limit(limit).Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		var subscriptions []*WebhookSubscription
+		if err := tx.Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+			return err
+		}
+
+		deliveries := newWebhookDeliveries(events, subscriptions, now)
+		if len(deliveries) > 0 {
+			if err := tx.Create(&deliveries).Error; err != nil {
+				return err
+			}
+		}
+		ids := make([]string, len(events))
+		for i, event := range events {
+			ids[i] = event.ID
+		}
+		created = len(deliveries)
+		return tx.Model(&WebhookEvent{}).Where("id IN ?", ids).Update("dispatched_at", now).Error
+	})
+	return created, err
+}
+
+// newWebhookDeliveries creates a pending delivery of each event to every subscription that wants it
+func newWebhookDeliveries(events []*WebhookEvent, subscriptions []*WebhookSubscription, now time.Time) []*WebhookDelivery {
+	deliveries := []*WebhookDelivery{}
+	for _, event := range events {
+		for _, subscription := range subscriptions {
+			if !subscription.Subscribes(event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, &WebhookDelivery{
+				ID:             GenerateID(),
+				EventID:        event.ID,
+				SubscriptionID: subscription.ID,
+				EventType:      event.Type,
+				Status:         WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+	}
+	return deliveries
+}
+
+// GetDelivery retrieves a webhook delivery by ID
+func (r *webhookRepository) GetDelivery(id string) (*WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	err := r.db.Where("id = ?", id).First(&delivery).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("webhook delivery not found")
+	}
+	return &delivery, err
+}
+
+// ListDeliveries retrieves the most recent deliveries of a subscription matching the filters
+func (r *webhookRepository) ListDeliveries(subscriptionID string, filters WebhookDeliveryFilters, limit int) ([]*WebhookDelivery, error) {
+	var deliveries []*WebhookDelivery
+	query := r.db.Where("subscription_id = ?", subscriptionID)
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.EventType != "" {
+		query = query.Where("event_type = ?", filters.EventType)
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDueDeliveries starts an attempt on up to limit pending deliveries that are due. Each claimed
+// delivery's next attempt moves lease into the future, so one interrupted by a crash is retried
+// once the lease runs out.
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error) {
+	var deliveries []*WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC, (SELECT created_at FROM webhook_events WHERE webhook_events.id = webhook_deliveries.event_id) ASC").
+			Limit(limit).Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+		ids := make([]string, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+			delivery.Attempts++
+			delivery.NextAttemptAt = now.Add(lease)
+			delivery.UpdatedAt = now
+		}
+		return tx.Model(&WebhookDelivery{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": now.Add(lease),
+			"updated_at":      now,
+		}).Error
+	})
+	return deliveries, err
+}
+
+// CompleteDelivery records a successful attempt
+func (r *webhookRepository) CompleteDelivery(id string, responseStatus int) error {
+	now := time.Now()
+	return r.db.Model(&WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          WebhookDeliverySucceeded,
+		"response_status": responseStatus,
+		"last_error":      "",
+		"delivered_at":    now,
+		"updated_at":      now,
+	}).Error
+}
+
+// FailDelivery records a failed attempt, retrying at retryAt or giving up when retryAt is nil
+func (r *webhookRepository) FailDelivery(id string, responseStatus int, lastError string, retryAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status":          WebhookDeliveryFailed,
+		"response_status": responseStatus,
+		"last_error":      lastError,
+		"updated_at":      time.Now(),
+	}
+	if retryAt != nil {
+		updates["status"] = WebhookDeliveryPending
+		updates["next_attempt_at"] = *retryAt
+	}
+	return r.db.Model(&WebhookDelivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// ReplayDelivery queues a new delivery of the same event to the same subscription
+func (r *webhookRepository) ReplayDelivery(id string) (*WebhookDelivery, error) {
+	original, err := r.GetDelivery(id)
+	if err != nil {
+		return nil, err
+	}
+	replay := newWebhookReplay(original, time.Now())
+	if err := r.db.Create(replay).Error; err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// newWebhookReplay creates a pending delivery repeating the original's event and subscription
+func newWebhookReplay(original *WebhookDelivery, now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             GenerateID(),
+		EventID:        original.EventID,
+		SubscriptionID: original.SubscriptionID,
+		EventType:      original.EventType,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		ReplayOf:       original.ID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/zidane0000/AI_Interview_Backend/api"
 )
@@ -18,6 +19,7 @@ type ChatInterviewSessionDTO = api.ChatInterviewSessionDTO
 type SendMessageRequestDTO = api.SendMessageRequestDTO
 type SendMessageResponseDTO = api.SendMessageResponseDTO
 type EvaluationResponseDTO = api.EvaluationResponseDTO
+type JobResponseDTO = api.JobResponseDTO
 
 // Test helper functions for E2E tests
 
@@ -206,7 +208,7 @@ func GetChatSession(t *testing.T, sessionID string) ChatInterviewSessionDTO {
 	return session
 }
 
-// EndChatSession ends a chat session and returns evaluation
+// EndChatSession ends a chat session and returns evaluation once its evaluation job has run
 func EndChatSession(t *testing.T, sessionID string) EvaluationResponseDTO {
 	t.Helper()
 	baseURL := GetAPIBaseURL()
@@ -217,6 +219,60 @@ func EndChatSession(t *testing.T, sessionID string) EvaluationResponseDTO {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Expected status 202, got %d", resp.StatusCode)
+	}
+
+	var job JobResponseDTO
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		t.Fatalf("Failed to decode evaluation job: %v", err)
+	}
+
+	job = WaitForJob(t, job.ID)
+	if job.Status != "succeeded" {
+		t.Fatalf("Evaluation job %s ended %s: %s", job.ID, job.Status, job.LastError)
+	}
+	return GetEvaluation(t, job.EvaluationID)
+}
+
+// WaitForJob polls a background job until it succeeds or is dead-lettered
+func WaitForJob(t *testing.T, jobID string) JobResponseDTO {
+	t.Helper()
+	baseURL := GetAPIBaseURL()
+
+	deadline := time.Now().Add(2 * time.Minute)
+	for {
+		resp, err := http.Get(fmt.Sprintf("%s/jobs/%s", baseURL, jobID))
+		if err != nil {
+			t.Fatalf("Failed to get job: %v", err)
+		}
+		var job JobResponseDTO
+		err = json.NewDecoder(resp.Body).Decode(&job)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Failed to decode job: %v", err)
+		}
+		if job.Status == "succeeded" || job.Status == "dead" {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for job %s (status %s)", jobID, job.Status)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// GetEvaluation retrieves an evaluation by ID
+func GetEvaluation(t *testing.T, evaluationID string) EvaluationResponseDTO {
+	t.Helper()
+	baseURL := GetAPIBaseURL()
+
+	resp, err := http.Get(fmt.Sprintf("%s/evaluation/%s", baseURL, evaluationID))
+	if err != nil {
+		t.Fatalf("Failed to get evaluation: %v", err)
+	}
+	defer resp.Body.Close()
+
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
 	}
@@ -0,0 +1,136 @@
+// Text extraction from uploaded resume documents
+package files
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Supported document content types
+const (
+	ContentTypePDF  = "application/pdf"
+	ContentTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	ContentTypeText = "text/plain"
+)
+
+// maxExtractedTextSize caps the extracted text kept from a document, well above any real resume
+const maxExtractedTextSize = 1 << 20
+
+// ErrUnsupportedContentType is returned for documents that are not PDF, DOCX or plain text
+var ErrUnsupportedContentType = errors.New("unsupported content type: must be PDF, DOCX or plain text")
+
+// ErrNoText is returned when a document contains no extractable text, e.g. a scanned PDF
+var ErrNoText = errors.New("no text could be extracted from the document")
+
+// DetectContentType determines a document's type from its leading bytes, using the declared
+// content type and file name only to tell DOCX apart from other ZIP archives. It returns
+// ErrUnsupportedContentType when the content is not a supported document or does not match
+// a more specific declared type.
+func DetectContentType(declared, filename string, head []byte) (string, error) {
+	declared = strings.ToLower(strings.TrimSpace(strings.Split(declared, ";")[0]))
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	var detected string
+	switch {
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		detected = ContentTypePDF
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")):
+		if declared != ContentTypeDOCX && ext != ".docx" {
+			return "", ErrUnsupportedContentType
+		}
+		detected = ContentTypeDOCX
+	case utf8.Valid(trimIncompleteRune(head)) && !bytes.ContainsRune(head, 0):
+		detected = ContentTypeText
+	default:
+		return "", ErrUnsupportedContentType
+	}
+
+	// A generic or missing declared type is fine; a specific one must agree with the content
+	switch declared {
+	case "", "application/octet-stream", detected:
+		return detected, nil
+	}
+	return "", fmt.Errorf("%w: content is %s but was declared as %s", ErrUnsupportedContentType, detected, declared)
+}
+
+// trimIncompleteRune drops a UTF-8 sequence cut off at the end of a sniffed prefix
+func trimIncompleteRune(b []byte) []byte {
+	for i := 1; i < utf8.UTFMax && i <= len(b); i++ {
+		if utf8.RuneStart(b[len(b)-i]) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return b[:len(b)-i]
+			}
+			break
+		}
+	}
+	return b
+}
+
+// ExtractText returns the normalized text of a document of the given content type, truncated
+// to maxExtractedTextSize bytes
+func ExtractText(contentType string, content []byte) (string, error) {
+	var text string
+	var err error
+	switch contentType {
+	case ContentTypePDF:
+		text, err = extractPDFText(content)
+	case ContentTypeDOCX:
+		text, err = extractDOCXText(content)
+	case ContentTypeText:
+		if !utf8.Valid(content) {
+			return "", errors.New("text file is not valid UTF-8")
+		}
+		text = strings.TrimPrefix(string(content), "\ufeff")
+	default:
+		return "", ErrUnsupportedContentType
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = normalizeText(text)
+	if text == "" {
+		return "", ErrNoText
+	}
+	return truncateText(text, maxExtractedTextSize), nil
+}
+
+// truncateText cuts text to at most limit bytes without splitting a character
+func truncateText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
+
+// normalizeText collapses runs of spaces within lines and runs of blank lines, and drops control characters
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.IsControl(r)
+		}), " ")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,201 @@
+package files
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// buildPDF wraps content streams in a minimal PDF, compressing those marked for Flate
+func buildPDF(streams []string, compress bool) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	for i, stream := range streams {
+		data := []byte(stream)
+		filter := ""
+		if compress {
+			var z bytes.Buffer
+			w := zlib.NewWriter(&z)
+			_, _ = w.Write(data)
+			_ = w.Close()
+			data = z.Bytes()
+			filter = " /Filter /FlateDecode"
+		}
+		fmt.Fprintf(&b, "%d 0 obj\n<< /Length %d%s >>\nstream\n", i+4, len(data), filter)
+		b.Write(data)
+		b.WriteString("\nendstream\nendobj\n")
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+// buildDOCX creates a minimal DOCX whose body is the given document.xml
+func buildDOCX(documentXML string) []byte {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	f, _ := w.Create("word/document.xml")
+	_, _ = f.Write([]byte(documentXML))
+	_ = w.Close()
+	return b.Bytes()
+}
+
+func TestExtractText_PDF(t *testing.T) {
+	content := `BT /F1 12 Tf 72 720 Td (Jane Doe) Tj 0 -14 Td (Senior Engineer \(Payments\)) Tj ET
+BT /F1 10 Tf 72 680 Td [(Led the pay)-20(ment migration)-300(at Acme)] TJ T* <FEFF00C9006C0065006E0061> Tj ET`
+
+	for _, compress := range []bool{false, true} {
+		text, err := ExtractText(ContentTypePDF, buildPDF([]string{content}, compress))
+		if err != nil {
+			t.Fatalf("compress=%v: ExtractText failed: %v", compress, err)
+		}
+		want := "Jane Doe\nSenior Engineer (Payments)\nLed the payment migration at Acme\nÉlena"
+		if text != want {
+			t.Errorf("compress=%v: expected %q, got %q", compress, want, text)
+		}
+	}
+
+	// Scanned resumes have no text operators
+	if _, err := ExtractText(ContentTypePDF, buildPDF([]string{"q 100 0 0 100 0 0 cm /Im1 Do Q"}, true)); !errors.Is(err, ErrNoText) {
+		t.Errorf("expected ErrNoText for a PDF without text, got %v", err)
+	}
+}
+
+func TestExtractText_PDFDecompressionBudget(t *testing.T) {
+	// Each blank stream inflates to 4 MiB from a few KiB; together they exceed the document budget
+	streams := []string{"BT (Jane Doe) Tj ET"}
+	for i := 0; i < maxPDFDecompressedSize/(4<<20)+1; i++ {
+		streams = append(streams, strings.Repeat(" ", 4<<20))
+	}
+	streams = append(streams, "BT (Hidden) Tj ET")
+	pdf := buildPDF(streams, true)
+	if len(pdf) > 1<<20 {
+		t.Fatalf("expected a small compressed PDF, got %d bytes", len(pdf))
+	}
+
+	text, err := ExtractText(ContentTypePDF, pdf)
+	if err != nil {
+		t.Fatalf("ExtractText failed: %v", err)
+	}
+	if text != "Jane Doe" {
+		t.Errorf("expected extraction to stop at the budget, got %q", text)
+	}
+}
+
+func TestExtractText_DOCX(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Jane</w:t></w:r><w:r><w:t xml:space="preserve"> Doe</w:t></w:r></w:p>
+<w:p></w:p><w:p></w:p>
+<w:p><w:r><w:t>Go</w:t><w:tab/><w:t>5 years</w:t><w:br/><w:t>Kubernetes &amp; AWS</w:t></w:r></w:p>
+</w:body></w:document>`
+
+	text, err := ExtractText(ContentTypeDOCX, buildDOCX(doc))
+	if err != nil {
+		t.Fatalf("ExtractText failed: %v", err)
+	}
+	if want := "Jane Doe\n\nGo 5 years\nKubernetes & AWS"; text != want {
+		t.Errorf("expected %q, got %q", want, text)
+	}
+
+	if _, err := ExtractText(ContentTypeDOCX, []byte("PK\x03\x04 not a zip")); err == nil {
+		t.Error("expected an error for a corrupt DOCX")
+	}
+}
+
+func TestExtractText_PlainText(t *testing.T) {
+	text, err := ExtractText(ContentTypeText, []byte("\ufeffJane   Doe\r\n\r\n\r\n\tBackend engineer \n"))
+	if err != nil {
+		t.Fatalf("ExtractText failed: %v", err)
+	}
+	if want := "Jane Doe\n\nBackend engineer"; text != want {
+		t.Errorf("expected %q, got %q", want, text)
+	}
+	if _, err := ExtractText(ContentTypeText, []byte(" \n\t ")); !errors.Is(err, ErrNoText) {
+		t.Errorf("expected ErrNoText for a blank file, got %v", err)
+	}
+	if _, err := ExtractText("image/png", []byte("x")); !errors.Is(err, ErrUnsupportedContentType) {
+		t.Errorf("expected ErrUnsupportedContentType, got %v", err)
+	}
+
+	// Long text is cut without splitting a character
+	text, err = ExtractText(ContentTypeText, []byte("a"+strings.Repeat("é", maxExtractedTextSize)))
+	if err != nil {
+		t.Fatalf("ExtractText failed: %v", err)
+	}
+	if len(text) != maxExtractedTextSize-1 || !utf8.ValidString(text) {
+		t.Errorf("expected valid text of %d bytes, got %d bytes", maxExtractedTextSize-1, len(text))
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	docx := buildDOCX("<w:document/>")
+	tests := []struct {
+		name, declared, filename string
+		content                  []byte
+		want                     string
+	}{
+		{"pdf", "application/pdf", "cv.pdf", []byte("%PDF-1.7\n"), ContentTypePDF},
+		{"pdf without declared type", "", "cv", []byte("%PDF-1.7\n"), ContentTypePDF},
+		{"docx by declared type", ContentTypeDOCX, "cv", docx, ContentTypeDOCX},
+		{"docx by extension", "application/octet-stream", "CV.DOCX", docx, ContentTypeDOCX},
+		{"text", "text/plain; charset=utf-8", "cv.txt", []byte("Jane Doe 履歷"), ContentTypeText},
+		{"text cut mid-rune", "", "cv.txt", []byte("Jane 履歷")[:7], ContentTypeText},
+		{"other zip", "application/zip", "cv.zip", docx, ""},
+		{"binary", "", "cv.bin", []byte{0x89, 'P', 'N', 'G', 0, 0}, ""},
+		{"mismatched declared type", "application/pdf", "cv.pdf", []byte("plain text"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectContentType(tt.declared, tt.filename, tt.content)
+			if tt.want == "" {
+				if !errors.Is(err, ErrUnsupportedContentType) {
+					t.Errorf("expected ErrUnsupportedContentType, got %q, %v", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("expected %q, got %q, %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore failed: %v", err)
+	}
+
+	size, err := store.Put("resumes/abc.pdf", strings.NewReader("content"))
+	if err != nil || size != 7 {
+		t.Fatalf("expected 7 bytes stored, got %d, %v", size, err)
+	}
+	rc, err := store.Get("resumes/abc.pdf")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	var got bytes.Buffer
+	_, _ = got.ReadFrom(rc)
+	rc.Close()
+	if got.String() != "content" {
+		t.Errorf("expected stored content, got %q", got.String())
+	}
+
+	if err := store.Delete("resumes/abc.pdf"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get("resumes/abc.pdf"); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound after delete, got %v", err)
+	}
+	if err := store.Delete("resumes/abc.pdf"); err != nil {
+		t.Errorf("expected deleting a missing blob to succeed, got %v", err)
+	}
+	if _, err := store.Put("../escape", strings.NewReader("x")); err == nil {
+		t.Error("expected keys escaping the root to be rejected")
+	}
+}
@@ -0,0 +1,341 @@
+// Text extraction from PDF documents
+package files
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Decompression limits that guard against zip bombs: per stream, and across all streams of a
+// document since a small upload can hold hundreds of compressed streams
+const (
+	maxPDFStreamSize       = 16 << 20
+	maxPDFDecompressedSize = 64 << 20
+)
+
+// extractPDFText reads the text shown by the content streams of a PDF. It handles uncompressed
+// and Flate-compressed streams with text in standard or UTF-16 encodings, which covers resumes
+// exported by common word processors; text drawn with embedded CID fonts or as images (scans)
+// is not recovered. Streams past the decompression budget are ignored.
+func extractPDFText(content []byte) (string, error) {
+	if !bytes.HasPrefix(content, []byte("%PDF-")) {
+		return "", errors.New("invalid PDF file")
+	}
+
+	var text strings.Builder
+	rest := content
+	budget := int64(maxPDFDecompressedSize)
+	for budget > 0 {
+		start := bytes.Index(rest, []byte("stream"))
+		if start < 0 {
+			break
+		}
+		// "endstream" also contains "stream"; only a keyword followed by an end of line starts a stream
+		dataStart := start + len("stream")
+		if start >= 3 && string(rest[start-3:start]) == "end" {
+			rest = rest[dataStart:]
+			continue
+		}
+		if bytes.HasPrefix(rest[dataStart:], []byte("\r\n")) {
+			dataStart += 2
+		} else if bytes.HasPrefix(rest[dataStart:], []byte("\n")) {
+			dataStart++
+		} else {
+			rest = rest[dataStart:]
+			continue
+		}
+		end := bytes.Index(rest[dataStart:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		dict := streamDictionary(rest[:start])
+		data := rest[dataStart : dataStart+end]
+		rest = rest[dataStart+end+len("endstream"):]
+
+		decoded, ok := decodePDFStream(dict, data, min(maxPDFStreamSize, budget))
+		if !ok {
+			continue
+		}
+		budget -= int64(len(decoded))
+		text.WriteString(pdfContentText(decoded))
+	}
+	return text.String(), nil
+}
+
+// streamDictionary returns the dictionary of the stream whose keyword follows prefix: the text
+// after the enclosing object's "obj" keyword
+func streamDictionary(prefix []byte) string {
+	if i := bytes.LastIndex(prefix, []byte("obj")); i >= 0 {
+		prefix = prefix[i:]
+	}
+	return string(prefix)
+}
+
+// decodePDFStream returns a stream's decoded data, inflating at most limit bytes, or false for
+// streams that cannot hold page text
+func decodePDFStream(dict string, data []byte, limit int64) ([]byte, bool) {
+	for _, skip := range []string{"/Image", "/XRef", "/ObjStm", "/Metadata", "/Length1", "/FontFile"} {
+		if strings.Contains(dict, skip) {
+			return nil, false
+		}
+	}
+	if !strings.Contains(dict, "/Filter") {
+		return data, true
+	}
+	// Only a lone Flate filter is supported; other filters compress images or fonts
+	if !strings.Contains(dict, "/FlateDecode") || strings.Count(dict, "Decode") > 1 {
+		return nil, false
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+	// Keep whatever was inflated before an error; truncated streams still yield their leading text
+	decoded, _ := io.ReadAll(io.LimitReader(reader, limit))
+	return decoded, len(decoded) > 0
+}
+
+// pdfContentText interprets the text operators of a content stream. Text objects end a line,
+// as do line moves, and large negative kerning in TJ arrays separates words.
+func pdfContentText(content []byte) string {
+	var text strings.Builder
+	var operands []interface{} // string, float64 or []interface{} for arrays
+	var arrays [][]interface{}
+	push := func(v interface{}) {
+		if len(arrays) > 0 {
+			arrays[len(arrays)-1] = append(arrays[len(arrays)-1], v)
+			return
+		}
+		operands = append(operands, v)
+	}
+	// Consecutive line breaks collapse so moving to a new text object does not add blank lines
+	newline := func() {
+		if text.Len() > 0 && !strings.HasSuffix(text.String(), "\n") {
+			text.WriteByte('\n')
+		}
+	}
+	writeStrings := func(values []interface{}) {
+		for _, v := range values {
+			switch value := v.(type) {
+			case string:
+				text.WriteString(value)
+			case float64:
+				if value < -200 {
+					text.WriteByte(' ')
+				}
+			case []interface{}:
+				for _, item := range value {
+					switch element := item.(type) {
+					case string:
+						text.WriteString(element)
+					case float64:
+						if element < -200 {
+							text.WriteByte(' ')
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case isPDFWhitespace(c):
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			s, next := readPDFLiteralString(content, i)
+			push(decodePDFString(s))
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] == '<', c == '>' && i+1 < len(content) && content[i+1] == '>':
+			i += 2 // Dictionaries only appear as operands of operators that draw no text
+		case c == '<':
+			end := bytes.IndexByte(content[i:], '>')
+			if end < 0 {
+				return text.String()
+			}
+			push(decodePDFString(decodePDFHex(content[i+1 : i+end])))
+			i += end + 1
+		case c == '[':
+			arrays = append(arrays, []interface{}{})
+			i++
+		case c == ']':
+			if len(arrays) > 0 {
+				array := arrays[len(arrays)-1]
+				arrays = arrays[:len(arrays)-1]
+				push(array)
+			}
+			i++
+		case c == '/':
+			i++
+			for i < len(content) && !isPDFWhitespace(content[i]) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			push(nil)
+		default:
+			start := i
+			for i < len(content) && !isPDFWhitespace(content[i]) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			if i == start {
+				i++ // Stray delimiter such as ')' or '}'
+				continue
+			}
+			token := string(content[start:i])
+			if n, err := strconv.ParseFloat(token, 64); err == nil {
+				push(n)
+				continue
+			}
+
+			switch token {
+			case "Tj", "TJ":
+				writeStrings(operands)
+			case "'", "\"":
+				newline()
+				writeStrings(operands)
+			case "T*", "ET", "Tm":
+				newline()
+			case "Td", "TD":
+				if len(operands) == 2 {
+					if dy, ok := operands[1].(float64); ok && dy != 0 {
+						newline()
+					} else {
+						text.WriteByte(' ')
+					}
+				}
+			case "ID":
+				// Skip inline image data up to its EI operator
+				if end := bytes.Index(content[i:], []byte("EI")); end >= 0 {
+					i += end + 2
+				} else {
+					i = len(content)
+				}
+			}
+			operands = operands[:0]
+			arrays = arrays[:0]
+		}
+	}
+	return text.String()
+}
+
+// readPDFLiteralString reads the parenthesized string starting at content[start], handling nested
+// parentheses and escapes, and returns its bytes and the index after its closing parenthesis
+func readPDFLiteralString(content []byte, start int) ([]byte, int) {
+	var s []byte
+	depth := 0
+	for i := start + 1; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '\\':
+			i++
+			if i >= len(content) {
+				return s, i
+			}
+			switch e := content[i]; e {
+			case 'n':
+				s = append(s, '\n')
+			case 'r':
+				s = append(s, '\r')
+			case 't':
+				s = append(s, '\t')
+			case 'b', 'f':
+			case '\r':
+				if i+1 < len(content) && content[i+1] == '\n' {
+					i++
+				}
+			case '\n':
+				// Escaped end of line continues the string
+			default:
+				if e >= '0' && e <= '7' {
+					n := 0
+					for j := 0; j < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; j++ {
+						n = n*8 + int(content[i]-'0')
+						i++
+					}
+					i--
+					s = append(s, byte(n))
+				} else {
+					s = append(s, e)
+				}
+			}
+		case '(':
+			depth++
+			s = append(s, c)
+		case ')':
+			if depth == 0 {
+				return s, i + 1
+			}
+			depth--
+			s = append(s, c)
+		default:
+			s = append(s, c)
+		}
+	}
+	return s, len(content)
+}
+
+// decodePDFHex decodes the digits of a hex string, treating a missing final digit as zero
+func decodePDFHex(digits []byte) []byte {
+	var s []byte
+	pending := -1
+	for _, c := range digits {
+		var v int
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'a' && c <= 'f':
+			v = int(c-'a') + 10
+		case c >= 'A' && c <= 'F':
+			v = int(c-'A') + 10
+		default:
+			continue
+		}
+		if pending < 0 {
+			pending = v
+			continue
+		}
+		s = append(s, byte(pending<<4|v))
+		pending = -1
+	}
+	if pending >= 0 {
+		s = append(s, byte(pending<<4))
+	}
+	return s
+}
+
+// decodePDFString converts string bytes to text: UTF-16BE when they start with a byte order mark,
+// otherwise one character per byte as in PDFDocEncoding's Latin-1 range
+func decodePDFString(s []byte) string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		units := make([]uint16, 0, len(s)/2)
+		for i := 2; i+1 < len(s); i += 2 {
+			units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	runes := make([]rune, len(s))
+	for i, b := range s {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// isPDFWhitespace reports whether c is a PDF whitespace character
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == 0
+}
+
+// isPDFDelimiter reports whether c is a PDF delimiter character
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
@@ -17,7 +17,7 @@ import (
 )
 
 // gracefulShutdown handles graceful shutdown of the application
-func gracefulShutdown(server *http.Server, timeout time.Duration) {
+func gracefulShutdown(server *http.Server, sweeper *api.SessionSweeper, jobWorkers *api.JobWorkerPool, webhooks *api.WebhookDispatcher, timeout time.Duration) {
 	// Create a channel to receive OS signals
 	quit := make(chan os.Signal, 1)
 
@@ -38,6 +38,11 @@ func gracefulShutdown(server *http.Server, timeout time.Duration) {
 
 	// Additional cleanup operations
 	utils.Infof("Performing cleanup operations...")
+	// Stop background work before the store it uses is closed; the sweeper queues jobs, so it stops first.
+	// Queued jobs and pending webhook deliveries stay in the store and run after the next start.
+	sweeper.Stop()
+	jobWorkers.Stop()
+	webhooks.Stop()
 	// Close database connections if available
 	if data.GlobalStore != nil {
 		if err := data.GlobalStore.Close(); err != nil {
@@ -84,12 +89,10 @@ func main() {
 	// AI clients are now created per-request using the factory pattern
 	// No global initialization needed - clients are created by handlers as needed
 	utils.Infof("AI client factory will be used for per-request client creation")
-	// TODO: Initialize file upload directory and permissions
-	// if err := os.MkdirAll(cfg.UploadPath, 0755); err != nil {
-	//     utils.Errorf("failed to create upload directory: %v", err)
-	// }
-	// Set up router with injected config
-	router := api.SetupRouter(cfg)
+	// Build the handler dependencies once, creating the upload directory, and share them with the
+	// router and the background workers
+	deps := api.NewHandlerDependenciesFromConfig(cfg)
+	router := api.NewRouter(deps)
 	// TODO: Add HTTPS support with TLS configuration
 	// TODO: Add health check endpoints
 	// TODO: Add metrics and monitoring endpoints
@@ -113,6 +116,18 @@ func main() {
 	utils.Infof("Server successfully started on port %s", cfg.Port)
 	utils.Infof("Frontend can now connect to: http://localhost:%s", cfg.Port)
 
+	// Expire idle chat sessions in the background
+	sweeper := api.NewSessionSweeper(cfg, deps)
+	sweeper.Start()
+
+	// Run queued evaluations in the background
+	jobWorkers := api.NewJobWorkerPool(cfg, deps)
+	jobWorkers.Start()
+
+	// Deliver webhook events recorded in the outbox
+	webhooks := api.NewWebhookDispatcher(cfg)
+	webhooks.Start()
+
 	// Start graceful shutdown handler (this will block until shutdown signal)
-	gracefulShutdown(server, cfg.ShutdownTimeout)
+	gracefulShutdown(server, sweeper, jobWorkers, webhooks, cfg.ShutdownTimeout)
 }
@@ -0,0 +1,106 @@
+// Inter-rater agreement and correlation statistics
+package utils
+
+import "math"
+
+// CohenKappa returns Cohen's kappa for two raters' categorical labels on the same items.
+// ok is false when the inputs differ in length, are empty, or chance agreement is total.
+func CohenKappa(a, b []string) (kappa float64, ok bool) {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0, false
+	}
+
+	n := float64(len(a))
+	var agreed float64
+	countsA := make(map[string]float64)
+	countsB := make(map[string]float64)
+	for i := range a {
+		if a[i] == b[i] {
+			agreed++
+		}
+		countsA[a[i]]++
+		countsB[b[i]]++
+	}
+
+	observed := agreed / n
+	var expected float64
+	for label, countA := range countsA {
+		expected += (countA / n) * (countsB[label] / n)
+	}
+	if expected >= 1 {
+		return 0, false
+	}
+	return (observed - expected) / (1 - expected), true
+}
+
+// FleissKappa returns Fleiss' kappa for items rated by two or more raters. Each item maps a
+// category to the number of raters who chose it; the number of raters may vary per item.
+// Items with fewer than two ratings are ignored. ok is false when no item qualifies or
+// chance agreement is total.
+func FleissKappa(items []map[string]int) (kappa float64, ok bool) {
+	var itemAgreement, totalRatings float64
+	var itemCount int
+	categoryTotals := make(map[string]float64)
+
+	for _, item := range items {
+		var n float64
+		for _, count := range item {
+			n += float64(count)
+		}
+		if n < 2 {
+			continue
+		}
+
+		var agreeingPairs float64
+		for category, count := range item {
+			c := float64(count)
+			agreeingPairs += c * (c - 1)
+			categoryTotals[category] += c
+		}
+		itemAgreement += agreeingPairs / (n * (n - 1))
+		totalRatings += n
+		itemCount++
+	}
+	if itemCount == 0 {
+		return 0, false
+	}
+
+	observed := itemAgreement / float64(itemCount)
+	var expected float64
+	for _, total := range categoryTotals {
+		p := total / totalRatings
+		expected += p * p
+	}
+	if expected >= 1 {
+		return 0, false
+	}
+	return (observed - expected) / (1 - expected), true
+}
+
+// PearsonCorrelation returns the Pearson correlation coefficient of paired samples.
+// ok is false with fewer than two pairs or when either sample has no variance.
+func PearsonCorrelation(x, y []float64) (r float64, ok bool) {
+	if len(x) != len(y) || len(x) < 2 {
+		return 0, false
+	}
+
+	n := float64(len(x))
+	var sumX, sumY float64
+	for i := range x {
+		sumX += x[i]
+		sumY += y[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
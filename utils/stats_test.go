@@ -0,0 +1,61 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zidane0000/AI_Interview_Backend/utils"
+)
+
+func TestCohenKappa(t *testing.T) {
+	// 4 of 6 agree; both raters label half "advance": po=2/3, pe=1/2
+	a := []string{"advance", "advance", "advance", "reject", "reject", "reject"}
+	b := []string{"advance", "advance", "reject", "reject", "reject", "advance"}
+	kappa, ok := utils.CohenKappa(a, b)
+	if !ok || math.Abs(kappa-1.0/3.0) > 1e-9 {
+		t.Errorf("expected kappa 1/3, got %v (ok=%v)", kappa, ok)
+	}
+
+	if kappa, ok := utils.CohenKappa(a, a); !ok || kappa != 1 {
+		t.Errorf("expected perfect agreement kappa 1, got %v", kappa)
+	}
+	if _, ok := utils.CohenKappa([]string{"hold", "hold"}, []string{"hold", "hold"}); ok {
+		t.Error("expected undefined kappa when every label is the same")
+	}
+	if _, ok := utils.CohenKappa(a, b[:3]); ok {
+		t.Error("expected undefined kappa for mismatched lengths")
+	}
+}
+
+func TestFleissKappa(t *testing.T) {
+	items := []map[string]int{
+		{"advance": 3},
+		{"reject": 3},
+		{"advance": 2, "reject": 1},
+		{"hold": 1}, // single rating is ignored
+	}
+	kappa, ok := utils.FleissKappa(items)
+	// P = (1 + 1 + 1/3) / 3 = 7/9; p_advance = 5/9, p_reject = 4/9; Pe = 41/81
+	expected := (7.0/9.0 - 41.0/81.0) / (1 - 41.0/81.0)
+	if !ok || math.Abs(kappa-expected) > 1e-9 {
+		t.Errorf("expected kappa %v, got %v (ok=%v)", expected, kappa, ok)
+	}
+
+	if _, ok := utils.FleissKappa([]map[string]int{{"hold": 1}}); ok {
+		t.Error("expected undefined kappa without multi-rated items")
+	}
+}
+
+func TestPearsonCorrelation(t *testing.T) {
+	r, ok := utils.PearsonCorrelation([]float64{0.1, 0.5, 0.9}, []float64{0.2, 0.6, 1.0})
+	if !ok || math.Abs(r-1) > 1e-9 {
+		t.Errorf("expected perfect correlation, got %v (ok=%v)", r, ok)
+	}
+	r, ok = utils.PearsonCorrelation([]float64{0.1, 0.5, 0.9}, []float64{0.9, 0.5, 0.1})
+	if !ok || math.Abs(r+1) > 1e-9 {
+		t.Errorf("expected perfect negative correlation, got %v (ok=%v)", r, ok)
+	}
+	if _, ok := utils.PearsonCorrelation([]float64{0.5, 0.5}, []float64{0.1, 0.9}); ok {
+		t.Error("expected undefined correlation without variance")
+	}
+}